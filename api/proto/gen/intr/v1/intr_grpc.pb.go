@@ -0,0 +1,516 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: intr/v1/intr.proto
+
+package intrv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	InteractiveService_IncrReadCnt_FullMethodName          = "/intr.v1.InteractiveService/IncrReadCnt"
+	InteractiveService_Like_FullMethodName                 = "/intr.v1.InteractiveService/Like"
+	InteractiveService_CancelLike_FullMethodName           = "/intr.v1.InteractiveService/CancelLike"
+	InteractiveService_React_FullMethodName                = "/intr.v1.InteractiveService/React"
+	InteractiveService_CancelReaction_FullMethodName       = "/intr.v1.InteractiveService/CancelReaction"
+	InteractiveService_AddCollectionItem_FullMethodName    = "/intr.v1.InteractiveService/AddCollectionItem"
+	InteractiveService_CancelCollectionItem_FullMethodName = "/intr.v1.InteractiveService/CancelCollectionItem"
+	InteractiveService_MoveCollectionItem_FullMethodName   = "/intr.v1.InteractiveService/MoveCollectionItem"
+	InteractiveService_Get_FullMethodName                  = "/intr.v1.InteractiveService/Get"
+	InteractiveService_GetByIds_FullMethodName             = "/intr.v1.InteractiveService/GetByIds"
+	InteractiveService_LikeRecords_FullMethodName          = "/intr.v1.InteractiveService/LikeRecords"
+	InteractiveService_CollectRecords_FullMethodName       = "/intr.v1.InteractiveService/CollectRecords"
+)
+
+// InteractiveServiceClient is the client API for InteractiveService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type InteractiveServiceClient interface {
+	IncrReadCnt(ctx context.Context, in *IncrReadCntRequest, opts ...grpc.CallOption) (*IncrReadCntResponse, error)
+	Like(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*LikeResponse, error)
+	CancelLike(ctx context.Context, in *CancelLikeRequest, opts ...grpc.CallOption) (*CancelLikeResponse, error)
+	React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error)
+	CancelReaction(ctx context.Context, in *CancelReactionRequest, opts ...grpc.CallOption) (*CancelReactionResponse, error)
+	AddCollectionItem(ctx context.Context, in *AddCollectionItemRequest, opts ...grpc.CallOption) (*AddCollectionItemResponse, error)
+	CancelCollectionItem(ctx context.Context, in *CancelCollectionItemRequest, opts ...grpc.CallOption) (*CancelCollectionItemResponse, error)
+	MoveCollectionItem(ctx context.Context, in *MoveCollectionItemRequest, opts ...grpc.CallOption) (*MoveCollectionItemResponse, error)
+	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
+	GetByIds(ctx context.Context, in *GetByIdsRequest, opts ...grpc.CallOption) (*GetByIdsResponse, error)
+	LikeRecords(ctx context.Context, in *LikeRecordsRequest, opts ...grpc.CallOption) (*LikeRecordsResponse, error)
+	CollectRecords(ctx context.Context, in *CollectRecordsRequest, opts ...grpc.CallOption) (*CollectRecordsResponse, error)
+}
+
+type interactiveServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewInteractiveServiceClient(cc grpc.ClientConnInterface) InteractiveServiceClient {
+	return &interactiveServiceClient{cc}
+}
+
+func (c *interactiveServiceClient) IncrReadCnt(ctx context.Context, in *IncrReadCntRequest, opts ...grpc.CallOption) (*IncrReadCntResponse, error) {
+	out := new(IncrReadCntResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_IncrReadCnt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) Like(ctx context.Context, in *LikeRequest, opts ...grpc.CallOption) (*LikeResponse, error) {
+	out := new(LikeResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_Like_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CancelLike(ctx context.Context, in *CancelLikeRequest, opts ...grpc.CallOption) (*CancelLikeResponse, error) {
+	out := new(CancelLikeResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CancelLike_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) React(ctx context.Context, in *ReactRequest, opts ...grpc.CallOption) (*ReactResponse, error) {
+	out := new(ReactResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_React_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CancelReaction(ctx context.Context, in *CancelReactionRequest, opts ...grpc.CallOption) (*CancelReactionResponse, error) {
+	out := new(CancelReactionResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CancelReaction_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) AddCollectionItem(ctx context.Context, in *AddCollectionItemRequest, opts ...grpc.CallOption) (*AddCollectionItemResponse, error) {
+	out := new(AddCollectionItemResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_AddCollectionItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CancelCollectionItem(ctx context.Context, in *CancelCollectionItemRequest, opts ...grpc.CallOption) (*CancelCollectionItemResponse, error) {
+	out := new(CancelCollectionItemResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CancelCollectionItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) MoveCollectionItem(ctx context.Context, in *MoveCollectionItemRequest, opts ...grpc.CallOption) (*MoveCollectionItemResponse, error) {
+	out := new(MoveCollectionItemResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_MoveCollectionItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error) {
+	out := new(GetResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_Get_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) GetByIds(ctx context.Context, in *GetByIdsRequest, opts ...grpc.CallOption) (*GetByIdsResponse, error) {
+	out := new(GetByIdsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_GetByIds_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) LikeRecords(ctx context.Context, in *LikeRecordsRequest, opts ...grpc.CallOption) (*LikeRecordsResponse, error) {
+	out := new(LikeRecordsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_LikeRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *interactiveServiceClient) CollectRecords(ctx context.Context, in *CollectRecordsRequest, opts ...grpc.CallOption) (*CollectRecordsResponse, error) {
+	out := new(CollectRecordsResponse)
+	err := c.cc.Invoke(ctx, InteractiveService_CollectRecords_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// InteractiveServiceServer is the server API for InteractiveService service.
+// All implementations must embed UnimplementedInteractiveServiceServer
+// for forward compatibility
+type InteractiveServiceServer interface {
+	IncrReadCnt(context.Context, *IncrReadCntRequest) (*IncrReadCntResponse, error)
+	Like(context.Context, *LikeRequest) (*LikeResponse, error)
+	CancelLike(context.Context, *CancelLikeRequest) (*CancelLikeResponse, error)
+	React(context.Context, *ReactRequest) (*ReactResponse, error)
+	CancelReaction(context.Context, *CancelReactionRequest) (*CancelReactionResponse, error)
+	AddCollectionItem(context.Context, *AddCollectionItemRequest) (*AddCollectionItemResponse, error)
+	CancelCollectionItem(context.Context, *CancelCollectionItemRequest) (*CancelCollectionItemResponse, error)
+	MoveCollectionItem(context.Context, *MoveCollectionItemRequest) (*MoveCollectionItemResponse, error)
+	Get(context.Context, *GetRequest) (*GetResponse, error)
+	GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error)
+	LikeRecords(context.Context, *LikeRecordsRequest) (*LikeRecordsResponse, error)
+	CollectRecords(context.Context, *CollectRecordsRequest) (*CollectRecordsResponse, error)
+	mustEmbedUnimplementedInteractiveServiceServer()
+}
+
+// UnimplementedInteractiveServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedInteractiveServiceServer struct {
+}
+
+func (UnimplementedInteractiveServiceServer) IncrReadCnt(context.Context, *IncrReadCntRequest) (*IncrReadCntResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method IncrReadCnt not implemented")
+}
+func (UnimplementedInteractiveServiceServer) Like(context.Context, *LikeRequest) (*LikeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Like not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CancelLike(context.Context, *CancelLikeRequest) (*CancelLikeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelLike not implemented")
+}
+func (UnimplementedInteractiveServiceServer) React(context.Context, *ReactRequest) (*ReactResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method React not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CancelReaction(context.Context, *CancelReactionRequest) (*CancelReactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelReaction not implemented")
+}
+func (UnimplementedInteractiveServiceServer) AddCollectionItem(context.Context, *AddCollectionItemRequest) (*AddCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCollectionItem not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CancelCollectionItem(context.Context, *CancelCollectionItemRequest) (*CancelCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelCollectionItem not implemented")
+}
+func (UnimplementedInteractiveServiceServer) MoveCollectionItem(context.Context, *MoveCollectionItemRequest) (*MoveCollectionItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveCollectionItem not implemented")
+}
+func (UnimplementedInteractiveServiceServer) Get(context.Context, *GetRequest) (*GetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedInteractiveServiceServer) GetByIds(context.Context, *GetByIdsRequest) (*GetByIdsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetByIds not implemented")
+}
+func (UnimplementedInteractiveServiceServer) LikeRecords(context.Context, *LikeRecordsRequest) (*LikeRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LikeRecords not implemented")
+}
+func (UnimplementedInteractiveServiceServer) CollectRecords(context.Context, *CollectRecordsRequest) (*CollectRecordsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectRecords not implemented")
+}
+func (UnimplementedInteractiveServiceServer) mustEmbedUnimplementedInteractiveServiceServer() {}
+
+// UnsafeInteractiveServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to InteractiveServiceServer will
+// result in compilation errors.
+type UnsafeInteractiveServiceServer interface {
+	mustEmbedUnimplementedInteractiveServiceServer()
+}
+
+func RegisterInteractiveServiceServer(s grpc.ServiceRegistrar, srv InteractiveServiceServer) {
+	s.RegisterService(&InteractiveService_ServiceDesc, srv)
+}
+
+func _InteractiveService_IncrReadCnt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrReadCntRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).IncrReadCnt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_IncrReadCnt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).IncrReadCnt(ctx, req.(*IncrReadCntRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_Like_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).Like(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_Like_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).Like(ctx, req.(*LikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CancelLike_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelLikeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CancelLike(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CancelLike_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CancelLike(ctx, req.(*CancelLikeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_React_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReactRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).React(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_React_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).React(ctx, req.(*ReactRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CancelReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CancelReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CancelReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CancelReaction(ctx, req.(*CancelReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_AddCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).AddCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_AddCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).AddCollectionItem(ctx, req.(*AddCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CancelCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CancelCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CancelCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CancelCollectionItem(ctx, req.(*CancelCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_MoveCollectionItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveCollectionItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).MoveCollectionItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_MoveCollectionItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).MoveCollectionItem(ctx, req.(*MoveCollectionItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).Get(ctx, req.(*GetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_GetByIds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetByIdsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).GetByIds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_GetByIds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).GetByIds(ctx, req.(*GetByIdsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_LikeRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LikeRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).LikeRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_LikeRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).LikeRecords(ctx, req.(*LikeRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InteractiveService_CollectRecords_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectRecordsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InteractiveServiceServer).CollectRecords(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InteractiveService_CollectRecords_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InteractiveServiceServer).CollectRecords(ctx, req.(*CollectRecordsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// InteractiveService_ServiceDesc is the grpc.ServiceDesc for InteractiveService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var InteractiveService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "intr.v1.InteractiveService",
+	HandlerType: (*InteractiveServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "IncrReadCnt",
+			Handler:    _InteractiveService_IncrReadCnt_Handler,
+		},
+		{
+			MethodName: "Like",
+			Handler:    _InteractiveService_Like_Handler,
+		},
+		{
+			MethodName: "CancelLike",
+			Handler:    _InteractiveService_CancelLike_Handler,
+		},
+		{
+			MethodName: "React",
+			Handler:    _InteractiveService_React_Handler,
+		},
+		{
+			MethodName: "CancelReaction",
+			Handler:    _InteractiveService_CancelReaction_Handler,
+		},
+		{
+			MethodName: "AddCollectionItem",
+			Handler:    _InteractiveService_AddCollectionItem_Handler,
+		},
+		{
+			MethodName: "CancelCollectionItem",
+			Handler:    _InteractiveService_CancelCollectionItem_Handler,
+		},
+		{
+			MethodName: "MoveCollectionItem",
+			Handler:    _InteractiveService_MoveCollectionItem_Handler,
+		},
+		{
+			MethodName: "Get",
+			Handler:    _InteractiveService_Get_Handler,
+		},
+		{
+			MethodName: "GetByIds",
+			Handler:    _InteractiveService_GetByIds_Handler,
+		},
+		{
+			MethodName: "LikeRecords",
+			Handler:    _InteractiveService_LikeRecords_Handler,
+		},
+		{
+			MethodName: "CollectRecords",
+			Handler:    _InteractiveService_CollectRecords_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "intr/v1/intr.proto",
+}
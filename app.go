@@ -3,9 +3,16 @@ package main
 import (
 	"github.com/gin-gonic/gin"
 	"webook/internal/domain/events"
+	"webook/internal/job"
 )
 
 type App struct {
 	server    *gin.Engine
 	consumers []events.Consumer
+	// 阅读数写缓冲的定时刷新
+	readCntFlusher *job.ReadCntFlusher
+	// 定时任务
+	cron *job.CronRunner
+	// 数据库里面的定时任务
+	scheduler *job.Scheduler
 }
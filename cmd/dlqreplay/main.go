@@ -0,0 +1,58 @@
+// dlqreplay 把死信 topic 里面的消息重新投递回原来的 topic
+//
+//	go run ./cmd/dlqreplay --brokers localhost:9094 --topic article_read
+package main
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"github.com/spf13/pflag"
+	"go.uber.org/zap"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"webook/pkg/logger"
+	"webook/pkg/saramax"
+)
+
+func main() {
+	brokers := pflag.String("brokers", "localhost:9094", "kafka 地址，多个用逗号分隔")
+	topic := pflag.String("topic", "", "原始 topic，重放它的死信 topic")
+	group := pflag.String("group", "dlq_replay", "记录重放进度的消费者组")
+	pflag.Parse()
+	if *topic == "" {
+		log.Fatal("必须指定 --topic")
+	}
+
+	cfg := sarama.NewConfig()
+	cfg.Producer.Return.Successes = true
+	// 新的消费者组从头开始重放
+	cfg.Consumer.Offsets.Initial = sarama.OffsetOldest
+	client, err := sarama.NewClient(strings.Split(*brokers, ","), cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+	producer, err := sarama.NewSyncProducerFromClient(client)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer producer.Close()
+
+	l, err := zap.NewDevelopment()
+	if err != nil {
+		log.Fatal(err)
+	}
+	r := saramax.NewReplayer(client, producer, logger.NewZapLogger(l))
+	r.GroupId = *group
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+	dlqTopic := saramax.DeadLetterTopic(*topic)
+	cnt, err := r.Replay(ctx, dlqTopic)
+	log.Printf("从 %s 重放了 %d 条消息到 %s", dlqTopic, cnt, *topic)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,11 @@
+package main
+
+import (
+	"webook/internal/job"
+	"webook/pkg/grpcx"
+)
+
+type App struct {
+	server         *grpcx.Server
+	readCntFlusher *job.ReadCntFlusher
+}
@@ -0,0 +1,43 @@
+// interactive 单独部署的互动服务，web 配置 grpc.client.intr.remote 为 true 之后通过 gRPC 调用它
+//
+//	go run ./cmd/interactive --config config/dev.yaml
+package main
+
+import (
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+func main() {
+	initViper()
+	app := InitApp()
+	app.readCntFlusher.Start()
+	defer app.readCntFlusher.Close()
+
+	go func() {
+		ch := make(chan os.Signal, 1)
+		signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+		<-ch
+		app.server.GracefulStop()
+	}()
+	log.Println("互动服务启动", app.server.Addr)
+	err := app.server.Serve()
+	if err != nil {
+		log.Println("互动服务退出", err)
+	}
+}
+
+func initViper() {
+	cfile := pflag.String("config", "config/dev.yaml", "config file path")
+	pflag.Parse()
+	viper.SetConfigType("yaml")
+	viper.SetConfigFile(*cfile)
+	err := viper.ReadInConfig()
+	if err != nil {
+		panic(err)
+	}
+}
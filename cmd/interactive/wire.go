@@ -0,0 +1,39 @@
+//go:build wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"webook/internal/domain/events/notification"
+	"webook/internal/grpc"
+	"webook/internal/repository"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+	"webook/internal/service"
+	"webook/ioc"
+)
+
+var interactiveSvcSet = wire.NewSet(
+	dao.NewGormInteractiveDAO,
+	ioc.InitInteractiveCache,
+	cache.NewRedisReadCntBuffer,
+	ioc.InitReaderCache,
+	ioc.InitTrendingCache,
+	ioc.InitTrendingRepository,
+	repository.NewCachedInteractiveRepository,
+	ioc.InitReactionSet,
+	service.NewInteractiveService,
+)
+
+func InitApp() *App {
+	wire.Build(
+		ioc.InitLogger, ioc.InitDB, ioc.InitRedis, ioc.InitCacheAside,
+		ioc.InitSaramaClient, ioc.InitSyncProducer, notification.NewSaramaSyncProducer,
+		interactiveSvcSet,
+		grpc.NewInteractiveServiceServer,
+		ioc.InitGRPCServer,
+		ioc.InitReadCntFlusher,
+		wire.Struct(new(App), "*"),
+	)
+	return new(App)
+}
@@ -0,0 +1,51 @@
+// Code generated by Wire. DO NOT EDIT.
+
+//go:generate go run -mod=mod github.com/google/wire/cmd/wire
+//go:build !wireinject
+// +build !wireinject
+
+package main
+
+import (
+	"github.com/google/wire"
+	"webook/internal/domain/events/notification"
+	"webook/internal/grpc"
+	"webook/internal/repository"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+	"webook/internal/service"
+	"webook/ioc"
+)
+
+// Injectors from wire.go:
+
+func InitApp() *App {
+	logger := ioc.InitLogger()
+	db := ioc.InitDB(logger)
+	interactiveDAO := dao.NewGormInteractiveDAO(db)
+	cmdable := ioc.InitRedis()
+	interactiveCache := ioc.InitInteractiveCache(cmdable, logger)
+	readCntBuffer := cache.NewRedisReadCntBuffer(cmdable)
+	readerCache := ioc.InitReaderCache(cmdable)
+	aside := ioc.InitCacheAside(cmdable)
+	trendingCache := ioc.InitTrendingCache(cmdable)
+	trendingRepository := ioc.InitTrendingRepository(trendingCache)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, readCntBuffer, readerCache, aside, trendingRepository, logger)
+	reactionSet := ioc.InitReactionSet()
+	client := ioc.InitSaramaClient()
+	syncProducer := ioc.InitSyncProducer(client)
+	producer := notification.NewSaramaSyncProducer(syncProducer)
+	interactiveService := service.NewInteractiveService(interactiveRepository, reactionSet, producer, logger)
+	interactiveServiceServer := grpc.NewInteractiveServiceServer(interactiveService)
+	server := ioc.InitGRPCServer(interactiveServiceServer)
+	readCntFlusher := ioc.InitReadCntFlusher(interactiveRepository, logger)
+	app := &App{
+		server:         server,
+		readCntFlusher: readCntFlusher,
+	}
+	return app
+}
+
+// wire.go:
+
+var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDAO, ioc.InitInteractiveCache, cache.NewRedisReadCntBuffer, ioc.InitReaderCache, ioc.InitTrendingCache, ioc.InitTrendingRepository, repository.NewCachedInteractiveRepository, ioc.InitReactionSet, service.NewInteractiveService)
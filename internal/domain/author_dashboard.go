@@ -0,0 +1,49 @@
+package domain
+
+// AuthorDashboard 作者数据看板
+type AuthorDashboard struct {
+	Uid int64 `json:"uid"`
+	// Days 环比的周期长度
+	Days       int   `json:"days"`
+	ArticleCnt int64 `json:"article_cnt"`
+	// Total 累计值，Date 是统计到哪一天
+	Total InteractiveStat `json:"total"`
+	// Current 最近 Days 天，Previous 是再往前 Days 天，Date 是周期的第一天
+	Current  InteractiveStat `json:"current"`
+	Previous InteractiveStat `json:"previous"`
+	// Delta Current 减 Previous
+	Delta     InteractiveStat  `json:"delta"`
+	TopByRead []ArticleRank    `json:"top_by_read"`
+	TopByLike []ArticleRank    `json:"top_by_like"`
+	Cadence   []PublishCadence `json:"cadence"`
+	Followers FollowerStats    `json:"followers"`
+	Utime     int64            `json:"utime"`
+}
+
+// FollowerStats 粉丝总数和两个周期的新增，新增只算现在还没取消关注的
+type FollowerStats struct {
+	Total    int64 `json:"total"`
+	Current  int64 `json:"current"`
+	Previous int64 `json:"previous"`
+	Delta    int64 `json:"delta"`
+}
+
+type ArticleRank struct {
+	Id      int64  `json:"id"`
+	Title   string `json:"title"`
+	ReadCnt int64  `json:"read_cnt"`
+	LikeCnt int64  `json:"like_cnt"`
+}
+
+// PublishCadence 一周发表了多少篇，Week 是周一
+type PublishCadence struct {
+	Week string `json:"week"`
+	Cnt  int64  `json:"cnt"`
+}
+
+// AuthorStatsBase 作者所有文章截止到 Day 当天结束的累计值，之后的部分从按天的增量里面加上去
+type AuthorStatsBase struct {
+	Uid   int64           `json:"uid"`
+	Day   string          `json:"day"`
+	Total InteractiveStat `json:"total"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+// Collection 收藏夹 id 为 0 的是每个用户默认的收藏夹，不需要创建
+type Collection struct {
+	Id         int64                `json:"id"`
+	Uid        int64                `json:"uid"`
+	Name       string               `json:"name"`
+	Visibility CollectionVisibility `json:"visibility"`
+	Ctime      int64                `json:"ctime"`
+	Utime      int64                `json:"utime"`
+}
+
+type CollectionVisibility uint8
+
+const (
+	CollectionVisibilityPrivate CollectionVisibility = 0 // 私密 默认
+	CollectionVisibilityPublic  CollectionVisibility = 1 // 公开
+)
+
+func (c CollectionVisibility) ToUint8() uint8 {
+	return uint8(c)
+}
+
+// CollectionItem 收藏夹里面的一条收藏
+type CollectionItem struct {
+	Id    int64  `json:"id"`
+	Cid   int64  `json:"cid"`
+	Uid   int64  `json:"uid"`
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// 文章摘要 只有 biz 是 article 的时候才有
+	Article Article `json:"article"`
+	Ctime   int64   `json:"ctime"`
+	Utime   int64   `json:"utime"`
+}
@@ -0,0 +1,33 @@
+package domain
+
+type Comment struct {
+	Id int64 `json:"id"`
+	// 评论的对象 <biz,bizId>
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+	// 评论者
+	Commentator User   `json:"commentator"`
+	Content     string `json:"content"`
+	// RootComment 根评论 为nil表示自己就是根评论
+	RootComment *Comment `json:"root_comment"`
+	// ParentComment 回复的那条评论
+	ParentComment *Comment `json:"parent_comment"`
+	LikeCnt       int64    `json:"like_cnt"`
+	ReplyCnt      int64    `json:"reply_cnt"`
+	Ctime         int64    `json:"ctime"`
+	Utime         int64    `json:"utime"`
+}
+
+// CommentSort 根评论列表的排序方式
+type CommentSort uint8
+
+const (
+	CommentSortNewest CommentSort = 0 // 最新
+	CommentSortHot    CommentSort = 1 // 最热 按点赞数
+)
+
+// CommentCursor 游标分页 第一页传零值
+type CommentCursor struct {
+	LastId      int64 `json:"last_id"`
+	LastLikeCnt int64 `json:"last_like_cnt"`
+}
@@ -2,6 +2,7 @@ package article
 
 import (
 	"context"
+	"errors"
 	"github.com/IBM/sarama"
 	"time"
 	"webook/internal/repository"
@@ -12,11 +13,17 @@ import (
 type InteractiveReadEventConsumer struct {
 	repo   repository.InteractiveRepository
 	client sarama.Client
-	l      logger.ZapLogger
+	opts   []saramax.Option
+	l      logger.Logger
+
+	cg     sarama.ConsumerGroup
+	cancel context.CancelFunc
 }
 
-func NewInteractiveReadEventConsumer(repo repository.InteractiveRepository, client sarama.Client, l logger.ZapLogger) *InteractiveReadEventConsumer {
-	return &InteractiveReadEventConsumer{repo: repo, client: client, l: l}
+// NewInteractiveReadEventConsumer opts 是重试、死信这些消息处理的配置
+func NewInteractiveReadEventConsumer(repo repository.InteractiveRepository,
+	client sarama.Client, l logger.Logger, opts ...saramax.Option) *InteractiveReadEventConsumer {
+	return &InteractiveReadEventConsumer{repo: repo, client: client, opts: opts, l: l}
 }
 
 func (i *InteractiveReadEventConsumer) Start() error {
@@ -24,21 +31,43 @@ func (i *InteractiveReadEventConsumer) Start() error {
 	if err != nil {
 		return err
 	}
+	ctx, cancel := context.WithCancel(context.Background())
+	i.cg, i.cancel = cg, cancel
+	handler := saramax.NewBatchHandler[ReadEvent](i.BatchConsume, i.l, i.opts...)
 	go func() {
-		er := cg.Consume(context.Background(),
-			[]string{"interactive_read_event"},
-			saramax.NewHandler[ReadEvent](i.Consumer, i.l),
-		)
-		if er != nil {
-			i.l.Error("consumer error", logger.Error(er))
+		// rebalance 之后 Consume 会返回，要重新加入消费者组
+		for ctx.Err() == nil {
+			er := cg.Consume(ctx, []string{TopicReadEvent}, handler)
+			if errors.Is(er, sarama.ErrClosedConsumerGroup) {
+				return
+			}
+			if er != nil {
+				i.l.Error("consumer error", logger.Error(er))
+			}
 		}
 	}()
 	return nil
 }
 
-func (i *InteractiveReadEventConsumer) Consumer(msg *sarama.ConsumerMessage, event ReadEvent) error {
+func (i *InteractiveReadEventConsumer) Close() error {
+	if i.cancel == nil {
+		return nil
+	}
+	i.cancel()
+	return i.cg.Close()
+}
+
+// BatchConsume 一批阅读事件一次写进阅读数缓冲区
+func (i *InteractiveReadEventConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []ReadEvent) error {
+	bizs := make([]string, 0, len(events))
+	bizIds := make([]int64, 0, len(events))
+	uids := make([]int64, 0, len(events))
+	for _, evt := range events {
+		bizs = append(bizs, "article")
+		bizIds = append(bizIds, evt.ArtId)
+		uids = append(uids, evt.Uid)
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
 	defer cancel()
-	err := i.repo.IncrReadCnt(ctx, "article", event.ArtId)
-	return err
+	return i.repo.BatchIncrReadCnt(ctx, bizs, bizIds, uids)
 }
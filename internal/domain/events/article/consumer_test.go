@@ -0,0 +1,54 @@
+package article
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+func TestInteractiveReadEventConsumer_BatchConsume(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+
+		wantErr error
+	}{
+		{
+			name: "一批阅读一次写入",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				intrRepo.EXPECT().BatchIncrReadCnt(gomock.Any(),
+					[]string{"article", "article", "article"}, []int64{1, 2, 1}, []int64{10, 11, 12}).Return(nil)
+				return intrRepo
+			},
+		},
+		{
+			name: "写入失败交给重试",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				intrRepo.EXPECT().BatchIncrReadCnt(gomock.Any(),
+					[]string{"article", "article", "article"}, []int64{1, 2, 1}, []int64{10, 11, 12}).
+					Return(errors.New("redis错误"))
+				return intrRepo
+			},
+			wantErr: errors.New("redis错误"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewInteractiveReadEventConsumer(tc.mock(ctrl), nil, logger.NewNopLogger())
+			err := c.BatchConsume(nil, []ReadEvent{
+				{ArtId: 1, Uid: 10},
+				{ArtId: 2, Uid: 11},
+				{ArtId: 1, Uid: 12},
+			})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package article
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+	"webook/pkg/saramax"
+)
+
+// HistoryReadEventConsumer 用阅读事件记录每个用户的阅读历史，和阅读数用不同的消费者组
+type HistoryReadEventConsumer struct {
+	repo   repository.HistoryRepository
+	client sarama.Client
+	opts   []saramax.Option
+	l      logger.Logger
+
+	cg     sarama.ConsumerGroup
+	cancel context.CancelFunc
+}
+
+func NewHistoryReadEventConsumer(repo repository.HistoryRepository,
+	client sarama.Client, l logger.Logger, opts ...saramax.Option) *HistoryReadEventConsumer {
+	return &HistoryReadEventConsumer{repo: repo, client: client, opts: opts, l: l}
+}
+
+func (h *HistoryReadEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("history", h.client)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	h.cg, h.cancel = cg, cancel
+	handler := saramax.NewBatchHandler[ReadEvent](h.BatchConsume, h.l, h.opts...)
+	go func() {
+		// rebalance 之后 Consume 会返回，要重新加入消费者组
+		for ctx.Err() == nil {
+			er := cg.Consume(ctx, []string{TopicReadEvent}, handler)
+			if errors.Is(er, sarama.ErrClosedConsumerGroup) {
+				return
+			}
+			if er != nil {
+				h.l.Error("consumer error", logger.Error(er))
+			}
+		}
+	}()
+	return nil
+}
+
+func (h *HistoryReadEventConsumer) Close() error {
+	if h.cancel == nil {
+		return nil
+	}
+	h.cancel()
+	return h.cg.Close()
+}
+
+func (h *HistoryReadEventConsumer) BatchConsume(msgs []*sarama.ConsumerMessage, events []ReadEvent) error {
+	uids := make([]int64, 0, len(events))
+	for _, evt := range events {
+		uids = append(uids, evt.Uid)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	paused, err := h.repo.PausedIn(ctx, uids)
+	if err != nil {
+		return err
+	}
+	type key struct {
+		uid   int64
+		artId int64
+	}
+	// 一批里面同一个人读同一篇只记一次
+	seen := make(map[key]struct{}, len(events))
+	hs := make([]domain.ReadHistory, 0, len(events))
+	for _, evt := range events {
+		// 没有登录的阅读
+		if evt.Uid <= 0 {
+			continue
+		}
+		if _, ok := paused[evt.Uid]; ok {
+			continue
+		}
+		k := key{uid: evt.Uid, artId: evt.ArtId}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		hs = append(hs, domain.ReadHistory{Uid: evt.Uid, ArtId: evt.ArtId})
+	}
+	return h.repo.Record(ctx, hs)
+}
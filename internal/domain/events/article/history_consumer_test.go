@@ -0,0 +1,30 @@
+package article
+
+import (
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+func TestHistoryReadEventConsumer_BatchConsume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockHistoryRepository(ctrl)
+	repo.EXPECT().PausedIn(gomock.Any(), []int64{1, 2, 1, 0}).
+		Return(map[int64]struct{}{2: {}}, nil)
+	// 2 暂停了，0 没有登录，1 重复读的只记一次
+	repo.EXPECT().Record(gomock.Any(), []domain.ReadHistory{
+		{Uid: 1, ArtId: 10},
+	}).Return(nil)
+	c := NewHistoryReadEventConsumer(repo, nil, logger.NewNopLogger())
+	err := c.BatchConsume(nil, []ReadEvent{
+		{Uid: 1, ArtId: 10},
+		{Uid: 2, ArtId: 10},
+		{Uid: 1, ArtId: 10},
+		{Uid: 0, ArtId: 10},
+	})
+	assert.NoError(t, err)
+}
@@ -5,6 +5,9 @@ import (
 	"github.com/IBM/sarama"
 )
 
+// TopicReadEvent 阅读事件，生产者和消费者用同一个 topic
+const TopicReadEvent = "article_read"
+
 type Producer interface {
 	ProduceReadEvent(event ReadEvent) error
 }
@@ -21,7 +24,7 @@ type SaramaSyncProducer struct {
 
 func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
 	return &SaramaSyncProducer{producer: producer,
-		TopicReadEvent: "article_read",
+		TopicReadEvent: TopicReadEvent,
 	}
 }
 
@@ -0,0 +1,110 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+	"webook/pkg/saramax"
+)
+
+// NotificationEventConsumer 把点赞、收藏、评论、关注事件变成接收者的通知
+type NotificationEventConsumer struct {
+	repo    repository.NotificationRepository
+	artRepo repository.ArticleRepository
+	client  sarama.Client
+	opts    []saramax.Option
+	l       logger.Logger
+
+	cg     sarama.ConsumerGroup
+	cancel context.CancelFunc
+}
+
+func NewNotificationEventConsumer(repo repository.NotificationRepository, artRepo repository.ArticleRepository,
+	client sarama.Client, l logger.Logger, opts ...saramax.Option) *NotificationEventConsumer {
+	return &NotificationEventConsumer{repo: repo, artRepo: artRepo, client: client, opts: opts, l: l}
+}
+
+func (n *NotificationEventConsumer) Start() error {
+	cg, err := sarama.NewConsumerGroupFromClient("notification", n.client)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	n.cg, n.cancel = cg, cancel
+	handler := saramax.NewHandler[Event](n.Consume, n.l, n.opts...)
+	go func() {
+		// rebalance 之后 Consume 会返回，要重新加入消费者组
+		for ctx.Err() == nil {
+			er := cg.Consume(ctx, []string{TopicNotificationEvent}, handler)
+			if errors.Is(er, sarama.ErrClosedConsumerGroup) {
+				return
+			}
+			if er != nil {
+				n.l.Error("consumer error", logger.Error(er))
+			}
+		}
+	}()
+	return nil
+}
+
+func (n *NotificationEventConsumer) Close() error {
+	if n.cancel == nil {
+		return nil
+	}
+	n.cancel()
+	return n.cg.Close()
+}
+
+func (n *NotificationEventConsumer) Consume(msg *sarama.ConsumerMessage, evt Event) error {
+	typ := domain.NotificationType(evt.Type)
+	if !typ.Valid() {
+		// 重试也没用
+		n.l.Warn("未知的通知类型", logger.String("type", evt.Type))
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	receiver, err := n.receiver(ctx, evt)
+	if err != nil {
+		return err
+	}
+	// 自己赞自己、资源已经不在了
+	if receiver == 0 || receiver == evt.Actor {
+		return nil
+	}
+	optOuts, err := n.repo.OptOuts(ctx, receiver)
+	if err != nil {
+		return err
+	}
+	for _, t := range optOuts {
+		if t == typ {
+			return nil
+		}
+	}
+	return n.repo.Notify(ctx, domain.Notification{
+		Uid:   receiver,
+		Type:  typ,
+		Biz:   evt.Biz,
+		BizId: evt.BizId,
+	}, evt.Actor)
+}
+
+// receiver 事件里面没带接收者的，目前只有文章，找文章的作者
+func (n *NotificationEventConsumer) receiver(ctx context.Context, evt Event) (int64, error) {
+	if evt.Receiver > 0 || evt.Biz != "article" {
+		return evt.Receiver, nil
+	}
+	art, err := n.artRepo.GetPubByArtId(ctx, evt.BizId)
+	switch {
+	case err == nil:
+		return art.Author.Id, nil
+	case errors.Is(err, repository.ErrArticleNotFound):
+		return 0, nil
+	default:
+		return 0, err
+	}
+}
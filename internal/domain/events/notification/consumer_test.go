@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+type fakeArticleRepository struct {
+	repository.ArticleRepository
+	arts map[int64]domain.Article
+}
+
+func (f *fakeArticleRepository) GetPubByArtId(ctx context.Context, artId int64) (domain.Article, error) {
+	art, ok := f.arts[artId]
+	if !ok {
+		return domain.Article{}, repository.ErrArticleNotFound
+	}
+	return art, nil
+}
+
+func TestNotificationEventConsumer_Consume(t *testing.T) {
+	arts := map[int64]domain.Article{
+		1: {Id: 1, Author: domain.Author{Id: 10}},
+	}
+	testCases := []struct {
+		name string
+		evt  Event
+		mock func(ctrl *gomock.Controller) repository.NotificationRepository
+	}{
+		{
+			name: "点赞通知文章作者",
+			evt:  Event{Type: "like", Actor: 20, Biz: "article", BizId: 1},
+			mock: func(ctrl *gomock.Controller) repository.NotificationRepository {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().OptOuts(gomock.Any(), int64(10)).Return(nil, nil)
+				repo.EXPECT().Notify(gomock.Any(), domain.Notification{
+					Uid:   10,
+					Type:  domain.NotificationTypeLike,
+					Biz:   "article",
+					BizId: 1,
+				}, int64(20)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "回复带着接收者",
+			evt:  Event{Type: "comment", Actor: 20, Receiver: 30, Biz: "comment", BizId: 5},
+			mock: func(ctrl *gomock.Controller) repository.NotificationRepository {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().OptOuts(gomock.Any(), int64(30)).Return([]domain.NotificationType{domain.NotificationTypeLike}, nil)
+				repo.EXPECT().Notify(gomock.Any(), domain.Notification{
+					Uid:   30,
+					Type:  domain.NotificationTypeComment,
+					Biz:   "comment",
+					BizId: 5,
+				}, int64(20)).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "自己赞自己",
+			evt:  Event{Type: "like", Actor: 10, Biz: "article", BizId: 1},
+			mock: func(ctrl *gomock.Controller) repository.NotificationRepository {
+				return repomocks.NewMockNotificationRepository(ctrl)
+			},
+		},
+		{
+			name: "文章不存在",
+			evt:  Event{Type: "collect", Actor: 20, Biz: "article", BizId: 2},
+			mock: func(ctrl *gomock.Controller) repository.NotificationRepository {
+				return repomocks.NewMockNotificationRepository(ctrl)
+			},
+		},
+		{
+			name: "关掉了这种通知",
+			evt:  Event{Type: "follow", Actor: 20, Receiver: 10, Biz: "user", BizId: 10},
+			mock: func(ctrl *gomock.Controller) repository.NotificationRepository {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().OptOuts(gomock.Any(), int64(10)).Return([]domain.NotificationType{domain.NotificationTypeFollow}, nil)
+				return repo
+			},
+		},
+		{
+			name: "未知类型直接丢掉",
+			evt:  Event{Type: "share", Actor: 20, Receiver: 10},
+			mock: func(ctrl *gomock.Controller) repository.NotificationRepository {
+				return repomocks.NewMockNotificationRepository(ctrl)
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewNotificationEventConsumer(tc.mock(ctrl), &fakeArticleRepository{arts: arts},
+				nil, logger.NewNopLogger())
+			err := c.Consume(nil, tc.evt)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package notification
+
+import (
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"strconv"
+)
+
+// TopicNotificationEvent 点赞、收藏、评论、关注之后发出来，消费者生成通知
+const TopicNotificationEvent = "notification_event"
+
+type Producer interface {
+	ProduceEvent(event Event) error
+}
+
+type Event struct {
+	Type  string
+	Actor int64
+	// Receiver 为 0 的时候由消费者按 Biz、BizId 找资源的作者，比如点赞文章的时候通知文章作者
+	Receiver int64
+	Biz      string
+	BizId    int64
+}
+
+type SaramaSyncProducer struct {
+	producer sarama.SyncProducer
+	Topic    string
+}
+
+func NewSaramaSyncProducer(producer sarama.SyncProducer) Producer {
+	return &SaramaSyncProducer{
+		producer: producer,
+		Topic:    TopicNotificationEvent,
+	}
+}
+
+func (s *SaramaSyncProducer) ProduceEvent(event Event) error {
+	val, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, _, err = s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic: s.Topic,
+		// 同一个资源的事件进同一个分区，聚合的时候少一些并发
+		Key:   sarama.StringEncoder(event.Biz + ":" + strconv.FormatInt(event.BizId, 10)),
+		Value: sarama.ByteEncoder(val),
+	})
+	return err
+}
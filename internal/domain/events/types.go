@@ -4,4 +4,6 @@ package events
 
 type Consumer interface {
 	Start() error
+	// Close 停止消费并关闭消费者组，正在处理的一批不会提交偏移量
+	Close() error
 }
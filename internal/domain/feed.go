@@ -0,0 +1,13 @@
+package domain
+
+// FeedItem 收件箱、发件箱里面的一篇文章，Ctime 是发表时间，也是分页的游标
+type FeedItem struct {
+	ArtId int64
+	Ctime int64
+}
+
+// Feed 关注的作者发表的文章，NextCursor 为 0 表示没有下一页了
+type Feed struct {
+	Articles   []Article
+	NextCursor int64
+}
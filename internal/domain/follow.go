@@ -0,0 +1,25 @@
+package domain
+
+// FollowRelation 关注关系，Id 用来做分页的游标
+type FollowRelation struct {
+	Id       int64 `json:"id"`
+	Follower int64 `json:"follower"`
+	Followee int64 `json:"followee"`
+	// Mutual 互相关注
+	Mutual bool  `json:"mutual"`
+	Ctime  int64 `json:"ctime"`
+}
+
+// FollowStatistic 用户的粉丝数、关注数，以及和当前用户之间的关注关系
+type FollowStatistic struct {
+	Uid       int64 `json:"uid"`
+	Followers int64 `json:"followers"`
+	Followees int64 `json:"followees"`
+	// Followed 当前用户关注了他，FollowedBy 他关注了当前用户
+	Followed   bool `json:"followed"`
+	FollowedBy bool `json:"followed_by"`
+}
+
+func (f FollowStatistic) Mutual() bool {
+	return f.Followed && f.FollowedBy
+}
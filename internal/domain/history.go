@@ -0,0 +1,13 @@
+package domain
+
+// ReadHistory 用户读过的一篇文章，Progress 是客户端上报的滚动进度，0 到 100
+type ReadHistory struct {
+	Uid      int64
+	ArtId    int64
+	Progress uint8
+	// Article 列表页用的摘要，已经撤回的文章是空的
+	Article Article
+	Ctime   int64
+	// Utime 最近一次阅读的时间
+	Utime int64
+}
@@ -1,16 +1,99 @@
 package domain
 
+import "time"
+
 type Interactive struct {
 	BizId int64
 	Biz   string
 
 	ReadCnt    int64 // 阅读数
+	ReaderCnt  int64 // 独立读者数
 	LikeCnt    int64 //点赞数
 	CollectCnt int64 //收藏数
 	CommentCnt int64 //评论数
 	ShareCnt   int64 // 分享数
 	Liked      bool  `json:"liked"`
 	Collected  bool  `json:"collected"`
-	Ctime      int64
-	Utime      int64
+	// ReactionCnts 每种表情的数量，默认表情 ReactionLike 的数量和 LikeCnt 一样
+	ReactionCnts map[string]int64
+	// Reactions 当前用户点过的表情
+	Reactions []string
+	Ctime     int64
+	Utime     int64
+}
+
+// ReactionLike 默认表情，就是原来的点赞
+const ReactionLike = "like"
+
+// ReactionSet 允许使用的表情，第一个是 ReactionLike
+type ReactionSet []string
+
+func (s ReactionSet) Contains(reaction string) bool {
+	for _, r := range s {
+		if r == reaction {
+			return true
+		}
+	}
+	return false
+}
+
+// InteractiveRecord 用户的一条点赞或者收藏记录
+type InteractiveRecord struct {
+	Uid   int64
+	Biz   string
+	BizId int64
+	Ctime int64
+	Utime int64
+}
+
+// InteractiveStat 一个统计周期内的增量，Date 是周期的第一天 2006-01-02
+type InteractiveStat struct {
+	Date       string `json:"date"`
+	ReadCnt    int64  `json:"read_cnt"`
+	LikeCnt    int64  `json:"like_cnt"`
+	CollectCnt int64  `json:"collect_cnt"`
+	CommentCnt int64  `json:"comment_cnt"`
+}
+
+// Add 把 o 的计数加到 s 上面，Date 不变
+func (s InteractiveStat) Add(o InteractiveStat) InteractiveStat {
+	s.ReadCnt += o.ReadCnt
+	s.LikeCnt += o.LikeCnt
+	s.CollectCnt += o.CollectCnt
+	s.CommentCnt += o.CommentCnt
+	return s
+}
+
+// Sub 把 o 的计数从 s 上面减掉，Date 不变
+func (s InteractiveStat) Sub(o InteractiveStat) InteractiveStat {
+	s.ReadCnt -= o.ReadCnt
+	s.LikeCnt -= o.LikeCnt
+	s.CollectCnt -= o.CollectCnt
+	s.CommentCnt -= o.CommentCnt
+	return s
+}
+
+// StatsGranularity 统计粒度
+type StatsGranularity string
+
+const (
+	StatsGranularityDay   StatsGranularity = "day"
+	StatsGranularityWeek  StatsGranularity = "week" // 周一开始
+	StatsGranularityMonth StatsGranularity = "month"
+)
+
+func (g StatsGranularity) Valid() bool {
+	switch g {
+	case StatsGranularityDay, StatsGranularityWeek, StatsGranularityMonth:
+		return true
+	}
+	return false
+}
+
+// StatsLocation 按天统计的时候按照北京时间切天
+var StatsLocation = time.FixedZone("CST", 8*60*60)
+
+// StatsDay t 所在的统计日期 2006-01-02
+func StatsDay(t time.Time) string {
+	return t.In(StatsLocation).Format(time.DateOnly)
 }
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"github.com/robfig/cron/v3"
+	"time"
+)
+
+// Job 存在数据库里面的定时任务，多个实例抢占执行
+type Job struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	// Executor 用哪个执行器执行，Cfg 是传给执行器的配置
+	Executor   string    `json:"executor"`
+	Cfg        string    `json:"cfg"`
+	Expression string    `json:"expression"` // cron 表达式
+	Status     JobStatus `json:"status"`
+	NextTime   int64     `json:"next_time"`
+	Owner      string    `json:"owner"` // 正在执行的实例
+	Heartbeat  int64     `json:"heartbeat"`
+	Version    int64     `json:"version"`
+	Ctime      int64     `json:"ctime"`
+	Utime      int64     `json:"utime"`
+}
+
+// Next 按 cron 表达式算出 t 之后的下一次执行时间，表达式不合法的时候返回零值
+func (j Job) Next(t time.Time) time.Time {
+	s, err := cron.ParseStandard(j.Expression)
+	if err != nil {
+		return time.Time{}
+	}
+	return s.Next(t)
+}
+
+type JobStatus uint8
+
+const (
+	JobStatusUnknown JobStatus = 0
+	JobStatusWaiting JobStatus = 1 // 等待下一次执行
+	JobStatusRunning JobStatus = 2 // 有实例在执行
+	JobStatusPaused  JobStatus = 3 // 暂停了，不会被抢占
+)
+
+func (s JobStatus) ToUint8() uint8 {
+	return uint8(s)
+}
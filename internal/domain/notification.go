@@ -0,0 +1,44 @@
+package domain
+
+// NotificationType 通知的类型，用户可以按类型关掉
+type NotificationType string
+
+const (
+	NotificationTypeLike    NotificationType = "like"
+	NotificationTypeCollect NotificationType = "collect"
+	// NotificationTypeComment 评论了文章或者回复了评论
+	NotificationTypeComment NotificationType = "comment"
+	NotificationTypeFollow  NotificationType = "follow"
+)
+
+var NotificationTypes = []NotificationType{
+	NotificationTypeLike,
+	NotificationTypeCollect,
+	NotificationTypeComment,
+	NotificationTypeFollow,
+}
+
+func (t NotificationType) Valid() bool {
+	for _, typ := range NotificationTypes {
+		if typ == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification 同一个资源上同一种未读的通知聚合成一条，比如 "12 个人赞了你的文章"
+// 关注的通知 Biz 是 user，BizId 是被关注的人
+type Notification struct {
+	Id    int64            `json:"id"`
+	Uid   int64            `json:"uid"`
+	Type  NotificationType `json:"type"`
+	Biz   string           `json:"biz"`
+	BizId int64            `json:"biz_id"`
+	// LastActor 最近一个触发通知的人，ActorCnt 一共有多少个人
+	LastActor int64 `json:"last_actor"`
+	ActorCnt  int64 `json:"actor_cnt"`
+	Read      bool  `json:"read"`
+	Ctime     int64 `json:"ctime"`
+	Utime     int64 `json:"utime"`
+}
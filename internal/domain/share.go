@@ -0,0 +1,46 @@
+package domain
+
+// ShareLink 每个用户对每篇文章只有一个分享链接，通过 Code 归因点击和阅读
+type ShareLink struct {
+	Id    int64  `json:"id"`
+	Code  string `json:"code"`
+	Uid   int64  `json:"uid"` // 分享者
+	Biz   string `json:"biz"`
+	BizId int64  `json:"biz_id"`
+
+	ClickCnt int64 `json:"click_cnt"` // 通过该链接的点击数
+	ReadCnt  int64 `json:"read_cnt"`  // 通过该链接带来的阅读数
+	Ctime    int64 `json:"ctime"`
+	Utime    int64 `json:"utime"`
+}
+
+// ShareStats 创作者看到的分享数据
+type ShareStats struct {
+	ShareCnt int64          `json:"share_cnt"`
+	ClickCnt int64          `json:"click_cnt"`
+	ReadCnt  int64          `json:"read_cnt"`
+	Channels []ShareChannel `json:"channels"`
+}
+
+type ShareChannel struct {
+	Channel  string `json:"channel"`
+	ShareCnt int64  `json:"share_cnt"`
+}
+
+const (
+	ShareChannelWechat = "wechat"
+	ShareChannelWeibo  = "weibo"
+	ShareChannelQQ     = "qq"
+	ShareChannelLink   = "link" // 复制链接
+	ShareChannelOther  = "other"
+)
+
+// ValidShareChannel 不认识的渠道统一归到 other
+func ValidShareChannel(channel string) string {
+	switch channel {
+	case ShareChannelWechat, ShareChannelWeibo, ShareChannelQQ, ShareChannelLink:
+		return channel
+	default:
+		return ShareChannelOther
+	}
+}
@@ -0,0 +1,7 @@
+package domain
+
+// TrendingItem 实时热度，最近一段时间里面阅读、点赞、收藏加权累加的分数
+type TrendingItem struct {
+	BizId int64   `json:"biz_id"`
+	Score float64 `json:"score"`
+}
@@ -14,6 +14,8 @@ type User struct {
 	Birthday   time.Time
 	AboutMe    string
 	WechatInfo WechatInfo
+	// LikesPublic 点赞记录是否对其他人公开 默认不公开
+	LikesPublic bool
 }
 
 //type Address struct {
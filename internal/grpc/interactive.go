@@ -0,0 +1,197 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	intrv1 "webook/api/proto/gen/intr/v1"
+	"webook/internal/domain"
+	"webook/internal/service"
+)
+
+// InteractiveServiceServer 把 service.InteractiveService 暴露成 gRPC 服务
+type InteractiveServiceServer struct {
+	intrv1.UnimplementedInteractiveServiceServer
+	svc service.InteractiveService
+}
+
+func NewInteractiveServiceServer(svc service.InteractiveService) *InteractiveServiceServer {
+	return &InteractiveServiceServer{
+		svc: svc,
+	}
+}
+
+func (i *InteractiveServiceServer) Register(server *grpc.Server) {
+	intrv1.RegisterInteractiveServiceServer(server, i)
+}
+
+func (i *InteractiveServiceServer) IncrReadCnt(ctx context.Context, req *intrv1.IncrReadCntRequest) (*intrv1.IncrReadCntResponse, error) {
+	err := i.svc.IncrReadCnt(ctx, req.GetBiz(), req.GetBizId(), req.GetUid())
+	return &intrv1.IncrReadCntResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) Like(ctx context.Context, req *intrv1.LikeRequest) (*intrv1.LikeResponse, error) {
+	err := i.svc.Like(ctx, req.GetBiz(), req.GetBizId(), req.GetUid())
+	return &intrv1.LikeResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) CancelLike(ctx context.Context, req *intrv1.CancelLikeRequest) (*intrv1.CancelLikeResponse, error) {
+	err := i.svc.CancelLike(ctx, req.GetBiz(), req.GetBizId(), req.GetUid())
+	return &intrv1.CancelLikeResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) React(ctx context.Context, req *intrv1.ReactRequest) (*intrv1.ReactResponse, error) {
+	err := i.svc.React(ctx, req.GetBiz(), req.GetBizId(), req.GetUid(), req.GetReaction())
+	return &intrv1.ReactResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) CancelReaction(ctx context.Context, req *intrv1.CancelReactionRequest) (*intrv1.CancelReactionResponse, error) {
+	err := i.svc.CancelReaction(ctx, req.GetBiz(), req.GetBizId(), req.GetUid(), req.GetReaction())
+	return &intrv1.CancelReactionResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) AddCollectionItem(ctx context.Context, req *intrv1.AddCollectionItemRequest) (*intrv1.AddCollectionItemResponse, error) {
+	err := i.svc.AddCollectionItem(ctx, req.GetBiz(), req.GetBizId(), req.GetCid(), req.GetUid())
+	return &intrv1.AddCollectionItemResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) CancelCollectionItem(ctx context.Context, req *intrv1.CancelCollectionItemRequest) (*intrv1.CancelCollectionItemResponse, error) {
+	err := i.svc.CancelCollectionItem(ctx, req.GetBiz(), req.GetBizId(), req.GetCid(), req.GetUid())
+	return &intrv1.CancelCollectionItemResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) MoveCollectionItem(ctx context.Context, req *intrv1.MoveCollectionItemRequest) (*intrv1.MoveCollectionItemResponse, error) {
+	err := i.svc.MoveCollectionItem(ctx, req.GetBiz(), req.GetBizId(), req.GetFromCid(), req.GetToCid(), req.GetUid())
+	return &intrv1.MoveCollectionItemResponse{}, toStatus(err)
+}
+
+func (i *InteractiveServiceServer) Get(ctx context.Context, req *intrv1.GetRequest) (*intrv1.GetResponse, error) {
+	intr, err := i.svc.GetIntrByArtId(ctx, req.GetBiz(), req.GetBizId(), req.GetUid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &intrv1.GetResponse{Intr: toDTO(intr)}, nil
+}
+
+func (i *InteractiveServiceServer) GetByIds(ctx context.Context, req *intrv1.GetByIdsRequest) (*intrv1.GetByIdsResponse, error) {
+	intrs, err := i.svc.GetIntrByArtIds(ctx, req.GetBiz(), req.GetBizIds(), req.GetUid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	res := make(map[int64]*intrv1.Interactive, len(intrs))
+	for id, intr := range intrs {
+		res[id] = toDTO(intr)
+	}
+	return &intrv1.GetByIdsResponse{Intrs: res}, nil
+}
+
+func (i *InteractiveServiceServer) LikeRecords(ctx context.Context, req *intrv1.LikeRecordsRequest) (*intrv1.LikeRecordsResponse, error) {
+	records, err := i.svc.LikeRecords(ctx, req.GetBiz(), req.GetUid(), int(req.GetOffset()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &intrv1.LikeRecordsResponse{Records: slice.Map(records, toRecordDTO)}, nil
+}
+
+func (i *InteractiveServiceServer) CollectRecords(ctx context.Context, req *intrv1.CollectRecordsRequest) (*intrv1.CollectRecordsResponse, error) {
+	records, err := i.svc.CollectRecords(ctx, req.GetBiz(), req.GetUid(), req.GetOnlyPublic(),
+		int(req.GetOffset()), int(req.GetLimit()))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &intrv1.CollectRecordsResponse{Records: slice.Map(records, toRecordDTO)}, nil
+}
+
+// toStatus 业务上的错误转成对应的状态码，客户端再用 fromStatus 转回来
+func toStatus(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, service.ErrCollectionNoPermission):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, service.ErrCollectionNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, service.ErrInvalidReaction):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// fromStatus 把 gRPC 的错误转回 service 层定义的错误
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	switch status.Code(err) {
+	case codes.PermissionDenied:
+		return service.ErrCollectionNoPermission
+	case codes.NotFound:
+		return service.ErrCollectionNotFound
+	case codes.InvalidArgument:
+		return service.ErrInvalidReaction
+	default:
+		return err
+	}
+}
+
+func toDTO(intr domain.Interactive) *intrv1.Interactive {
+	return &intrv1.Interactive{
+		Biz:          intr.Biz,
+		BizId:        intr.BizId,
+		ReadCnt:      intr.ReadCnt,
+		ReaderCnt:    intr.ReaderCnt,
+		LikeCnt:      intr.LikeCnt,
+		CollectCnt:   intr.CollectCnt,
+		CommentCnt:   intr.CommentCnt,
+		ShareCnt:     intr.ShareCnt,
+		Liked:        intr.Liked,
+		Collected:    intr.Collected,
+		ReactionCnts: intr.ReactionCnts,
+		Reactions:    intr.Reactions,
+		Ctime:        intr.Ctime,
+		Utime:        intr.Utime,
+	}
+}
+
+func toDomain(intr *intrv1.Interactive) domain.Interactive {
+	return domain.Interactive{
+		Biz:          intr.GetBiz(),
+		BizId:        intr.GetBizId(),
+		ReadCnt:      intr.GetReadCnt(),
+		ReaderCnt:    intr.GetReaderCnt(),
+		LikeCnt:      intr.GetLikeCnt(),
+		CollectCnt:   intr.GetCollectCnt(),
+		CommentCnt:   intr.GetCommentCnt(),
+		ShareCnt:     intr.GetShareCnt(),
+		Liked:        intr.GetLiked(),
+		Collected:    intr.GetCollected(),
+		ReactionCnts: intr.GetReactionCnts(),
+		Reactions:    intr.GetReactions(),
+		Ctime:        intr.GetCtime(),
+		Utime:        intr.GetUtime(),
+	}
+}
+
+func toRecordDTO(idx int, src domain.InteractiveRecord) *intrv1.InteractiveRecord {
+	return &intrv1.InteractiveRecord{
+		Uid:   src.Uid,
+		Biz:   src.Biz,
+		BizId: src.BizId,
+		Ctime: src.Ctime,
+		Utime: src.Utime,
+	}
+}
+
+func toRecordDomain(idx int, src *intrv1.InteractiveRecord) domain.InteractiveRecord {
+	return domain.InteractiveRecord{
+		Uid:   src.GetUid(),
+		Biz:   src.GetBiz(),
+		BizId: src.GetBizId(),
+		Ctime: src.GetCtime(),
+		Utime: src.GetUtime(),
+	}
+}
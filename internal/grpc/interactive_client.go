@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	intrv1 "webook/api/proto/gen/intr/v1"
+	"webook/internal/domain"
+	"webook/internal/service"
+)
+
+// InteractiveClient 通过 gRPC 调用远程的互动服务，对调用方来说和本地的 service.InteractiveService 一样
+type InteractiveClient struct {
+	client intrv1.InteractiveServiceClient
+}
+
+func NewInteractiveClient(client intrv1.InteractiveServiceClient) service.InteractiveService {
+	return &InteractiveClient{
+		client: client,
+	}
+}
+
+func (i *InteractiveClient) IncrReadCnt(ctx context.Context, biz string, bizId int64, uid int64) error {
+	_, err := i.client.IncrReadCnt(ctx, &intrv1.IncrReadCntRequest{Biz: biz, BizId: bizId, Uid: uid})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) Like(ctx context.Context, biz string, bizId int64, uid int64) error {
+	_, err := i.client.Like(ctx, &intrv1.LikeRequest{Biz: biz, BizId: bizId, Uid: uid})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) CancelLike(ctx context.Context, biz string, bizId int64, uid int64) error {
+	_, err := i.client.CancelLike(ctx, &intrv1.CancelLikeRequest{Biz: biz, BizId: bizId, Uid: uid})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) React(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	_, err := i.client.React(ctx, &intrv1.ReactRequest{Biz: biz, BizId: bizId, Uid: uid, Reaction: reaction})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) CancelReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	_, err := i.client.CancelReaction(ctx, &intrv1.CancelReactionRequest{Biz: biz, BizId: bizId, Uid: uid, Reaction: reaction})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
+	_, err := i.client.AddCollectionItem(ctx, &intrv1.AddCollectionItemRequest{
+		Biz: biz, BizId: bizId, Cid: cid, Uid: uid,
+	})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) CancelCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
+	_, err := i.client.CancelCollectionItem(ctx, &intrv1.CancelCollectionItemRequest{
+		Biz: biz, BizId: bizId, Cid: cid, Uid: uid,
+	})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) MoveCollectionItem(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error {
+	_, err := i.client.MoveCollectionItem(ctx, &intrv1.MoveCollectionItemRequest{
+		Biz: biz, BizId: bizId, FromCid: fromCid, ToCid: toCid, Uid: uid,
+	})
+	return fromStatus(err)
+}
+
+func (i *InteractiveClient) GetIntrByArtId(ctx context.Context, biz string, bizId int64, uid int64) (domain.Interactive, error) {
+	resp, err := i.client.Get(ctx, &intrv1.GetRequest{Biz: biz, BizId: bizId, Uid: uid})
+	if err != nil {
+		return domain.Interactive{}, fromStatus(err)
+	}
+	return toDomain(resp.GetIntr()), nil
+}
+
+func (i *InteractiveClient) GetIntrByArtIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	resp, err := i.client.GetByIds(ctx, &intrv1.GetByIdsRequest{Biz: biz, BizIds: bizIds, Uid: uid})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	res := make(map[int64]domain.Interactive, len(resp.GetIntrs()))
+	for id, intr := range resp.GetIntrs() {
+		res[id] = toDomain(intr)
+	}
+	return res, nil
+}
+
+func (i *InteractiveClient) LikeRecords(ctx context.Context, biz string, uid int64, offset int, limit int) ([]domain.InteractiveRecord, error) {
+	resp, err := i.client.LikeRecords(ctx, &intrv1.LikeRecordsRequest{
+		Biz: biz, Uid: uid, Offset: int32(offset), Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return slice.Map(resp.GetRecords(), toRecordDomain), nil
+}
+
+func (i *InteractiveClient) CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool,
+	offset int, limit int) ([]domain.InteractiveRecord, error) {
+	resp, err := i.client.CollectRecords(ctx, &intrv1.CollectRecordsRequest{
+		Biz: biz, Uid: uid, OnlyPublic: onlyPublic, Offset: int32(offset), Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return slice.Map(resp.GetRecords(), toRecordDomain), nil
+}
@@ -0,0 +1,225 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"net"
+	"testing"
+	intrv1 "webook/api/proto/gen/intr/v1"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/internal/service"
+	"webook/pkg/logger"
+)
+
+var testReactions = domain.ReactionSet{domain.ReactionLike, "funny"}
+
+// nopProducer 这里不关心通知
+type nopProducer struct{}
+
+func (nopProducer) ProduceEvent(event notification.Event) error {
+	return nil
+}
+
+// 同样的用例分别跑本地的 service 和经过 gRPC 的客户端，两种模式的行为要一样
+func TestInteractiveService_LocalAndRemote(t *testing.T) {
+	modes := map[string]func(t *testing.T, repo repository.InteractiveRepository) service.InteractiveService{
+		"local": func(t *testing.T, repo repository.InteractiveRepository) service.InteractiveService {
+			return service.NewInteractiveService(repo, testReactions, nopProducer{}, logger.NewNopLogger())
+		},
+		"remote": newRemoteInteractiveService,
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+		call func(ctx context.Context, svc service.InteractiveService) (any, error)
+		want any
+		// wantErr 不为 nil 的时候用 errors.Is 比较，wantAnyErr 只要求返回错误
+		wantErr    error
+		wantAnyErr bool
+	}{
+		{
+			name: "点赞成功",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddReaction(gomock.Any(), "article", int64(1), int64(123), domain.ReactionLike).Return(true, nil)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return nil, svc.Like(ctx, "article", 1, 123)
+			},
+		},
+		{
+			name: "取消表情",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().DeleteReaction(gomock.Any(), "article", int64(1), int64(123), "funny").Return(nil)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return nil, svc.CancelReaction(ctx, "article", 1, 123, "funny")
+			},
+		},
+		{
+			name: "不支持的表情",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				return repomocks.NewMockInteractiveRepository(ctrl)
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return nil, svc.React(ctx, "article", 1, 123, "angry")
+			},
+			wantErr: service.ErrInvalidReaction,
+		},
+		{
+			name: "收藏到别人的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(123)).
+					Return(false, repository.ErrCollectionNoPermission)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return nil, svc.AddCollectionItem(ctx, "article", 1, 2, 123)
+			},
+			wantErr: service.ErrCollectionNoPermission,
+		},
+		{
+			name: "移动到不存在的收藏夹",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().MoveCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(3), int64(123)).
+					Return(repository.ErrCollectionNotFound)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return nil, svc.MoveCollectionItem(ctx, "article", 1, 2, 3, 123)
+			},
+			wantErr: service.ErrCollectionNotFound,
+		},
+		{
+			name: "数据库错误",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().IncrReadCnt(gomock.Any(), "article", int64(1), int64(123)).
+					Return(errors.New("db error"))
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return nil, svc.IncrReadCnt(ctx, "article", 1, 123)
+			},
+			wantAnyErr: true,
+		},
+		{
+			name: "获取详情",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetInteractive(gomock.Any(), "article", int64(1)).Return(domain.Interactive{
+					Biz: "article", BizId: 1, ReadCnt: 10, ReaderCnt: 3, LikeCnt: 2,
+					ReactionCnts: map[string]int64{domain.ReactionLike: 2},
+				}, nil)
+				repo.EXPECT().Liked(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				repo.EXPECT().Reactions(gomock.Any(), "article", int64(1), int64(123)).
+					Return([]string{domain.ReactionLike}, nil)
+				repo.EXPECT().Collected(gomock.Any(), "article", int64(1), int64(123)).Return(false, nil)
+				repo.EXPECT().ReaderCnt(gomock.Any(), "article", int64(1)).Return(int64(5), nil)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return svc.GetIntrByArtId(ctx, "article", 1, 123)
+			},
+			want: domain.Interactive{
+				Biz: "article", BizId: 1, ReadCnt: 10, ReaderCnt: 5, LikeCnt: 2, Liked: true,
+				// 没人点过的表情也要返回 0
+				ReactionCnts: map[string]int64{domain.ReactionLike: 2, "funny": 0},
+				Reactions:    []string{domain.ReactionLike},
+			},
+		},
+		{
+			name: "批量获取",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}, int64(0)).
+					Return(map[int64]domain.Interactive{
+						1: {Biz: "article", BizId: 1, ReadCnt: 10},
+						2: {Biz: "article", BizId: 2, CommentCnt: 1},
+					}, nil)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return svc.GetIntrByArtIds(ctx, "article", []int64{1, 2}, 0)
+			},
+			want: map[int64]domain.Interactive{
+				1: {Biz: "article", BizId: 1, ReadCnt: 10},
+				2: {Biz: "article", BizId: 2, CommentCnt: 1},
+			},
+		},
+		{
+			name: "收藏记录",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().CollectRecords(gomock.Any(), "article", int64(123), true, 10, 5).
+					Return([]domain.InteractiveRecord{
+						{Uid: 123, Biz: "article", BizId: 1, Ctime: 100, Utime: 200},
+					}, nil)
+				return repo
+			},
+			call: func(ctx context.Context, svc service.InteractiveService) (any, error) {
+				return svc.CollectRecords(ctx, "article", 123, true, 10, 5)
+			},
+			want: []domain.InteractiveRecord{
+				{Uid: 123, Biz: "article", BizId: 1, Ctime: 100, Utime: 200},
+			},
+		},
+	}
+	for mode, newSvc := range modes {
+		for _, tc := range testCases {
+			t.Run(mode+"/"+tc.name, func(t *testing.T) {
+				ctrl := gomock.NewController(t)
+				defer ctrl.Finish()
+				svc := newSvc(t, tc.mock(ctrl))
+				res, err := tc.call(context.Background(), svc)
+				switch {
+				case tc.wantErr != nil:
+					assert.ErrorIs(t, err, tc.wantErr)
+					return
+				case tc.wantAnyErr:
+					assert.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+				if tc.want != nil {
+					assert.Equal(t, tc.want, res)
+				}
+			})
+		}
+	}
+}
+
+// newRemoteInteractiveService 用 bufconn 在进程内起一个 gRPC 服务端，返回连着它的客户端
+func newRemoteInteractiveService(t *testing.T, repo repository.InteractiveRepository) service.InteractiveService {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	NewInteractiveServiceServer(service.NewInteractiveService(repo, testReactions, nopProducer{}, logger.NewNopLogger())).Register(server)
+	go func() {
+		_ = server.Serve(lis)
+	}()
+	t.Cleanup(server.Stop)
+	cc, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = cc.Close()
+	})
+	return NewInteractiveClient(intrv1.NewInteractiveServiceClient(cc))
+}
@@ -5,6 +5,8 @@ package startup
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	"webook/internal/domain/events/article"
+	"webook/internal/domain/events/notification"
 	"webook/internal/repository"
 	"webook/internal/repository/cache"
 	"webook/internal/repository/dao"
@@ -15,7 +17,100 @@ import (
 )
 
 var thirdPartySet = wire.NewSet(
-	InitDB, InitRedis, InitLog,
+	InitDB, InitRedis, InitLog, ioc.InitSnowflakeNode, ioc.InitCacheAside,
+	ioc.InitSaramaClient, ioc.InitSyncProducer, notification.NewSaramaSyncProducer, article.NewSaramaSyncProducer,
+)
+
+var interactiveSvcSet = wire.NewSet(
+	dao.NewGormInteractiveDAO,
+	cache.NewInteractiveCache,
+	cache.NewRedisReadCntBuffer,
+	ioc.InitReaderCache,
+	ioc.InitTrendingCache,
+	ioc.InitTrendingRepository,
+	repository.NewCachedInteractiveRepository,
+	ioc.InitReactionSet,
+	service.NewInteractiveService,
+)
+
+var commentSvcSet = wire.NewSet(
+	dao.NewGormCommentDAO,
+	repository.NewCachedCommentRepository,
+	service.NewCommentService,
+	web.NewCommentHandler,
+)
+
+var shareSvcSet = wire.NewSet(
+	dao.NewGormShareDAO,
+	repository.NewCachedShareRepository,
+	service.NewShareService,
+)
+
+var collectionSvcSet = wire.NewSet(
+	dao.NewGormCollectionDAO,
+	repository.NewCachedCollectionRepository,
+	service.NewCollectionService,
+	web.NewCollectionHandler,
+)
+
+var rankingSvcSet = wire.NewSet(
+	cache.NewRankingRedisCache,
+	cache.NewRankingLocalCache,
+	repository.NewCachedRankingRepository,
+	ioc.InitRankingService,
+	service.NewTrendingService,
+)
+
+var articleStatsSvcSet = wire.NewSet(
+	cache.NewRedisAuthorDashboardCache,
+	repository.NewCachedAuthorDashboardRepository,
+	service.NewAuthorDashboardService,
+	service.NewArticleStatsService,
+	web.NewArticleStatsHandler,
+)
+
+var followSvcSet = wire.NewSet(
+	dao.NewGormFollowDAO,
+	cache.NewRedisFollowCache,
+	repository.NewCachedFollowRepository,
+	service.NewFollowService,
+	web.NewFollowHandler,
+)
+
+var feedSvcSet = wire.NewSet(
+	ioc.InitFeedCache,
+	repository.NewCachedFeedRepository,
+	ioc.InitFeedService,
+	web.NewFeedHandler,
+)
+
+var notificationSvcSet = wire.NewSet(
+	dao.NewGormNotificationDAO,
+	cache.NewRedisNotificationCache,
+	repository.NewCachedNotificationRepository,
+	service.NewNotificationService,
+	web.NewNotificationHandler,
+)
+
+var relatedSvcSet = wire.NewSet(
+	dao.NewGormRelatedDAO,
+	cache.NewRedisRelatedCache,
+	repository.NewCachedRelatedRepository,
+	ioc.InitRelatedService,
+)
+
+var historySvcSet = wire.NewSet(
+	dao.NewGormHistoryDAO,
+	repository.NewDBHistoryRepository,
+	ioc.InitHistoryService,
+	web.NewHistoryHandler,
+)
+
+var jobSvcSet = wire.NewSet(
+	dao.NewGormJobDAO,
+	repository.NewPreemptJobRepository,
+	ioc.InitJobService,
+	ioc.InitJobHandler,
 )
 
 func InitWebServer() *gin.Engine {
@@ -25,15 +120,27 @@ func InitWebServer() *gin.Engine {
 		//dao
 		dao.NewGormUserDAO, dao.NewGormArticleDAO,
 		//cache
-		cache.NewRedisUserCache, cache.NewRedisCodeCache,
+		cache.NewRedisUserCache, cache.NewRedisCodeCache, cache.NewArticleRedisCache,
 		//repository
 		repository.NewCacheUserRepository, repository.NewCodeRepository, repository.NewCachedArticleRepository,
 		//service
 		ioc.InitSMSService, InitWechatService,
 		service.NewUserService, service.NewCodeService, service.NewArticleService,
 		//handler
-		ijwt.NewRedisJWTHandler, web.NewUserHandler, web.NewArticleHandler, web.NewOAuth2WechatHandler,
+		ijwt.NewRedisJWTHandler, ioc.InitActionLimiter, web.NewUserHandler, web.NewArticleHandler, web.NewOAuth2WechatHandler,
 		ioc.InitGinMiddleware, ioc.InitWebService,
+		interactiveSvcSet,
+		commentSvcSet,
+		shareSvcSet,
+		collectionSvcSet,
+		articleStatsSvcSet,
+		rankingSvcSet,
+		followSvcSet,
+		feedSvcSet,
+		notificationSvcSet,
+		relatedSvcSet,
+		historySvcSet,
+		jobSvcSet,
 	)
 	return gin.Default()
 }
@@ -41,8 +148,25 @@ func InitWebServer() *gin.Engine {
 func InitArticleHandler(articleDAO dao.ArticleDAO) *web.ArticleHandler {
 	wire.Build(
 		thirdPartySet,
+		cache.NewArticleRedisCache,
 		repository.NewCachedArticleRepository,
+		interactiveSvcSet,
+		shareSvcSet,
+		rankingSvcSet,
+		dao.NewGormUserDAO,
+		cache.NewRedisUserCache,
+		repository.NewCacheUserRepository,
+		service.NewUserService,
 		service.NewArticleService,
+		dao.NewGormFollowDAO,
+		cache.NewRedisFollowCache,
+		repository.NewCachedFollowRepository,
+		service.NewFollowService,
+		ioc.InitFeedCache,
+		repository.NewCachedFeedRepository,
+		ioc.InitFeedService,
+		relatedSvcSet,
+		ioc.InitActionLimiter,
 		web.NewArticleHandler,
 	)
 	return &web.ArticleHandler{}
@@ -0,0 +1,228 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/robfig/cron/v3"
+	"runtime/debug"
+	"sync"
+	"time"
+	"webook/pkg/logger"
+	"webook/pkg/rlock"
+)
+
+var errJobPanic = errors.New("job: 任务 panic")
+
+// Job 定时任务，Run 要尊重 ctx 的超时
+type Job interface {
+	Name() string
+	Run(ctx context.Context) error
+}
+
+// CronRunner 按 cron 表达式调度任务，多个实例部署的时候通过 redis 分布式锁选出一个 leader 来跑
+// 抢到锁的实例会一直续约，后面每次都由它来跑，直到它退出或者续约失败，其它实例才有机会抢到
+type CronRunner struct {
+	cron   *cron.Cron
+	client *rlock.Client
+	l      logger.Logger
+	jobs   []*cronJob
+
+	// LeaseTTL 锁的租期，每 LeaseTTL/3 续约一次
+	LeaseTTL time.Duration
+
+	duration *prometheus.SummaryVec
+}
+
+type cronJob struct {
+	job     Job
+	timeout time.Duration
+
+	mu   sync.Mutex
+	lock *rlock.Lock
+	// 正在跑的那一次的 cancel，丢了锁的时候要马上停下来
+	cancel context.CancelFunc
+}
+
+func NewCronRunner(client *rlock.Client, l logger.Logger) *CronRunner {
+	return &CronRunner{
+		// 上一次还没跑完的时候跳过这一次
+		cron:     cron.New(cron.WithChain(cron.SkipIfStillRunning(cronLogger{l: l}))),
+		client:   client,
+		l:        l,
+		LeaseTTL: 30 * time.Second,
+		duration: newRunDuration(),
+	}
+}
+
+// newRunDuration CronRunner 和 Scheduler 共用一个指标
+func newRunDuration() *prometheus.SummaryVec {
+	duration := prometheus.NewSummaryVec(prometheus.SummaryOpts{
+		Namespace: "webook",
+		Subsystem: "job",
+		Name:      "run_duration_ms",
+		Help:      "定时任务一次运行的耗时，result 是 success、error、timeout、canceled 或者 panic",
+		Objectives: map[float64]float64{
+			0.5:  0.01,
+			0.9:  0.01,
+			0.99: 0.001,
+		},
+	}, []string{"job", "result"})
+	err := prometheus.Register(duration)
+	if err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			panic(err)
+		}
+		duration = are.ExistingCollector.(*prometheus.SummaryVec)
+	}
+	return duration
+}
+
+// AddJob 注册任务，spec 是标准的 5 位 cron 表达式，也支持 @every 1m 这种写法
+func (r *CronRunner) AddJob(spec string, job Job, timeout time.Duration) error {
+	j := &cronJob{
+		job:     job,
+		timeout: timeout,
+	}
+	_, err := r.cron.AddFunc(spec, func() {
+		r.run(j)
+	})
+	if err != nil {
+		return fmt.Errorf("注册任务 %s 失败: %w", job.Name(), err)
+	}
+	r.jobs = append(r.jobs, j)
+	return nil
+}
+
+func (r *CronRunner) Start() {
+	r.cron.Start()
+}
+
+// Close 不再调度新的任务，等正在跑的跑完，然后把锁都放掉，让别的实例尽快接手
+func (r *CronRunner) Close() {
+	<-r.cron.Stop().Done()
+	for _, j := range r.jobs {
+		j.mu.Lock()
+		lock := j.lock
+		j.lock = nil
+		j.mu.Unlock()
+		if lock == nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		err := lock.Unlock(ctx)
+		cancel()
+		if err != nil {
+			r.l.Error("释放任务锁失败", logger.String("job", j.job.Name()), logger.Error(err))
+		}
+	}
+}
+
+func (r *CronRunner) run(j *cronJob) {
+	if !r.elect(j) {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), j.timeout)
+	j.mu.Lock()
+	j.cancel = cancel
+	j.mu.Unlock()
+	defer func() {
+		j.mu.Lock()
+		j.cancel = nil
+		j.mu.Unlock()
+		cancel()
+	}()
+
+	name := j.job.Name()
+	start := time.Now()
+	err := exec(ctx, j.job.Run)
+	result := runResult(ctx, err)
+	r.duration.WithLabelValues(name, result).Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		r.l.Error("定时任务运行失败", logger.String("job", name),
+			logger.String("result", result), logger.Error(err))
+	}
+}
+
+// exec 执行任务，panic 转成 error
+func exec(ctx context.Context, run func(ctx context.Context) error) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("%w: %v\n%s", errJobPanic, e, debug.Stack())
+		}
+	}()
+	return run(ctx)
+}
+
+func runResult(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(err, errJobPanic):
+		return "panic"
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(ctx.Err(), context.Canceled):
+		return "canceled"
+	case err != nil:
+		return "error"
+	}
+	return "success"
+}
+
+// elect 已经是 leader 直接返回 true，不是的话抢一次锁，抢到了就开始自动续约
+func (r *CronRunner) elect(j *cronJob) bool {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.lock != nil {
+		return true
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	lock, err := r.client.TryLock(ctx, "job:lock:"+j.job.Name(), r.LeaseTTL)
+	if err != nil {
+		if !errors.Is(err, rlock.ErrFailedToPreemptLock) {
+			r.l.Error("抢任务锁失败", logger.String("job", j.job.Name()), logger.Error(err))
+		}
+		return false
+	}
+	j.lock = lock
+	go func() {
+		err := lock.AutoRefresh(r.LeaseTTL/3, time.Second)
+		if err == nil {
+			// 主动释放的
+			return
+		}
+		r.l.Error("任务锁续约失败，放弃 leader", logger.String("job", j.job.Name()), logger.Error(err))
+		j.mu.Lock()
+		defer j.mu.Unlock()
+		if j.lock == lock {
+			j.lock = nil
+			if j.cancel != nil {
+				j.cancel()
+			}
+		}
+	}()
+	return true
+}
+
+// cronLogger 把 cron 的日志转到 logger.Logger
+type cronLogger struct {
+	l logger.Logger
+}
+
+func (c cronLogger) Info(msg string, keysAndValues ...interface{}) {
+	c.l.Info(msg, c.fields(keysAndValues)...)
+}
+
+func (c cronLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	c.l.Error(msg, append(c.fields(keysAndValues), logger.Error(err))...)
+}
+
+func (c cronLogger) fields(keysAndValues []interface{}) []logger.Field {
+	fields := make([]logger.Field, 0, len(keysAndValues)/2)
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		fields = append(fields, logger.Field{Key: fmt.Sprint(keysAndValues[i]), Value: keysAndValues[i+1]})
+	}
+	return fields
+}
@@ -0,0 +1,132 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	redismocks "webook/internal/repository/cache/rediscache"
+	"webook/pkg/logger"
+	"webook/pkg/rlock"
+)
+
+type fakeJob struct {
+	name string
+	run  func(ctx context.Context) error
+	runs int
+}
+
+func (f *fakeJob) Name() string {
+	return f.name
+}
+
+func (f *fakeJob) Run(ctx context.Context) error {
+	f.runs++
+	return f.run(ctx)
+}
+
+func TestCronRunner_Run(t *testing.T) {
+	testCases := []struct {
+		name string
+		// 抢锁返回的结果，OK 表示抢到了
+		lockRes  string
+		run      func(ctx context.Context) error
+		wantRuns int
+		// 调度两次，没有跑的时候 wantResult 为空
+		wantResult string
+	}{
+		{
+			name:    "成功",
+			lockRes: "OK",
+			run: func(ctx context.Context) error {
+				return nil
+			},
+			wantRuns:   2,
+			wantResult: "success",
+		},
+		{
+			name:    "失败",
+			lockRes: "OK",
+			run: func(ctx context.Context) error {
+				return errors.New("db error")
+			},
+			wantRuns:   2,
+			wantResult: "error",
+		},
+		{
+			name:    "超时",
+			lockRes: "OK",
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantRuns:   2,
+			wantResult: "timeout",
+		},
+		{
+			name:    "panic",
+			lockRes: "OK",
+			run: func(ctx context.Context) error {
+				panic("boom")
+			},
+			wantRuns:   2,
+			wantResult: "panic",
+		},
+		{
+			name:    "别的实例是leader",
+			lockRes: "",
+			run: func(ctx context.Context) error {
+				return nil
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := redismocks.NewMockCmdable(ctrl)
+			// 第一次抢到锁以后就是 leader 了，第二次不用再抢，Close 的时候释放
+			lockTimes := 2
+			if tc.lockRes == "OK" {
+				lockTimes = 1
+			}
+			cmd.EXPECT().Eval(gomock.Any(), gomock.Any(), []string{"job:lock:" + tc.name}, gomock.Any(), int64(60000)).
+				Return(redis.NewCmdResult(tc.lockRes, nil)).Times(lockTimes)
+			if tc.lockRes == "OK" {
+				cmd.EXPECT().Eval(gomock.Any(), gomock.Any(), []string{"job:lock:" + tc.name}, gomock.Any()).
+					Return(redis.NewCmdResult(int64(1), nil))
+			}
+
+			r := NewCronRunner(rlock.NewClient(cmd), logger.NewNopLogger())
+			r.LeaseTTL = time.Minute
+			t.Cleanup(func() {
+				prometheus.Unregister(r.duration)
+			})
+			job := &fakeJob{name: tc.name, run: tc.run}
+			require.NoError(t, r.AddJob("@every 1h", job, 10*time.Millisecond))
+			j := r.jobs[0]
+			r.run(j)
+			r.run(j)
+			r.Close()
+
+			assert.Equal(t, tc.wantRuns, job.runs)
+			if tc.wantResult == "" {
+				return
+			}
+			assert.Equal(t, uint64(2), sampleCount(t, r.duration, tc.name, tc.wantResult))
+		})
+	}
+}
+
+// sampleCount 某个任务某种结果的运行次数
+func sampleCount(t *testing.T, vec *prometheus.SummaryVec, labels ...string) uint64 {
+	var m dto.Metric
+	require.NoError(t, vec.WithLabelValues(labels...).(prometheus.Metric).Write(&m))
+	return m.GetSummary().GetSampleCount()
+}
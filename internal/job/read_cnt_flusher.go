@@ -0,0 +1,140 @@
+package job
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+// ReadCntFlusher 定时把缓冲的阅读数刷到数据库
+// 满足任意一个条件就刷：待刷新的资源数达到 BatchSize，或者距离上次刷新超过 Interval
+// 刷阅读数的时候顺便把独立读者数持久化
+type ReadCntFlusher struct {
+	repo repository.InteractiveRepository
+	l    logger.Logger
+
+	// BatchSize 和 Interval 是刷新的触发条件，CheckInterval 是检查缓冲区深度的间隔
+	BatchSize     int64
+	Interval      time.Duration
+	CheckInterval time.Duration
+	// Timeout 一次刷新的超时时间，要比缓冲区锁的租期短
+	Timeout time.Duration
+
+	depth    prometheus.Gauge
+	flushed  prometheus.Counter
+	failures prometheus.Counter
+	duration prometheus.Summary
+
+	stop chan struct{}
+	wg   sync.WaitGroup
+}
+
+func NewReadCntFlusher(repo repository.InteractiveRepository, l logger.Logger) *ReadCntFlusher {
+	f := &ReadCntFlusher{
+		repo:          repo,
+		l:             l,
+		BatchSize:     1000,
+		Interval:      10 * time.Second,
+		CheckInterval: time.Second,
+		Timeout:       10 * time.Second,
+		depth: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "read_cnt_buffer_depth",
+			Help:      "缓冲区里面待刷新的资源个数",
+		}),
+		flushed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "read_cnt_flushed_total",
+			Help:      "刷到数据库的资源个数",
+		}),
+		failures: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "read_cnt_flush_failures_total",
+			Help:      "刷新失败的次数",
+		}),
+		duration: prometheus.NewSummary(prometheus.SummaryOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "read_cnt_flush_duration_ms",
+			Help:      "一次刷新的耗时",
+			Objectives: map[float64]float64{
+				0.5:  0.01,
+				0.9:  0.01,
+				0.99: 0.001,
+			},
+		}),
+		stop: make(chan struct{}),
+	}
+	prometheus.MustRegister(f.depth, f.flushed, f.failures, f.duration)
+	return f
+}
+
+func (f *ReadCntFlusher) Start() {
+	f.wg.Add(1)
+	go func() {
+		defer f.wg.Done()
+		ticker := time.NewTicker(f.CheckInterval)
+		defer ticker.Stop()
+		last := time.Now()
+		for {
+			select {
+			case <-f.stop:
+				return
+			case <-ticker.C:
+			}
+			depth, err := f.checkDepth()
+			if err != nil {
+				f.l.Error("获取阅读数缓冲区深度失败", logger.Error(err))
+				continue
+			}
+			if depth < f.BatchSize && time.Since(last) < f.Interval {
+				continue
+			}
+			f.flush()
+			last = time.Now()
+		}
+	}()
+}
+
+// Close 停止定时刷新，然后把剩下的刷一次
+// 这一次失败也没关系，数据还在 redis 里面，下次启动或者别的实例会刷
+func (f *ReadCntFlusher) Close() {
+	close(f.stop)
+	f.wg.Wait()
+	f.flush()
+}
+
+func (f *ReadCntFlusher) checkDepth() (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	depth, err := f.repo.ReadCntDepth(ctx)
+	if err != nil {
+		return 0, err
+	}
+	f.depth.Set(float64(depth))
+	return depth, nil
+}
+
+func (f *ReadCntFlusher) flush() {
+	ctx, cancel := context.WithTimeout(context.Background(), f.Timeout)
+	defer cancel()
+	start := time.Now()
+	cnt, err := f.repo.FlushReadCnt(ctx)
+	f.duration.Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		f.failures.Inc()
+		f.l.Error("刷新阅读数失败", logger.Error(err))
+	} else {
+		f.flushed.Add(float64(cnt))
+	}
+	_, err = f.repo.FlushReaderCnt(ctx)
+	if err != nil {
+		f.l.Error("持久化独立读者数失败", logger.Error(err))
+	}
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"webook/internal/service"
+)
+
+// RelatedJob 定时重新计算相关文章
+type RelatedJob struct {
+	svc service.RelatedService
+}
+
+func NewRelatedJob(svc service.RelatedService) *RelatedJob {
+	return &RelatedJob{
+		svc: svc,
+	}
+}
+
+func (r *RelatedJob) Name() string {
+	return "related"
+}
+
+func (r *RelatedJob) Run(ctx context.Context) error {
+	return r.svc.Compute(ctx)
+}
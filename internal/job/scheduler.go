@@ -0,0 +1,206 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/semaphore"
+	"sync"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/service"
+	"webook/pkg/logger"
+)
+
+// Executor 执行数据库里面的任务，任务的 Executor 字段决定用哪个
+type Executor interface {
+	Name() string
+	Exec(ctx context.Context, j domain.Job) error
+}
+
+// LocalExecutor 在当前进程里面执行，按任务名字找注册的 Job
+type LocalExecutor struct {
+	jobs map[string]Job
+}
+
+func NewLocalExecutor() *LocalExecutor {
+	return &LocalExecutor{
+		jobs: make(map[string]Job),
+	}
+}
+
+func (l *LocalExecutor) Name() string {
+	return "local"
+}
+
+func (l *LocalExecutor) Register(j Job) {
+	l.jobs[j.Name()] = j
+}
+
+func (l *LocalExecutor) Exec(ctx context.Context, j domain.Job) error {
+	job, ok := l.jobs[j.Name]
+	if !ok {
+		return fmt.Errorf("未注册的本地任务 %s", j.Name)
+	}
+	return job.Run(ctx)
+}
+
+// Scheduler 不停地从数据库抢到期的任务来执行，执行期间定时心跳，执行完释放并设置下一次执行时间
+// 实例挂了的话心跳会断掉，任务过一段时间被别的实例抢走
+type Scheduler struct {
+	svc       service.JobService
+	executors map[string]Executor
+	l         logger.Logger
+
+	// Concurrency 同时执行的任务数
+	Concurrency int64
+	// Interval 没有任务可以抢的时候，隔多久再抢
+	Interval time.Duration
+	// HeartbeatInterval 要比 JobService 判断实例挂了的超时短得多
+	HeartbeatInterval time.Duration
+	// Timeout 一次执行的超时时间
+	Timeout time.Duration
+
+	duration *prometheus.SummaryVec
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewScheduler(svc service.JobService, l logger.Logger) *Scheduler {
+	return &Scheduler{
+		svc:               svc,
+		executors:         make(map[string]Executor),
+		l:                 l,
+		Concurrency:       10,
+		Interval:          time.Second,
+		HeartbeatInterval: 10 * time.Second,
+		Timeout:           time.Minute,
+		duration:          newRunDuration(),
+	}
+}
+
+func (s *Scheduler) RegisterExecutor(e Executor) {
+	s.executors[e.Name()] = e
+}
+
+func (s *Scheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	sem := semaphore.NewWeighted(s.Concurrency)
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			// 并发数满了就等正在执行的任务结束
+			if sem.Acquire(ctx, 1) != nil {
+				return
+			}
+			j, err := s.preempt(ctx)
+			if err != nil {
+				sem.Release(1)
+				if !errors.Is(err, service.ErrNoJob) && ctx.Err() == nil {
+					s.l.Error("抢占任务失败", logger.Error(err))
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case <-time.After(s.Interval):
+				}
+				continue
+			}
+			s.wg.Add(1)
+			go func() {
+				defer s.wg.Done()
+				defer sem.Release(1)
+				s.exec(j)
+			}()
+		}
+	}()
+}
+
+// Close 不再抢新的任务，等正在执行的执行完并释放
+func (s *Scheduler) Close() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Scheduler) preempt(ctx context.Context) (domain.Job, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	return s.svc.Preempt(ctx)
+}
+
+func (s *Scheduler) exec(j domain.Job) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.Timeout)
+	defer cancel()
+	executor, ok := s.executors[j.Executor]
+	var err error
+	start := time.Now()
+	if ok {
+		stop := s.heartbeat(j, cancel)
+		err = exec(ctx, func(ctx context.Context) error {
+			return executor.Exec(ctx, j)
+		})
+		stop()
+	} else {
+		err = fmt.Errorf("未知的执行器 %s", j.Executor)
+	}
+	result := runResult(ctx, err)
+	s.duration.WithLabelValues(j.Name, result).Observe(float64(time.Since(start).Milliseconds()))
+	if err != nil {
+		s.l.Error("执行任务失败", logger.Int64("id", j.Id), logger.String("job", j.Name),
+			logger.String("result", result), logger.Error(err))
+	}
+
+	// 失败了也按正常的节奏算下一次，不然一个一直失败的任务会被反复执行
+	rctx, rcancel := context.WithTimeout(context.Background(), time.Second)
+	defer rcancel()
+	err = s.svc.Release(rctx, j)
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrJobNotHold):
+		// 执行期间被暂停了或者心跳断了被别人抢走了
+		s.l.Warn("释放任务的时候任务已经不属于当前实例", logger.Int64("id", j.Id), logger.String("job", j.Name))
+	default:
+		// 释放失败了也不要紧，心跳超时之后会被重新抢占
+		s.l.Error("释放任务失败", logger.Int64("id", j.Id), logger.String("job", j.Name), logger.Error(err))
+	}
+}
+
+// heartbeat 定时续约，任务不再属于当前实例的时候调用 cancel 中断执行，返回的函数用来停止续约
+func (s *Scheduler) heartbeat(j domain.Job, cancel context.CancelFunc) func() {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(s.HeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			ctx, cc := context.WithTimeout(context.Background(), time.Second)
+			err := s.svc.Heartbeat(ctx, j)
+			cc()
+			if errors.Is(err, service.ErrJobNotHold) {
+				s.l.Warn("任务已经不属于当前实例，停止执行", logger.Int64("id", j.Id), logger.String("job", j.Name))
+				cancel()
+				return
+			}
+			if err != nil {
+				s.l.Error("任务心跳失败", logger.Int64("id", j.Id), logger.String("job", j.Name), logger.Error(err))
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
@@ -0,0 +1,117 @@
+package job
+
+import (
+	"context"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/service"
+	"webook/pkg/logger"
+)
+
+// fakeJobService 只有一个任务，抢到以后记录心跳和释放
+type fakeJobService struct {
+	service.JobService
+
+	mu        sync.Mutex
+	job       domain.Job
+	preempted bool
+	// heartbeatErr 心跳返回的错误
+	heartbeatErr error
+	released     chan domain.Job
+}
+
+func (f *fakeJobService) Preempt(ctx context.Context) (domain.Job, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.preempted {
+		return domain.Job{}, service.ErrNoJob
+	}
+	f.preempted = true
+	return f.job, nil
+}
+
+func (f *fakeJobService) Heartbeat(ctx context.Context, j domain.Job) error {
+	return f.heartbeatErr
+}
+
+func (f *fakeJobService) Release(ctx context.Context, j domain.Job) error {
+	f.released <- j
+	return f.heartbeatErr
+}
+
+func TestScheduler(t *testing.T) {
+	testCases := []struct {
+		name         string
+		job          domain.Job
+		heartbeatErr error
+		run          func(ctx context.Context) error
+		wantResult   string
+	}{
+		{
+			name: "执行成功",
+			job:  domain.Job{Id: 1, Name: "sched_ok", Executor: "local"},
+			run: func(ctx context.Context) error {
+				return nil
+			},
+			wantResult: "success",
+		},
+		{
+			name:         "执行期间任务被抢走",
+			job:          domain.Job{Id: 2, Name: "sched_lost", Executor: "local"},
+			heartbeatErr: service.ErrJobNotHold,
+			run: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			wantResult: "canceled",
+		},
+		{
+			name:       "未知的执行器也要释放",
+			job:        domain.Job{Id: 3, Name: "sched_unknown", Executor: "remote"},
+			wantResult: "error",
+		},
+		{
+			name: "panic",
+			job:  domain.Job{Id: 4, Name: "sched_panic", Executor: "local"},
+			run: func(ctx context.Context) error {
+				panic("boom")
+			},
+			wantResult: "panic",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			svc := &fakeJobService{
+				job:          tc.job,
+				heartbeatErr: tc.heartbeatErr,
+				released:     make(chan domain.Job, 1),
+			}
+			s := NewScheduler(svc, logger.NewNopLogger())
+			t.Cleanup(func() {
+				prometheus.Unregister(s.duration)
+			})
+			s.Interval = 10 * time.Millisecond
+			s.HeartbeatInterval = 10 * time.Millisecond
+			s.Timeout = time.Second
+			local := NewLocalExecutor()
+			if tc.run != nil {
+				local.Register(&fakeJob{name: tc.job.Name, run: tc.run})
+			}
+			s.RegisterExecutor(local)
+			s.Start()
+
+			select {
+			case j := <-svc.released:
+				assert.Equal(t, tc.job, j)
+			case <-time.After(time.Second):
+				t.Fatal("任务没有被释放")
+			}
+			s.Close()
+			assert.Equal(t, uint64(1), sampleCount(t, s.duration, tc.job.Name, tc.wantResult))
+		})
+	}
+}
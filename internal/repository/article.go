@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/ecodeclub/ekit/slice"
 	"gorm.io/gorm"
@@ -9,6 +10,13 @@ import (
 	"webook/internal/domain"
 	"webook/internal/repository/cache"
 	"webook/internal/repository/dao"
+	"webook/pkg/cachex"
+)
+
+var (
+	ErrArticleNotFound = dao.ErrRecordNotFound
+	// ErrAuthorNotFound 文章在但是创作者查不到，不能当成文章不存在记空值
+	ErrAuthorNotFound = errors.New("创作者不存在")
 )
 
 type ArticleRepository interface {
@@ -19,65 +27,115 @@ type ArticleRepository interface {
 	GetByAuthor(ctx context.Context, limit, offset int, uid int64) ([]domain.Article, error)
 	GetByArtId(ctx context.Context, artId int64) (domain.Article, error)
 	GetPubByArtId(ctx context.Context, artId int64) (domain.Article, error)
+	// GetPubByArtIds 列表页用 只需要摘要
+	GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error)
+	GetPubIdsByAuthor(ctx context.Context, uid int64) ([]int64, error)
+	// GetPubBriefsByAuthor 作者发表过的所有文章，没有内容
+	GetPubBriefsByAuthor(ctx context.Context, uid int64) ([]domain.Article, error)
+	// ListPub start 之后更新过的已发表文章，没有内容
+	// last 是上一批的最后一篇，第一批传零值
+	ListPub(ctx context.Context, start time.Time, last domain.Article, limit int) ([]domain.Article, error)
 }
 
-func (c *CachedArticleRepository) GetPubByArtId(ctx context.Context, artId int64) (domain.Article, error) {
-	val, err := c.cache.GetPub(ctx, artId)
-	if err == nil {
-		return val, nil
-	}
-	// 线上库
-	res, err := c.dao.GetPubByArtId(ctx, artId)
+func (c *CachedArticleRepository) ListPub(ctx context.Context, start time.Time, last domain.Article, limit int) ([]domain.Article, error) {
+	arts, err := c.dao.ListPub(ctx, start.UnixMilli(), last.Utime, last.Id, limit)
 	if err != nil {
-		return domain.Article{}, err
+		return nil, err
 	}
-	// 转换
-	art := c.toDomain(dao.Article{
-		Id:       res.Id,
-		Title:    res.Title,
-		Content:  res.Content,
-		AuthorId: res.AuthorId,
-		Status:   res.Status,
-		Ctime:    res.Ctime,
-		Utime:    res.Utime,
-	})
-	// 延迟加载 创作者信息
-	uid, err := c.userRepo.FindById(ctx, art.Author.Id)
+	return slice.Map[dao.ArticlePublish, domain.Article](arts, func(idx int, src dao.ArticlePublish) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
+}
+
+func (c *CachedArticleRepository) GetPubBriefsByAuthor(ctx context.Context, uid int64) ([]domain.Article, error) {
+	arts, err := c.dao.GetPubBriefsByAuthor(ctx, uid)
 	if err != nil {
-		// 没有获取到创作者名字，但是上一步数据获取到了
-		return art, err
-		//return domain.Article{}, err  这种数据被抛弃了，需要记录日志
+		return nil, err
 	}
-	art.Author.Name = uid.Nickname
-	go func() {
-		ctx, cancel := context.WithTimeout(ctx, time.Second)
-		defer cancel()
-		err := c.cache.SetPub(ctx, art)
-		if err != nil {
-			// 记录日志 监控
-			fmt.Println("缓存回写失败", err)
-		}
-	}()
-	return art, nil
+	return slice.Map[dao.ArticlePublish, domain.Article](arts, func(idx int, src dao.ArticlePublish) domain.Article {
+		return c.toDomain(dao.Article(src))
+	}), nil
 }
 
-func (c *CachedArticleRepository) GetByArtId(ctx context.Context, artId int64) (domain.Article, error) {
-	res, err := c.cache.Get(ctx, artId)
-	if err == nil {
-		return res, err
+func (c *CachedArticleRepository) GetPubIdsByAuthor(ctx context.Context, uid int64) ([]int64, error) {
+	return c.dao.GetPubIdsByAuthor(ctx, uid)
+}
+
+func (c *CachedArticleRepository) GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error) {
+	if len(artIds) == 0 {
+		return nil, nil
 	}
-	art, err := c.dao.GetByArtId(ctx, artId)
+	arts, err := c.dao.GetPubByArtIds(ctx, artIds)
 	if err != nil {
-		return domain.Article{}, err
+		return nil, err
 	}
-	go func() {
-		err := c.cache.Set(ctx, c.toDomain(art))
-		if err != nil {
-			// 记录日志 监控
-			fmt.Println("缓存回写失败", err)
-		}
-	}()
-	return c.toDomain(art), nil
+	return slice.Map[dao.ArticlePublish, domain.Article](arts, func(idx int, src dao.ArticlePublish) domain.Article {
+		art := c.toDomain(dao.Article(src))
+		art.Content = art.Abstract()
+		return art
+	}), nil
+}
+
+func (c *CachedArticleRepository) GetPubByArtId(ctx context.Context, artId int64) (domain.Article, error) {
+	return cachex.Get(ctx, c.aside, cachex.Query[domain.Article]{
+		Key: c.pubKey(artId),
+		Get: func(ctx context.Context) (domain.Article, error) {
+			return c.cache.GetPub(ctx, artId)
+		},
+		Load: func(ctx context.Context) (domain.Article, error) {
+			// 线上库
+			res, err := c.dao.GetPubByArtId(ctx, artId)
+			if err != nil {
+				return domain.Article{}, err
+			}
+			art := c.toDomain(dao.Article(res))
+			// 延迟加载 创作者信息
+			u, err := c.userRepo.FindById(ctx, art.Author.Id)
+			if errors.Is(err, ErrUserNotFound) {
+				err = ErrAuthorNotFound
+			}
+			if err != nil {
+				// 没有获取到创作者名字，但是上一步数据获取到了，不回写缓存
+				return art, err
+			}
+			art.Author.Name = u.Nickname
+			return art, nil
+		},
+		Set: func(ctx context.Context, art domain.Article) error {
+			err := c.cache.SetPub(ctx, art)
+			if err != nil {
+				// 记录日志 监控
+				fmt.Println("缓存回写失败", err)
+			}
+			return err
+		},
+		NotFound: ErrArticleNotFound,
+	})
+}
+
+func (c *CachedArticleRepository) GetByArtId(ctx context.Context, artId int64) (domain.Article, error) {
+	return cachex.Get(ctx, c.aside, cachex.Query[domain.Article]{
+		Key: c.key(artId),
+		Get: func(ctx context.Context) (domain.Article, error) {
+			return c.cache.Get(ctx, artId)
+		},
+		Load: func(ctx context.Context) (domain.Article, error) {
+			art, err := c.dao.GetByArtId(ctx, artId)
+			if err != nil {
+				return domain.Article{}, err
+			}
+			return c.toDomain(art), nil
+		},
+		Set: func(ctx context.Context, art domain.Article) error {
+			err := c.cache.Set(ctx, art)
+			if err != nil {
+				// 记录日志 监控
+				fmt.Println("缓存回写失败", err)
+			}
+			return err
+		},
+		NotFound: ErrArticleNotFound,
+	})
 }
 
 func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, limit, offset int, uid int64) ([]domain.Article, error) {
@@ -118,7 +176,8 @@ func (c *CachedArticleRepository) GetByAuthor(ctx context.Context, limit, offset
 type CachedArticleRepository struct {
 	dao      dao.ArticleDAO
 	cache    cache.ArticleCache
-	userRepo CacheUserRepository
+	userRepo UserRepository
+	aside    *cachex.Aside
 	// repository层 V2分发 SyncV1专用
 	authorDAO dao.ArticleAuthorDAO
 	readerDAO dao.ArticleReaderDAO
@@ -146,10 +205,13 @@ func (c *CachedArticleRepository) SyncStatus(ctx context.Context, artId int64, u
 	return nil
 }
 
-func NewCachedArticleRepository(dao dao.ArticleDAO, cache cache.ArticleCache) ArticleRepository {
+func NewCachedArticleRepository(dao dao.ArticleDAO, cache cache.ArticleCache,
+	userRepo UserRepository, aside *cachex.Aside) ArticleRepository {
 	return &CachedArticleRepository{
-		dao:   dao,
-		cache: cache,
+		dao:      dao,
+		cache:    cache,
+		userRepo: userRepo,
+		aside:    aside,
 	}
 }
 
@@ -158,6 +220,11 @@ func (c *CachedArticleRepository) Sync(ctx context.Context, art domain.Article)
 	if err != nil {
 		return 0, err
 	}
+	// 发表之前可能被当成不存在记了空值
+	err = c.aside.Forget(ctx, c.pubKey(artId))
+	if err != nil {
+		return 0, err
+	}
 	err = c.cache.DelFirstPage(ctx, artId)
 	if err != nil {
 		return 0, err
@@ -252,6 +319,10 @@ func (c *CachedArticleRepository) Create(ctx context.Context, art domain.Article
 	if err != nil {
 		return 0, err
 	}
+	err = c.aside.Forget(ctx, c.key(artId))
+	if err != nil {
+		return 0, err
+	}
 	err = c.cache.DelFirstPage(ctx, artId)
 	if err != nil {
 		return 0, err
@@ -259,6 +330,15 @@ func (c *CachedArticleRepository) Create(ctx context.Context, art domain.Article
 	return artId, nil
 }
 
+// pubKey 和 key 只用来合并请求和记空值，和缓存里面的 key 没有关系
+func (c *CachedArticleRepository) pubKey(artId int64) string {
+	return fmt.Sprintf("article:pub:%d", artId)
+}
+
+func (c *CachedArticleRepository) key(artId int64) string {
+	return fmt.Sprintf("article:%d", artId)
+}
+
 func (c *CachedArticleRepository) toEntity(art domain.Article) dao.Article {
 	return dao.Article{
 		Id:       art.Id,
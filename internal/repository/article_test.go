@@ -1,9 +1,110 @@
 package repository
 
 import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	cachemocks "webook/internal/repository/cache/mocks"
+	redismocks "webook/internal/repository/cache/rediscache"
+	"webook/internal/repository/dao"
+	daomocks "webook/internal/repository/dao/mocks"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/cachex"
 )
 
 func TestCachedArticleRepository_SyncV1(t *testing.T) {
 
 }
+
+func TestCachedArticleRepository_GetPubByArtId(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, UserRepository, redis.Cmdable)
+
+		wantAuthor string
+		wantErr    error
+	}{
+		{
+			name: "缓存未命中，回写缓存",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, UserRepository, redis.Cmdable) {
+				d := daomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				cmd := redismocks.NewMockCmdable(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:article:pub:1").Return(redis.NewIntResult(0, nil))
+				d.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(dao.ArticlePublish{Id: 1, AuthorId: 2, Title: "标题"}, nil)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{Id: 2, Nickname: "作者"}, nil)
+				c.EXPECT().SetPub(gomock.Any(), gomock.Any()).Return(nil)
+				return d, c, userRepo, cmd
+			},
+			wantAuthor: "作者",
+		},
+		{
+			name: "文章不存在，记空值",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, UserRepository, redis.Cmdable) {
+				d := daomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				cmd := redismocks.NewMockCmdable(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:article:pub:1").Return(redis.NewIntResult(0, nil))
+				d.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).Return(dao.ArticlePublish{}, dao.ErrRecordNotFound)
+				cmd.EXPECT().Set(gomock.Any(), "cache:nil:article:pub:1", "", gomock.Any()).
+					Return(redis.NewStatusResult("OK", nil))
+				return d, c, userRepo, cmd
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "创作者不存在，不记空值也不回写",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, UserRepository, redis.Cmdable) {
+				d := daomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				cmd := redismocks.NewMockCmdable(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:article:pub:1").Return(redis.NewIntResult(0, nil))
+				d.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(dao.ArticlePublish{Id: 1, AuthorId: 2, Title: "标题"}, nil)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{}, ErrUserNotFound)
+				return d, c, userRepo, cmd
+			},
+			wantErr: ErrAuthorNotFound,
+		},
+		{
+			name: "查询创作者出错",
+			mock: func(ctrl *gomock.Controller) (dao.ArticleDAO, cache.ArticleCache, UserRepository, redis.Cmdable) {
+				d := daomocks.NewMockArticleDAO(ctrl)
+				c := cachemocks.NewMockArticleCache(ctrl)
+				userRepo := repomocks.NewMockUserRepository(ctrl)
+				cmd := redismocks.NewMockCmdable(ctrl)
+				c.EXPECT().GetPub(gomock.Any(), int64(1)).Return(domain.Article{}, cache.ErrKeyNotExist)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:article:pub:1").Return(redis.NewIntResult(0, nil))
+				d.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(dao.ArticlePublish{Id: 1, AuthorId: 2, Title: "标题"}, nil)
+				userRepo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.User{}, errors.New("mock db error"))
+				return d, c, userRepo, cmd
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c, userRepo, cmd := tc.mock(ctrl)
+			repo := NewCachedArticleRepository(d, c, userRepo, cachex.NewAside(cmd, time.Second))
+			art, err := repo.GetPubByArtId(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantAuthor, art.Author.Name)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+)
+
+// AuthorDashboardRepository 作者看板只放在缓存里面，丢了可以重新算
+type AuthorDashboardRepository interface {
+	Get(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error)
+	Set(ctx context.Context, dash domain.AuthorDashboard) error
+	GetBase(ctx context.Context, uid int64) (domain.AuthorStatsBase, error)
+	SetBase(ctx context.Context, base domain.AuthorStatsBase) error
+}
+
+type CachedAuthorDashboardRepository struct {
+	cache cache.AuthorDashboardCache
+}
+
+func NewCachedAuthorDashboardRepository(cache cache.AuthorDashboardCache) AuthorDashboardRepository {
+	return &CachedAuthorDashboardRepository{
+		cache: cache,
+	}
+}
+
+func (c *CachedAuthorDashboardRepository) Get(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error) {
+	return c.cache.Get(ctx, uid, days)
+}
+
+func (c *CachedAuthorDashboardRepository) Set(ctx context.Context, dash domain.AuthorDashboard) error {
+	return c.cache.Set(ctx, dash)
+}
+
+func (c *CachedAuthorDashboardRepository) GetBase(ctx context.Context, uid int64) (domain.AuthorStatsBase, error) {
+	return c.cache.GetBase(ctx, uid)
+}
+
+func (c *CachedAuthorDashboardRepository) SetBase(ctx context.Context, base domain.AuthorStatsBase) error {
+	return c.cache.SetBase(ctx, base)
+}
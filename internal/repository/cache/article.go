@@ -7,6 +7,7 @@ import (
 	"github.com/go-redis/redis/v8"
 	"time"
 	"webook/internal/domain"
+	"webook/pkg/cachex"
 )
 
 type ArticleCache interface {
@@ -37,8 +38,12 @@ func (r *ArticleRedisCache) GetPub(ctx context.Context, artId int64) (domain.Art
 }
 
 func (r *ArticleRedisCache) SetPub(ctx context.Context, art domain.Article) error {
-	err := r.cmd.Set(ctx, r.pubKey(art.Id), art, time.Minute*10).Err()
-	return err
+	// domain.Article 没有实现 BinaryMarshaler，要自己序列化
+	val, err := json.Marshal(art)
+	if err != nil {
+		return err
+	}
+	return r.cmd.Set(ctx, r.pubKey(art.Id), val, cachex.Jitter(time.Minute*10)).Err()
 }
 
 func (r *ArticleRedisCache) Get(ctx context.Context, artId int64) (domain.Article, error) {
@@ -55,8 +60,11 @@ func (r *ArticleRedisCache) Get(ctx context.Context, artId int64) (domain.Articl
 }
 
 func (r *ArticleRedisCache) Set(ctx context.Context, art domain.Article) error {
-	err := r.cmd.Set(ctx, r.key(art.Id), art, time.Minute*10).Err()
-	return err
+	val, err := json.Marshal(art)
+	if err != nil {
+		return err
+	}
+	return r.cmd.Set(ctx, r.key(art.Id), val, cachex.Jitter(time.Minute*10)).Err()
 }
 
 func (r *ArticleRedisCache) DelFirstPage(ctx context.Context, uid int64) error {
@@ -88,7 +96,7 @@ func (r *ArticleRedisCache) SetFirstPage(ctx context.Context, uid int64, arts []
 	if err != nil {
 		return err
 	}
-	err = r.cmd.Set(ctx, key, val, time.Minute*10).Err()
+	err = r.cmd.Set(ctx, key, val, cachex.Jitter(time.Minute*10)).Err()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,87 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"time"
+	"webook/internal/domain"
+)
+
+type AuthorDashboardCache interface {
+	Get(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error)
+	Set(ctx context.Context, dash domain.AuthorDashboard) error
+	GetBase(ctx context.Context, uid int64) (domain.AuthorStatsBase, error)
+	SetBase(ctx context.Context, base domain.AuthorStatsBase) error
+}
+
+type RedisAuthorDashboardCache struct {
+	cmd redis.Cmdable
+	// 看板本身缓存的时间，过期之后只查最近几天的增量
+	expiration time.Duration
+	// 累计值隔一段时间从数据库全量算一次，纠正增量的误差
+	baseExpiration time.Duration
+}
+
+func NewRedisAuthorDashboardCache(cmd redis.Cmdable) AuthorDashboardCache {
+	return &RedisAuthorDashboardCache{
+		cmd:            cmd,
+		expiration:     time.Minute * 5,
+		baseExpiration: time.Hour * 24 * 7,
+	}
+}
+
+func (c *RedisAuthorDashboardCache) Get(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error) {
+	var dash domain.AuthorDashboard
+	err := c.get(ctx, c.key(uid, days), &dash)
+	return dash, err
+}
+
+func (c *RedisAuthorDashboardCache) Set(ctx context.Context, dash domain.AuthorDashboard) error {
+	return c.set(ctx, c.key(dash.Uid, dash.Days), dash, c.expiration)
+}
+
+func (c *RedisAuthorDashboardCache) GetBase(ctx context.Context, uid int64) (domain.AuthorStatsBase, error) {
+	var base domain.AuthorStatsBase
+	err := c.get(ctx, c.baseKey(uid), &base)
+	return base, err
+}
+
+// SetBase 只在第一次写的时候设置过期时间，后面增量更新不续期
+func (c *RedisAuthorDashboardCache) SetBase(ctx context.Context, base domain.AuthorStatsBase) error {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return err
+	}
+	key := c.baseKey(base.Uid)
+	ok, err := c.cmd.SetXX(ctx, key, data, redis.KeepTTL).Result()
+	if err != nil || ok {
+		return err
+	}
+	return c.cmd.Set(ctx, key, data, c.baseExpiration).Err()
+}
+
+func (c *RedisAuthorDashboardCache) get(ctx context.Context, key string, val any) error {
+	data, err := c.cmd.Get(ctx, key).Bytes()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, val)
+}
+
+func (c *RedisAuthorDashboardCache) set(ctx context.Context, key string, val any, expiration time.Duration) error {
+	data, err := json.Marshal(val)
+	if err != nil {
+		return err
+	}
+	return c.cmd.Set(ctx, key, data, expiration).Err()
+}
+
+func (c *RedisAuthorDashboardCache) key(uid int64, days int) string {
+	return fmt.Sprintf("author:dashboard:%d:%d", uid, days)
+}
+
+func (c *RedisAuthorDashboardCache) baseKey(uid int64) string {
+	return fmt.Sprintf("author:dashboard:base:%d", uid)
+}
@@ -0,0 +1,74 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisAuthorDashboardCache_SetBase(t *testing.T) {
+	base := domain.AuthorStatsBase{
+		Uid:   123,
+		Day:   "2024-01-02",
+		Total: domain.InteractiveStat{ReadCnt: 100, LikeCnt: 10},
+	}
+	data, err := json.Marshal(base)
+	assert.NoError(t, err)
+	key := "author:dashboard:base:123"
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantErr error
+	}{
+		{
+			name: "已经有了，保留过期时间",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewBoolCmd(context.Background())
+				res.SetVal(true)
+				cmd.EXPECT().SetXX(gomock.Any(), key, data, time.Duration(redis.KeepTTL)).Return(res)
+				return cmd
+			},
+		},
+		{
+			name: "第一次写，设置过期时间",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewBoolCmd(context.Background())
+				res.SetVal(false)
+				cmd.EXPECT().SetXX(gomock.Any(), key, data, time.Duration(redis.KeepTTL)).Return(res)
+				setRes := redis.NewStatusCmd(context.Background())
+				setRes.SetVal("OK")
+				cmd.EXPECT().Set(gomock.Any(), key, data, time.Hour*24*7).Return(setRes)
+				return cmd
+			},
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewBoolCmd(context.Background())
+				res.SetErr(errors.New("redis error"))
+				cmd.EXPECT().SetXX(gomock.Any(), key, data, time.Duration(redis.KeepTTL)).Return(res)
+				return cmd
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRedisAuthorDashboardCache(tc.mock(ctrl))
+			err := c.SetBase(context.Background(), base)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+)
+
+var (
+	//go:embed lua/feed_push.lua
+	luaFeedPush string
+	//go:embed lua/feed_touch.lua
+	luaFeedTouch string
+)
+
+const feedBigAuthorsKey = "feed:big_authors"
+
+type FeedCache interface {
+	// AddOutbox 作者的发件箱，拉模式和重建收件箱的时候用
+	AddOutbox(ctx context.Context, author int64, item domain.FeedItem) error
+	RemoveOutbox(ctx context.Context, author int64, artId int64) error
+	// Outboxes 这些作者发件箱里面 cursor 之前的，每个作者最多 limit 篇，没有排序
+	Outboxes(ctx context.Context, authors []int64, cursor int64, limit int) ([]domain.FeedItem, error)
+	// Push 推到活跃用户的收件箱，返回实际推了多少个
+	Push(ctx context.Context, uids []int64, item domain.FeedItem) (int, error)
+	// Remove 从这些用户的收件箱里面删掉这些文章
+	Remove(ctx context.Context, uids []int64, artIds []int64) error
+	// Inbox cursor 之前的 limit 篇，按发表时间倒序，cursor 为 0 的时候从最新的开始
+	Inbox(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FeedItem, error)
+	// Touch 续期，返回 false 表示用户不活跃，收件箱需要重建
+	Touch(ctx context.Context, uid int64) (bool, error)
+	// Rebuild 用拉回来的文章覆盖收件箱，并把用户标记成活跃
+	Rebuild(ctx context.Context, uid int64, items []domain.FeedItem) error
+	// AddBigAuthor 粉丝太多的作者不推，读的时候拉
+	AddBigAuthor(ctx context.Context, author int64) error
+	BigAuthors(ctx context.Context) ([]int64, error)
+}
+
+// RedisFeedCache 收件箱和发件箱都是 zset，member 是文章 id，score 是发表时间
+// 收件箱有一个单独的活跃标记，ActiveTTL 内没有看过关注流的用户不再往收件箱推
+type RedisFeedCache struct {
+	cmd redis.Cmdable
+
+	InboxSize  int
+	OutboxSize int
+	ActiveTTL  time.Duration
+}
+
+func NewRedisFeedCache(cmd redis.Cmdable) *RedisFeedCache {
+	return &RedisFeedCache{
+		cmd:        cmd,
+		InboxSize:  500,
+		OutboxSize: 100,
+		ActiveTTL:  7 * 24 * time.Hour,
+	}
+}
+
+func (r *RedisFeedCache) AddOutbox(ctx context.Context, author int64, item domain.FeedItem) error {
+	key := r.outboxKey(author)
+	_, err := r.cmd.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		// 重新发表的文章保留第一次发表的时间
+		pipe.ZAddNX(ctx, key, &redis.Z{Score: float64(item.Ctime), Member: item.ArtId})
+		pipe.ZRemRangeByRank(ctx, key, 0, int64(-r.OutboxSize-1))
+		return nil
+	})
+	return err
+}
+
+func (r *RedisFeedCache) RemoveOutbox(ctx context.Context, author int64, artId int64) error {
+	return r.cmd.ZRem(ctx, r.outboxKey(author), artId).Err()
+}
+
+func (r *RedisFeedCache) Outboxes(ctx context.Context, authors []int64, cursor int64, limit int) ([]domain.FeedItem, error) {
+	if len(authors) == 0 {
+		return nil, nil
+	}
+	cmds := make([]*redis.ZSliceCmd, 0, len(authors))
+	_, err := r.cmd.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, author := range authors {
+			cmds = append(cmds, pipe.ZRevRangeByScoreWithScores(ctx, r.outboxKey(author), r.rangeBy(cursor, limit)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var res []domain.FeedItem
+	for _, cmd := range cmds {
+		items, err := r.toItems(cmd.Val())
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, items...)
+	}
+	return res, nil
+}
+
+func (r *RedisFeedCache) Push(ctx context.Context, uids []int64, item domain.FeedItem) (int, error) {
+	if len(uids) == 0 {
+		return 0, nil
+	}
+	keys := make([]string, 0, len(uids)*2)
+	for _, uid := range uids {
+		keys = append(keys, r.inboxKey(uid), r.activeKey(uid))
+	}
+	return r.cmd.Eval(ctx, luaFeedPush, keys, r.InboxSize, item.ArtId, item.Ctime).Int()
+}
+
+func (r *RedisFeedCache) Remove(ctx context.Context, uids []int64, artIds []int64) error {
+	if len(uids) == 0 || len(artIds) == 0 {
+		return nil
+	}
+	members := make([]any, 0, len(artIds))
+	for _, artId := range artIds {
+		members = append(members, artId)
+	}
+	_, err := r.cmd.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, uid := range uids {
+			pipe.ZRem(ctx, r.inboxKey(uid), members...)
+		}
+		return nil
+	})
+	return err
+}
+
+func (r *RedisFeedCache) Inbox(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FeedItem, error) {
+	zs, err := r.cmd.ZRevRangeByScoreWithScores(ctx, r.inboxKey(uid), r.rangeBy(cursor, limit)).Result()
+	if err != nil {
+		return nil, err
+	}
+	return r.toItems(zs)
+}
+
+func (r *RedisFeedCache) Touch(ctx context.Context, uid int64) (bool, error) {
+	res, err := r.cmd.Eval(ctx, luaFeedTouch, []string{r.inboxKey(uid), r.activeKey(uid)},
+		r.ActiveTTL.Milliseconds()).Int()
+	return res == 1, err
+}
+
+func (r *RedisFeedCache) Rebuild(ctx context.Context, uid int64, items []domain.FeedItem) error {
+	key := r.inboxKey(uid)
+	_, err := r.cmd.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, key)
+		if len(items) > 0 {
+			zs := make([]*redis.Z, 0, len(items))
+			for _, item := range items {
+				zs = append(zs, &redis.Z{Score: float64(item.Ctime), Member: item.ArtId})
+			}
+			pipe.ZAdd(ctx, key, zs...)
+			pipe.ZRemRangeByRank(ctx, key, 0, int64(-r.InboxSize-1))
+			pipe.PExpire(ctx, key, r.ActiveTTL)
+		}
+		pipe.Set(ctx, r.activeKey(uid), "", r.ActiveTTL)
+		return nil
+	})
+	return err
+}
+
+func (r *RedisFeedCache) AddBigAuthor(ctx context.Context, author int64) error {
+	return r.cmd.SAdd(ctx, feedBigAuthorsKey, author).Err()
+}
+
+func (r *RedisFeedCache) BigAuthors(ctx context.Context) ([]int64, error) {
+	res, err := r.cmd.SMembers(ctx, feedBigAuthorsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	authors := make([]int64, 0, len(res))
+	for _, s := range res {
+		author, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		authors = append(authors, author)
+	}
+	return authors, nil
+}
+
+// rangeBy 分数比 cursor 小的，同一毫秒发表的文章可能会被跳过，可以接受
+func (r *RedisFeedCache) rangeBy(cursor int64, limit int) *redis.ZRangeBy {
+	max := "+inf"
+	if cursor > 0 {
+		max = "(" + strconv.FormatInt(cursor, 10)
+	}
+	return &redis.ZRangeBy{Min: "-inf", Max: max, Count: int64(limit)}
+}
+
+func (r *RedisFeedCache) toItems(zs []redis.Z) ([]domain.FeedItem, error) {
+	items := make([]domain.FeedItem, 0, len(zs))
+	for _, z := range zs {
+		artId, err := strconv.ParseInt(z.Member.(string), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, domain.FeedItem{ArtId: artId, Ctime: int64(z.Score)})
+	}
+	return items, nil
+}
+
+func (r *RedisFeedCache) inboxKey(uid int64) string {
+	return fmt.Sprintf("feed:inbox:%d", uid)
+}
+
+func (r *RedisFeedCache) outboxKey(author int64) string {
+	return fmt.Sprintf("feed:outbox:%d", author)
+}
+
+func (r *RedisFeedCache) activeKey(uid int64) string {
+	return fmt.Sprintf("feed:active:%d", uid)
+}
@@ -0,0 +1,56 @@
+package cache
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisFeedCache_Push(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	cmd.EXPECT().Eval(gomock.Any(), luaFeedPush,
+		[]string{"feed:inbox:1", "feed:active:1", "feed:inbox:2", "feed:active:2"},
+		500, int64(10), int64(1700000000000)).
+		Return(redis.NewCmdResult(int64(1), nil))
+	cnt, err := NewRedisFeedCache(cmd).Push(context.Background(), []int64{1, 2},
+		domain.FeedItem{ArtId: 10, Ctime: 1700000000000})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cnt)
+}
+
+func TestRedisFeedCache_Inbox(t *testing.T) {
+	testCases := []struct {
+		name      string
+		cursor    int64
+		wantRange *redis.ZRangeBy
+	}{
+		{
+			name:      "第一页",
+			wantRange: &redis.ZRangeBy{Min: "-inf", Max: "+inf", Count: 2},
+		},
+		{
+			name:      "从游标之后开始",
+			cursor:    300,
+			wantRange: &redis.ZRangeBy{Min: "-inf", Max: "(300", Count: 2},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := redismocks.NewMockCmdable(ctrl)
+			res := redis.NewZSliceCmd(context.Background())
+			res.SetVal([]redis.Z{{Score: 200, Member: "3"}, {Score: 100, Member: "1"}})
+			cmd.EXPECT().ZRevRangeByScoreWithScores(gomock.Any(), "feed:inbox:7", tc.wantRange).Return(res)
+			items, err := NewRedisFeedCache(cmd).Inbox(context.Background(), 7, tc.cursor, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, []domain.FeedItem{{ArtId: 3, Ctime: 200}, {ArtId: 1, Ctime: 100}}, items)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+	"webook/pkg/cachex"
+)
+
+const (
+	fieldFollowers = "followers"
+	fieldFollowees = "followees"
+)
+
+type FollowCache interface {
+	GetStatistic(ctx context.Context, uid int64) (domain.FollowStatistic, error)
+	SetStatistic(ctx context.Context, s domain.FollowStatistic) error
+	// IncrStatisticIfPresent follower 的关注数和 followee 的粉丝数加 delta，没有缓存的不管
+	IncrStatisticIfPresent(ctx context.Context, follower int64, followee int64, delta int64) error
+}
+
+type RedisFollowCache struct {
+	cmd        redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisFollowCache(cmd redis.Cmdable) FollowCache {
+	return &RedisFollowCache{
+		cmd:        cmd,
+		expiration: 15 * time.Minute,
+	}
+}
+
+func (r *RedisFollowCache) GetStatistic(ctx context.Context, uid int64) (domain.FollowStatistic, error) {
+	res, err := r.cmd.HGetAll(ctx, r.key(uid)).Result()
+	if err != nil {
+		return domain.FollowStatistic{}, err
+	}
+	if len(res) == 0 {
+		return domain.FollowStatistic{}, ErrKeyNotExist
+	}
+	s := domain.FollowStatistic{Uid: uid}
+	s.Followers, _ = strconv.ParseInt(res[fieldFollowers], 10, 64)
+	s.Followees, _ = strconv.ParseInt(res[fieldFollowees], 10, 64)
+	return s, nil
+}
+
+func (r *RedisFollowCache) SetStatistic(ctx context.Context, s domain.FollowStatistic) error {
+	key := r.key(s.Uid)
+	err := r.cmd.HMSet(ctx, key, fieldFollowers, s.Followers, fieldFollowees, s.Followees).Err()
+	if err != nil {
+		return err
+	}
+	return r.cmd.Expire(ctx, key, cachex.Jitter(r.expiration)).Err()
+}
+
+func (r *RedisFollowCache) IncrStatisticIfPresent(ctx context.Context, follower int64, followee int64, delta int64) error {
+	err := r.cmd.Eval(ctx, luaIncrCnt, []string{r.key(follower)}, fieldFollowees, delta).Err()
+	if err != nil {
+		return err
+	}
+	return r.cmd.Eval(ctx, luaIncrCnt, []string{r.key(followee)}, fieldFollowers, delta).Err()
+}
+
+func (r *RedisFollowCache) key(uid int64) string {
+	return fmt.Sprintf("follow:statistic:%d", uid)
+}
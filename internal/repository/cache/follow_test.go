@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisFollowCache_GetStatistic(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantRes domain.FollowStatistic
+		wantErr error
+	}{
+		{
+			name: "命中",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewStringStringMapCmd(context.Background())
+				res.SetVal(map[string]string{"followers": "10", "followees": "3"})
+				cmd.EXPECT().HGetAll(gomock.Any(), "follow:statistic:1").Return(res)
+				return cmd
+			},
+			wantRes: domain.FollowStatistic{Uid: 1, Followers: 10, Followees: 3},
+		},
+		{
+			name: "没有缓存",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				res := redis.NewStringStringMapCmd(context.Background())
+				res.SetVal(map[string]string{})
+				cmd.EXPECT().HGetAll(gomock.Any(), "follow:statistic:1").Return(res)
+				return cmd
+			},
+			wantErr: ErrKeyNotExist,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRedisFollowCache(tc.mock(ctrl))
+			res, err := c.GetStatistic(context.Background(), 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestRedisFollowCache_IncrStatisticIfPresent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, []string{"follow:statistic:1"}, "followees", int64(1)).
+		Return(redis.NewCmdResult(int64(1), nil))
+	cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, []string{"follow:statistic:2"}, "followers", int64(1)).
+		Return(redis.NewCmdResult(int64(0), nil))
+	err := NewRedisFollowCache(cmd).IncrStatisticIfPresent(context.Background(), 1, 2, 1)
+	assert.NoError(t, err)
+}
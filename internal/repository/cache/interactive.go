@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"github.com/go-redis/redis/v8"
 	"strconv"
+	"strings"
 	"time"
 	"webook/internal/domain"
+	"webook/pkg/cachex"
 )
 
 var (
@@ -18,14 +20,31 @@ var (
 const filedReadCnt = "read_cnt"
 const filedLikeCnt = "like_cnt"
 const filedCollectCnt = "collect_cnt"
+const filedCommentCnt = "comment_cnt"
+const filedShareCnt = "share_cnt"
+const filedReaderCnt = "reader_cnt"
+
+// 表情计数放在同一个 hash 里面，field 是 reaction:<表情>
+// 默认表情就是点赞，直接用 like_cnt，不单独存
+const filedReactionPrefix = "reaction:"
 
 type InteractiveCache interface {
 	IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error
-	IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
-	DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	// BatchIncrReadCntIfPresent 用一个 pipeline 批量加阅读数，cnts 是每个资源的增量
+	BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error
+	// IncrReactionCntIfPresent 表情计数加 delta，取消传负数
+	IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error
 	IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64) error
+	DecrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error
+	IncrShareCntIfPresent(ctx context.Context, biz string, bizId int64) error
 	GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	SetInteractive(ctx context.Context, res domain.Interactive) error
+	// GetInteractives 用一个 pipeline 批量查询，只返回命中的
+	GetInteractives(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error)
+	// SetInteractives 用一个 pipeline 批量回写
+	SetInteractives(ctx context.Context, intrs []domain.Interactive) error
 }
 
 type InteractiveRedisCache struct {
@@ -34,17 +53,13 @@ type InteractiveRedisCache struct {
 
 func (i *InteractiveRedisCache) SetInteractive(ctx context.Context, res domain.Interactive) error {
 	key := i.key(res.Biz, res.BizId)
-	err := i.cmd.HMSet(ctx, key, map[string]interface{}{
-		filedReadCnt:    res.ReadCnt,
-		filedLikeCnt:    res.LikeCnt,
-		filedCollectCnt: res.CollectCnt,
-	}).Err()
+	err := i.cmd.HMSet(ctx, key, i.toValues(res)).Err()
 	if err != nil {
 		return err
 	}
 
 	// 设置过期时间
-	return i.cmd.Expire(ctx, key, 15*time.Minute).Err()
+	return i.cmd.Expire(ctx, key, cachex.Jitter(15*time.Minute)).Err()
 }
 
 func (i *InteractiveRedisCache) GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
@@ -56,16 +71,104 @@ func (i *InteractiveRedisCache) GetInteractive(ctx context.Context, biz string,
 	if len(res) == 0 {
 		return domain.Interactive{}, ErrKeyNotExist
 	}
+	return i.toDomain(biz, bizId, res), nil
+}
+
+func (i *InteractiveRedisCache) GetInteractives(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	pipe := i.cmd.Pipeline()
+	cmds := make([]*redis.StringStringMapCmd, 0, len(bizIds))
+	for _, bizId := range bizIds {
+		cmds = append(cmds, pipe.HGetAll(ctx, i.key(biz, bizId)))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for idx, cmd := range cmds {
+		vals := cmd.Val()
+		if len(vals) == 0 {
+			continue
+		}
+		res[bizIds[idx]] = i.toDomain(biz, bizIds[idx], vals)
+	}
+	return res, nil
+}
+
+func (i *InteractiveRedisCache) SetInteractives(ctx context.Context, intrs []domain.Interactive) error {
+	if len(intrs) == 0 {
+		return nil
+	}
+	pipe := i.cmd.Pipeline()
+	for _, intr := range intrs {
+		key := i.key(intr.Biz, intr.BizId)
+		pipe.HMSet(ctx, key, i.toValues(intr))
+		pipe.Expire(ctx, key, cachex.Jitter(15*time.Minute))
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (i *InteractiveRedisCache) toValues(intr domain.Interactive) map[string]interface{} {
+	res := map[string]interface{}{
+		filedReadCnt:    intr.ReadCnt,
+		filedLikeCnt:    intr.LikeCnt,
+		filedCollectCnt: intr.CollectCnt,
+		filedCommentCnt: intr.CommentCnt,
+		filedShareCnt:   intr.ShareCnt,
+		filedReaderCnt:  intr.ReaderCnt,
+	}
+	for reaction, cnt := range intr.ReactionCnts {
+		if reaction == domain.ReactionLike {
+			continue
+		}
+		res[i.reactionField(reaction)] = cnt
+	}
+	return res
+}
+
+func (i *InteractiveRedisCache) toDomain(biz string, bizId int64, res map[string]string) domain.Interactive {
 	readCnt, _ := strconv.ParseInt(res[filedReadCnt], 10, 64)
 	likeCnt, _ := strconv.ParseInt(res[filedLikeCnt], 10, 64)
 	collectCnt, _ := strconv.ParseInt(res[filedCollectCnt], 10, 64)
+	commentCnt, _ := strconv.ParseInt(res[filedCommentCnt], 10, 64)
+	shareCnt, _ := strconv.ParseInt(res[filedShareCnt], 10, 64)
+	readerCnt, _ := strconv.ParseInt(res[filedReaderCnt], 10, 64)
+	var reactionCnts map[string]int64
+	for field, val := range res {
+		reaction, ok := strings.CutPrefix(field, filedReactionPrefix)
+		if !ok {
+			continue
+		}
+		cnt, _ := strconv.ParseInt(val, 10, 64)
+		if cnt <= 0 {
+			continue
+		}
+		if reactionCnts == nil {
+			reactionCnts = make(map[string]int64)
+		}
+		reactionCnts[reaction] = cnt
+	}
+	if likeCnt > 0 {
+		if reactionCnts == nil {
+			reactionCnts = make(map[string]int64)
+		}
+		reactionCnts[domain.ReactionLike] = likeCnt
+	}
 	return domain.Interactive{
-		Biz:        biz,
-		BizId:      bizId,
-		ReadCnt:    readCnt,
-		LikeCnt:    likeCnt,
-		CollectCnt: collectCnt,
-	}, nil
+		Biz:          biz,
+		BizId:        bizId,
+		ReadCnt:      readCnt,
+		LikeCnt:      likeCnt,
+		CollectCnt:   collectCnt,
+		CommentCnt:   commentCnt,
+		ShareCnt:     shareCnt,
+		ReaderCnt:    readerCnt,
+		ReactionCnts: reactionCnts,
+	}
 }
 
 func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
@@ -73,14 +176,37 @@ func (i *InteractiveRedisCache) IncrCollectCntIfPresent(ctx context.Context, biz
 	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedCollectCnt, 1).Err()
 }
 
-func (i *InteractiveRedisCache) IncrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+func (i *InteractiveRedisCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := i.key(biz, bizId)
+	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedCollectCnt, -1).Err()
+}
+
+func (i *InteractiveRedisCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	key := i.key(biz, bizId)
+	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedCommentCnt, 1).Err()
+}
+
+// DecrCommentCntIfPresent 删除根评论会把回复一起删掉，所以需要传入删除的条数
+func (i *InteractiveRedisCache) DecrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	key := i.key(biz, bizId)
+	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedCommentCnt, -cnt).Err()
+}
+
+func (i *InteractiveRedisCache) IncrShareCntIfPresent(ctx context.Context, biz string, bizId int64) error {
 	key := i.key(biz, bizId)
-	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedLikeCnt, 1).Err()
+	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedShareCnt, 1).Err()
 }
 
-func (i *InteractiveRedisCache) DecrLikeCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+func (i *InteractiveRedisCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error {
 	key := i.key(biz, bizId)
-	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedLikeCnt, -1).Err()
+	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, i.reactionField(reaction), delta).Err()
+}
+
+func (i *InteractiveRedisCache) reactionField(reaction string) string {
+	if reaction == domain.ReactionLike {
+		return filedLikeCnt
+	}
+	return filedReactionPrefix + reaction
 }
 
 func (i *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error {
@@ -89,6 +215,18 @@ func (i *InteractiveRedisCache) IncrReadCntIfPresent(ctx context.Context, biz st
 	return i.cmd.Eval(ctx, luaIncrCnt, []string{key}, filedReadCnt, 1).Err()
 }
 
+func (i *InteractiveRedisCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds []int64, cnts []int64) error {
+	if len(bizs) == 0 {
+		return nil
+	}
+	pipe := i.cmd.Pipeline()
+	for idx := range bizs {
+		pipe.Eval(ctx, luaIncrCnt, []string{i.key(bizs[idx], bizIds[idx])}, filedReadCnt, cnts[idx])
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 func (i *InteractiveRedisCache) key(biz string, bizId int64) string {
 	return fmt.Sprintf("interactive:article:%s:%d", biz, bizId)
 }
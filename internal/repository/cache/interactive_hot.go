@@ -0,0 +1,255 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/prometheus/client_golang/prometheus"
+	"sync"
+	"time"
+	"webook/internal/domain"
+)
+
+// 计数变化的时候在这个 channel 上广播 biz:bizId，所有实例删掉自己的本地缓存
+const interactiveInvalidateChannel = "interactive:invalidate"
+
+// HotInteractiveCache 在 redis 前面给热点资源的计数加一层本地缓存。
+// 一个 Window 内 GetInteractive 超过 Threshold 次的资源算热点，热点在本地缓存 TTL 这么久。
+// 点赞、收藏、评论、分享变化的时候通过 pub/sub 通知所有实例删除本地缓存；
+// 阅读数变得太频繁，不通知，容忍 TTL 内的延迟
+type HotInteractiveCache struct {
+	// 没有覆盖的方法直接走 redis
+	InteractiveCache
+	cmd redis.Cmdable
+
+	// Threshold 和 Window 决定什么算热点，MaxTracked 是一个窗口内最多统计多少个 key
+	Threshold  int64
+	Window     time.Duration
+	MaxTracked int
+	ttl        time.Duration
+
+	mu          sync.Mutex
+	local       *localLRU
+	counts      map[string]int64
+	windowStart time.Time
+	now         func() time.Time
+
+	requests      *prometheus.CounterVec
+	hotKeys       prometheus.Gauge
+	promotions    prometheus.Counter
+	invalidations prometheus.Counter
+}
+
+// NewHotInteractiveCache capacity 是本地最多缓存多少个热点
+func NewHotInteractiveCache(redisCache InteractiveCache, cmd redis.Cmdable,
+	capacity int, ttl time.Duration) *HotInteractiveCache {
+	c := newHotInteractiveCache(redisCache, cmd, capacity, ttl)
+	prometheus.MustRegister(c.requests, c.hotKeys, c.promotions, c.invalidations)
+	return c
+}
+
+func newHotInteractiveCache(redisCache InteractiveCache, cmd redis.Cmdable,
+	capacity int, ttl time.Duration) *HotInteractiveCache {
+	return &HotInteractiveCache{
+		InteractiveCache: redisCache,
+		cmd:              cmd,
+		Threshold:        50,
+		Window:           time.Second,
+		MaxTracked:       100000,
+		ttl:              ttl,
+		local:            newLocalLRU(capacity),
+		counts:           make(map[string]int64),
+		now:              time.Now,
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "local_cache_requests_total",
+			Help:      "本地热点缓存的请求数，result 是 hit 或者 miss",
+		}, []string{"result"}),
+		hotKeys: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "local_cache_hot_keys",
+			Help:      "本地缓存着的热点个数",
+		}),
+		promotions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "local_cache_promotions_total",
+			Help:      "被识别成热点放进本地缓存的次数",
+		}),
+		invalidations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "webook",
+			Subsystem: "interactive",
+			Name:      "local_cache_invalidations_total",
+			Help:      "收到的失效通知个数",
+		}),
+	}
+}
+
+func (c *HotInteractiveCache) GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	key := bizMember(biz, bizId)
+	c.mu.Lock()
+	now := c.now()
+	intr, ok := c.local.get(key, now)
+	hot := !ok && c.touch(key, now)
+	c.hotKeys.Set(float64(c.local.len()))
+	c.mu.Unlock()
+	if ok {
+		c.requests.WithLabelValues("hit").Inc()
+		return intr, nil
+	}
+	c.requests.WithLabelValues("miss").Inc()
+
+	intr, err := c.InteractiveCache.GetInteractive(ctx, biz, bizId)
+	if err != nil || !hot {
+		return intr, err
+	}
+	c.mu.Lock()
+	c.local.set(key, intr, c.now().Add(c.ttl))
+	c.hotKeys.Set(float64(c.local.len()))
+	c.mu.Unlock()
+	c.promotions.Inc()
+	return intr, nil
+}
+
+// touch 记一次访问，返回 key 是不是热点。按照固定窗口计数，窗口过了就清零
+func (c *HotInteractiveCache) touch(key string, now time.Time) bool {
+	if now.Sub(c.windowStart) >= c.Window {
+		c.counts = make(map[string]int64, len(c.counts))
+		c.windowStart = now
+	}
+	cnt, ok := c.counts[key]
+	if !ok && len(c.counts) >= c.MaxTracked {
+		return false
+	}
+	cnt++
+	c.counts[key] = cnt
+	return cnt >= c.Threshold
+}
+
+// Subscribe 接收别的实例的失效通知，阻塞直到 ctx 结束
+func (c *HotInteractiveCache) Subscribe(ctx context.Context, client redis.UniversalClient) error {
+	ps := client.Subscribe(ctx, interactiveInvalidateChannel)
+	defer ps.Close()
+	ch := ps.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+			c.invalidate(msg.Payload)
+		}
+	}
+}
+
+func (c *HotInteractiveCache) invalidate(key string) {
+	c.mu.Lock()
+	c.local.del(key)
+	c.hotKeys.Set(float64(c.local.len()))
+	c.mu.Unlock()
+	c.invalidations.Inc()
+}
+
+// changed 计数变了，先删自己的再通知别的实例
+func (c *HotInteractiveCache) changed(ctx context.Context, biz string, bizId int64, err error) error {
+	if err != nil {
+		return err
+	}
+	key := bizMember(biz, bizId)
+	c.mu.Lock()
+	c.local.del(key)
+	c.mu.Unlock()
+	return c.cmd.Publish(ctx, interactiveInvalidateChannel, key).Err()
+}
+
+func (c *HotInteractiveCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error {
+	return c.changed(ctx, biz, bizId, c.InteractiveCache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, delta))
+}
+
+func (c *HotInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return c.changed(ctx, biz, bizId, c.InteractiveCache.IncrCollectCntIfPresent(ctx, biz, bizId))
+}
+
+func (c *HotInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return c.changed(ctx, biz, bizId, c.InteractiveCache.DecrCollectCntIfPresent(ctx, biz, bizId))
+}
+
+func (c *HotInteractiveCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return c.changed(ctx, biz, bizId, c.InteractiveCache.IncrCommentCntIfPresent(ctx, biz, bizId))
+}
+
+func (c *HotInteractiveCache) DecrCommentCntIfPresent(ctx context.Context, biz string, bizId int64, cnt int64) error {
+	return c.changed(ctx, biz, bizId, c.InteractiveCache.DecrCommentCntIfPresent(ctx, biz, bizId, cnt))
+}
+
+func (c *HotInteractiveCache) IncrShareCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	return c.changed(ctx, biz, bizId, c.InteractiveCache.IncrShareCntIfPresent(ctx, biz, bizId))
+}
+
+// localLRU 不是并发安全的，由 HotInteractiveCache 加锁
+type localLRU struct {
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type localEntry struct {
+	key      string
+	val      domain.Interactive
+	expireAt time.Time
+}
+
+func newLocalLRU(capacity int) *localLRU {
+	return &localLRU{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element, capacity),
+	}
+}
+
+func (l *localLRU) get(key string, now time.Time) (domain.Interactive, bool) {
+	elem, ok := l.items[key]
+	if !ok {
+		return domain.Interactive{}, false
+	}
+	entry := elem.Value.(*localEntry)
+	if !now.Before(entry.expireAt) {
+		l.remove(elem)
+		return domain.Interactive{}, false
+	}
+	l.ll.MoveToFront(elem)
+	return entry.val, true
+}
+
+func (l *localLRU) set(key string, val domain.Interactive, expireAt time.Time) {
+	if elem, ok := l.items[key]; ok {
+		entry := elem.Value.(*localEntry)
+		entry.val = val
+		entry.expireAt = expireAt
+		l.ll.MoveToFront(elem)
+		return
+	}
+	l.items[key] = l.ll.PushFront(&localEntry{key: key, val: val, expireAt: expireAt})
+	for l.ll.Len() > l.capacity {
+		l.remove(l.ll.Back())
+	}
+}
+
+func (l *localLRU) del(key string) {
+	if elem, ok := l.items[key]; ok {
+		l.remove(elem)
+	}
+}
+
+func (l *localLRU) remove(elem *list.Element) {
+	l.ll.Remove(elem)
+	delete(l.items, elem.Value.(*localEntry).key)
+}
+
+func (l *localLRU) len() int {
+	return l.ll.Len()
+}
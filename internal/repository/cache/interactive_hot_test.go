@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestHotInteractiveCache_GetInteractive(t *testing.T) {
+	key := "interactive:article:article:1"
+	hgetAll := func(cmd *redismocks.MockCmdable, times int) {
+		res := redis.NewStringStringMapCmd(context.Background())
+		res.SetVal(map[string]string{filedReadCnt: "10", filedLikeCnt: "2"})
+		cmd.EXPECT().HGetAll(gomock.Any(), key).Return(res).Times(times)
+	}
+	want := domain.Interactive{Biz: "article", BizId: 1, ReadCnt: 10, LikeCnt: 2,
+		ReactionCnts: map[string]int64{domain.ReactionLike: 2}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) *redismocks.MockCmdable
+		// 在第几次调用之前做什么，比如让时间流逝、修改点赞数
+		before map[int]func(c *HotInteractiveCache, clock *time.Time)
+		calls  int
+	}{
+		{
+			name: "没有达到阈值，每次都查 redis",
+			mock: func(ctrl *gomock.Controller) *redismocks.MockCmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				hgetAll(cmd, 2)
+				return cmd
+			},
+			calls: 2,
+		},
+		{
+			name: "变成热点之后走本地缓存",
+			mock: func(ctrl *gomock.Controller) *redismocks.MockCmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				hgetAll(cmd, 3)
+				return cmd
+			},
+			calls: 6,
+		},
+		{
+			name: "窗口过了重新计数",
+			mock: func(ctrl *gomock.Controller) *redismocks.MockCmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				hgetAll(cmd, 4)
+				return cmd
+			},
+			before: map[int]func(c *HotInteractiveCache, clock *time.Time){
+				2: func(c *HotInteractiveCache, clock *time.Time) {
+					*clock = clock.Add(time.Second)
+				},
+			},
+			calls: 4,
+		},
+		{
+			name: "本地缓存过期",
+			mock: func(ctrl *gomock.Controller) *redismocks.MockCmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				hgetAll(cmd, 4)
+				return cmd
+			},
+			before: map[int]func(c *HotInteractiveCache, clock *time.Time){
+				// 第 3 次放进本地缓存，过期之后还在同一个窗口里面，马上又是热点
+				4: func(c *HotInteractiveCache, clock *time.Time) {
+					*clock = clock.Add(time.Millisecond * 600)
+				},
+			},
+			calls: 6,
+		},
+		{
+			name: "点赞之后删掉本地缓存并通知别的实例",
+			mock: func(ctrl *gomock.Controller) *redismocks.MockCmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				hgetAll(cmd, 4)
+				cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, []string{key}, filedLikeCnt, int64(1)).
+					Return(redis.NewCmdResult(int64(1), nil))
+				cmd.EXPECT().Publish(gomock.Any(), interactiveInvalidateChannel, "article:1").
+					Return(redis.NewIntResult(1, nil))
+				return cmd
+			},
+			before: map[int]func(c *HotInteractiveCache, clock *time.Time){
+				4: func(c *HotInteractiveCache, clock *time.Time) {
+					err := c.IncrReactionCntIfPresent(context.Background(), "article", 1, domain.ReactionLike, 1)
+					require.NoError(t, err)
+				},
+			},
+			calls: 5,
+		},
+		{
+			name: "收到别的实例的失效通知",
+			mock: func(ctrl *gomock.Controller) *redismocks.MockCmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				hgetAll(cmd, 4)
+				return cmd
+			},
+			before: map[int]func(c *HotInteractiveCache, clock *time.Time){
+				4: func(c *HotInteractiveCache, clock *time.Time) {
+					c.invalidate("article:1")
+				},
+			},
+			calls: 5,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			cmd := tc.mock(ctrl)
+			clock := time.UnixMilli(1000)
+			c := newHotInteractiveCache(NewInteractiveCache(cmd), cmd, 10, 500*time.Millisecond)
+			c.Threshold = 3
+			c.Window = time.Second
+			c.now = func() time.Time {
+				return clock
+			}
+			for i := 1; i <= tc.calls; i++ {
+				if fn, ok := tc.before[i]; ok {
+					fn(c, &clock)
+				}
+				intr, err := c.GetInteractive(context.Background(), "article", 1)
+				require.NoError(t, err)
+				assert.Equal(t, want, intr)
+			}
+		})
+	}
+}
+
+func TestLocalLRU(t *testing.T) {
+	now := time.UnixMilli(1000)
+	l := newLocalLRU(2)
+	l.set("a", domain.Interactive{BizId: 1}, now.Add(time.Second))
+	l.set("b", domain.Interactive{BizId: 2}, now.Add(time.Second))
+	// a 最近用过，放 c 的时候淘汰 b
+	_, ok := l.get("a", now)
+	assert.True(t, ok)
+	l.set("c", domain.Interactive{BizId: 3}, now.Add(time.Second))
+	_, ok = l.get("b", now)
+	assert.False(t, ok)
+	assert.Equal(t, 2, l.len())
+
+	_, ok = l.get("c", now.Add(time.Second))
+	assert.False(t, ok)
+	assert.Equal(t, 1, l.len())
+}
@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestInteractiveRedisCache_DecrCommentCntIfPresent(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		biz     string
+		bizId   int64
+		cnt     int64
+		wantErr error
+	}{
+		{
+			name: "删除根评论连同回复",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(1), nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt,
+					[]string{"interactive:article:article:1"},
+					filedCommentCnt, int64(-3)).Return(mockRes)
+				return cmd
+			},
+			biz:   "article",
+			bizId: 1,
+			cnt:   3,
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(0), errors.New("redis error"))
+				cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, gomock.Any(), gomock.Any()).Return(mockRes)
+				return cmd
+			},
+			biz:     "article",
+			bizId:   1,
+			cnt:     1,
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewInteractiveCache(tc.mock(ctrl))
+			err := c.DecrCommentCntIfPresent(context.Background(), tc.biz, tc.bizId, tc.cnt)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestInteractiveRedisCache_IncrReactionCntIfPresent(t *testing.T) {
+	key := []string{"interactive:article:article:1"}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) redis.Cmdable
+		reaction string
+		delta    int64
+		wantErr  error
+	}{
+		{
+			name: "默认表情就是点赞数",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(1), nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, key, filedLikeCnt, int64(1)).Return(mockRes)
+				return cmd
+			},
+			reaction: domain.ReactionLike,
+			delta:    1,
+		},
+		{
+			name: "取消别的表情",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(1), nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaIncrCnt, key, "reaction:funny", int64(-1)).Return(mockRes)
+				return cmd
+			},
+			reaction: "funny",
+			delta:    -1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewInteractiveCache(tc.mock(ctrl))
+			err := c.IncrReactionCntIfPresent(context.Background(), "article", 1, tc.reaction, tc.delta)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
+
+func TestInteractiveRedisCache_GetInteractive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	res := redis.NewStringStringMapCmd(context.Background())
+	res.SetVal(map[string]string{
+		filedReadCnt:     "10",
+		filedLikeCnt:     "2",
+		"reaction:funny": "3",
+		// 取消到 0 的不返回
+		"reaction:insightful": "0",
+	})
+	cmd.EXPECT().HGetAll(gomock.Any(), "interactive:article:article:1").Return(res)
+	intr, err := NewInteractiveCache(cmd).GetInteractive(context.Background(), "article", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.Interactive{
+		Biz: "article", BizId: 1, ReadCnt: 10, LikeCnt: 2,
+		ReactionCnts: map[string]int64{domain.ReactionLike: 2, "funny": 3},
+	}, intr)
+}
@@ -0,0 +1,15 @@
+local flushing = KEYS[1]
+local lock = KEYS[2]
+local owner = ARGV[1]
+-- 1 表示已经写入数据库，可以删除这一批
+local done = ARGV[2]
+
+if redis.call("GET", lock) ~= owner then
+    -- 锁已经过期，这一批可能已经被别的实例拿走了
+    return 0
+end
+if done == "1" then
+    redis.call("DEL", flushing)
+end
+redis.call("DEL", lock)
+return 1
@@ -0,0 +1,13 @@
+-- KEYS 成对的收件箱和活跃标记，ARGV[1] 收件箱容量，ARGV[2] member，ARGV[3] 发表时间
+-- 只推给活跃的用户，不活跃的用户回来的时候再拉
+local cnt = 0
+for i = 1, #KEYS, 2 do
+    local ttl = redis.call('pttl', KEYS[i + 1])
+    if ttl > 0 then
+        redis.call('zadd', KEYS[i], 'NX', ARGV[3], ARGV[2])
+        redis.call('zremrangebyrank', KEYS[i], 0, -tonumber(ARGV[1]) - 1)
+        redis.call('pexpire', KEYS[i], ttl)
+        cnt = cnt + 1
+    end
+end
+return cnt
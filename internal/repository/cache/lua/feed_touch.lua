@@ -0,0 +1,8 @@
+-- KEYS[1] 收件箱，KEYS[2] 活跃标记，ARGV[1] 过期时间（毫秒）
+-- 活跃标记不在说明收件箱已经不全了，要重建
+if redis.call('exists', KEYS[2]) == 0 then
+    return 0
+end
+redis.call('pexpire', KEYS[2], ARGV[1])
+redis.call('pexpire', KEYS[1], ARGV[1])
+return 1
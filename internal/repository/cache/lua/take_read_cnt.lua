@@ -0,0 +1,23 @@
+-- 待刷新的阅读数
+local pending = KEYS[1]
+-- 正在刷新的阅读数，刷新成功之后才删除
+local flushing = KEYS[2]
+local lock = KEYS[3]
+-- 锁的持有者和过期时间(毫秒)
+local owner = ARGV[1]
+local lease = ARGV[2]
+
+if not redis.call("SET", lock, owner, "NX", "PX", lease) then
+    -- 别的实例正在刷新
+    return {}
+end
+
+-- 上一次刷新没有确认，可能是进程崩溃了，先把这一批刷掉
+if redis.call("EXISTS", flushing) == 0 then
+    if redis.call("EXISTS", pending) == 0 then
+        redis.call("DEL", lock)
+        return {}
+    end
+    redis.call("RENAME", pending, flushing)
+end
+return redis.call("HGETALL", flushing)
@@ -0,0 +1,6 @@
+-- KEYS[1] 当前时间桶，ARGV[1] 时间桶的过期时间（毫秒），后面是成对的 member 和分数
+for i = 2, #ARGV, 2 do
+    redis.call('zincrby', KEYS[1], ARGV[i + 1], ARGV[i])
+end
+redis.call('pexpire', KEYS[1], ARGV[1])
+return 1
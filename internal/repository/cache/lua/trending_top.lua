@@ -0,0 +1,7 @@
+-- KEYS[1] 合并结果，后面是要合并的时间桶
+-- ARGV[1] 合并结果的过期时间（毫秒），ARGV[2] 取多少个
+if redis.call('exists', KEYS[1]) == 0 then
+    redis.call('zunionstore', KEYS[1], #KEYS - 1, unpack(KEYS, 2))
+    redis.call('pexpire', KEYS[1], ARGV[1])
+end
+return redis.call('zrevrange', KEYS[1], 0, tonumber(ARGV[2]) - 1, 'WITHSCORES')
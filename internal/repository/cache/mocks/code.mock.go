@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen.exe -source=./internal/repository/cache/code.go -package=cachemocks -destination=./internal/repository/cache/mocks/code.mock.go
+//	mockgen -source=./internal/repository/cache/code.go -package=cachemocks -destination=./internal/repository/cache/mocks/code.mock.go
 //
 // Package cachemocks is a generated GoMock package.
 package cachemocks
@@ -0,0 +1,210 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cache/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cache/interactive.go -package=cachemocks -destination=./internal/repository/cache/mocks/interactive.mock.go
+//
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveCache is a mock of InteractiveCache interface.
+type MockInteractiveCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveCacheMockRecorder
+}
+
+// MockInteractiveCacheMockRecorder is the mock recorder for MockInteractiveCache.
+type MockInteractiveCacheMockRecorder struct {
+	mock *MockInteractiveCache
+}
+
+// NewMockInteractiveCache creates a new mock instance.
+func NewMockInteractiveCache(ctrl *gomock.Controller) *MockInteractiveCache {
+	mock := &MockInteractiveCache{ctrl: ctrl}
+	mock.recorder = &MockInteractiveCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveCache) EXPECT() *MockInteractiveCacheMockRecorder {
+	return m.recorder
+}
+
+// BatchIncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) BatchIncrReadCntIfPresent(ctx context.Context, bizs []string, bizIds, cnts []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCntIfPresent", ctx, bizs, bizIds, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCntIfPresent indicates an expected call of BatchIncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) BatchIncrReadCntIfPresent(ctx, bizs, bizIds, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).BatchIncrReadCntIfPresent), ctx, bizs, bizIds, cnts)
+}
+
+// DecrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrCollectCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrCollectCntIfPresent indicates an expected call of DecrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrCollectCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrCollectCntIfPresent), ctx, biz, bizId)
+}
+
+// DecrCommentCntIfPresent mocks base method.
+func (m *MockInteractiveCache) DecrCommentCntIfPresent(ctx context.Context, biz string, bizId, cnt int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DecrCommentCntIfPresent", ctx, biz, bizId, cnt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DecrCommentCntIfPresent indicates an expected call of DecrCommentCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) DecrCommentCntIfPresent(ctx, biz, bizId, cnt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DecrCommentCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).DecrCommentCntIfPresent), ctx, biz, bizId, cnt)
+}
+
+// GetInteractive mocks base method.
+func (m *MockInteractiveCache) GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInteractive", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInteractive indicates an expected call of GetInteractive.
+func (mr *MockInteractiveCacheMockRecorder) GetInteractive(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInteractive", reflect.TypeOf((*MockInteractiveCache)(nil).GetInteractive), ctx, biz, bizId)
+}
+
+// GetInteractives mocks base method.
+func (m *MockInteractiveCache) GetInteractives(ctx context.Context, biz string, bizIds []int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInteractives", ctx, biz, bizIds)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInteractives indicates an expected call of GetInteractives.
+func (mr *MockInteractiveCacheMockRecorder) GetInteractives(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInteractives", reflect.TypeOf((*MockInteractiveCache)(nil).GetInteractives), ctx, biz, bizIds)
+}
+
+// IncrCollectCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrCollectCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCollectCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCollectCntIfPresent indicates an expected call of IncrCollectCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrCollectCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCollectCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCollectCntIfPresent), ctx, biz, bizId)
+}
+
+// IncrCommentCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrCommentCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCommentCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCommentCntIfPresent indicates an expected call of IncrCommentCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrCommentCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCommentCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrCommentCntIfPresent), ctx, biz, bizId)
+}
+
+// IncrReactionCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrReactionCntIfPresent(ctx context.Context, biz string, bizId int64, reaction string, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReactionCntIfPresent", ctx, biz, bizId, reaction, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReactionCntIfPresent indicates an expected call of IncrReactionCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrReactionCntIfPresent(ctx, biz, bizId, reaction, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReactionCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrReactionCntIfPresent), ctx, biz, bizId, reaction, delta)
+}
+
+// IncrReadCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrReadCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCntIfPresent indicates an expected call of IncrReadCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrReadCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrReadCntIfPresent), ctx, biz, bizId)
+}
+
+// IncrShareCntIfPresent mocks base method.
+func (m *MockInteractiveCache) IncrShareCntIfPresent(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrShareCntIfPresent", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrShareCntIfPresent indicates an expected call of IncrShareCntIfPresent.
+func (mr *MockInteractiveCacheMockRecorder) IncrShareCntIfPresent(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrShareCntIfPresent", reflect.TypeOf((*MockInteractiveCache)(nil).IncrShareCntIfPresent), ctx, biz, bizId)
+}
+
+// SetInteractive mocks base method.
+func (m *MockInteractiveCache) SetInteractive(ctx context.Context, res domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInteractive", ctx, res)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInteractive indicates an expected call of SetInteractive.
+func (mr *MockInteractiveCacheMockRecorder) SetInteractive(ctx, res any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInteractive", reflect.TypeOf((*MockInteractiveCache)(nil).SetInteractive), ctx, res)
+}
+
+// SetInteractives mocks base method.
+func (m *MockInteractiveCache) SetInteractives(ctx context.Context, intrs []domain.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetInteractives", ctx, intrs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetInteractives indicates an expected call of SetInteractives.
+func (mr *MockInteractiveCacheMockRecorder) SetInteractives(ctx, intrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInteractives", reflect.TypeOf((*MockInteractiveCache)(nil).SetInteractives), ctx, intrs)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cache/read_cnt_buffer.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cache/read_cnt_buffer.go -package=cachemocks -destination=./internal/repository/cache/mocks/read_cnt_buffer.mock.go
+//
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	cache "webook/internal/repository/cache"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReadCntBuffer is a mock of ReadCntBuffer interface.
+type MockReadCntBuffer struct {
+	ctrl     *gomock.Controller
+	recorder *MockReadCntBufferMockRecorder
+}
+
+// MockReadCntBufferMockRecorder is the mock recorder for MockReadCntBuffer.
+type MockReadCntBufferMockRecorder struct {
+	mock *MockReadCntBuffer
+}
+
+// NewMockReadCntBuffer creates a new mock instance.
+func NewMockReadCntBuffer(ctrl *gomock.Controller) *MockReadCntBuffer {
+	mock := &MockReadCntBuffer{ctrl: ctrl}
+	mock.recorder = &MockReadCntBufferMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReadCntBuffer) EXPECT() *MockReadCntBufferMockRecorder {
+	return m.recorder
+}
+
+// Ack mocks base method.
+func (m *MockReadCntBuffer) Ack(ctx context.Context, owner string, done bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ack", ctx, owner, done)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ack indicates an expected call of Ack.
+func (mr *MockReadCntBufferMockRecorder) Ack(ctx, owner, done any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ack", reflect.TypeOf((*MockReadCntBuffer)(nil).Ack), ctx, owner, done)
+}
+
+// Add mocks base method.
+func (m *MockReadCntBuffer) Add(ctx context.Context, biz string, bizId, delta int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockReadCntBufferMockRecorder) Add(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockReadCntBuffer)(nil).Add), ctx, biz, bizId, delta)
+}
+
+// AddBatch mocks base method.
+func (m *MockReadCntBuffer) AddBatch(ctx context.Context, deltas []cache.ReadCntDelta) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBatch", ctx, deltas)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBatch indicates an expected call of AddBatch.
+func (mr *MockReadCntBufferMockRecorder) AddBatch(ctx, deltas any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBatch", reflect.TypeOf((*MockReadCntBuffer)(nil).AddBatch), ctx, deltas)
+}
+
+// Depth mocks base method.
+func (m *MockReadCntBuffer) Depth(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Depth", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Depth indicates an expected call of Depth.
+func (mr *MockReadCntBufferMockRecorder) Depth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Depth", reflect.TypeOf((*MockReadCntBuffer)(nil).Depth), ctx)
+}
+
+// Pending mocks base method.
+func (m *MockReadCntBuffer) Pending(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pending", ctx, biz, bizIds)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pending indicates an expected call of Pending.
+func (mr *MockReadCntBufferMockRecorder) Pending(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pending", reflect.TypeOf((*MockReadCntBuffer)(nil).Pending), ctx, biz, bizIds)
+}
+
+// Take mocks base method.
+func (m *MockReadCntBuffer) Take(ctx context.Context, owner string, lease time.Duration) ([]cache.ReadCntDelta, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Take", ctx, owner, lease)
+	ret0, _ := ret[0].([]cache.ReadCntDelta)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Take indicates an expected call of Take.
+func (mr *MockReadCntBufferMockRecorder) Take(ctx, owner, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Take", reflect.TypeOf((*MockReadCntBuffer)(nil).Take), ctx, owner, lease)
+}
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/cache/reader.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/cache/reader.go -package=cachemocks -destination=./internal/repository/cache/mocks/reader.mock.go
+//
+// Package cachemocks is a generated GoMock package.
+package cachemocks
+
+import (
+	context "context"
+	reflect "reflect"
+	cache "webook/internal/repository/cache"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockReaderCache is a mock of ReaderCache interface.
+type MockReaderCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockReaderCacheMockRecorder
+}
+
+// MockReaderCacheMockRecorder is the mock recorder for MockReaderCache.
+type MockReaderCacheMockRecorder struct {
+	mock *MockReaderCache
+}
+
+// NewMockReaderCache creates a new mock instance.
+func NewMockReaderCache(ctrl *gomock.Controller) *MockReaderCache {
+	mock := &MockReaderCache{ctrl: ctrl}
+	mock.recorder = &MockReaderCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReaderCache) EXPECT() *MockReaderCacheMockRecorder {
+	return m.recorder
+}
+
+// BatchRecord mocks base method.
+func (m *MockReaderCache) BatchRecord(ctx context.Context, bizs []string, bizIds, uids []int64) ([]bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchRecord", ctx, bizs, bizIds, uids)
+	ret0, _ := ret[0].([]bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchRecord indicates an expected call of BatchRecord.
+func (mr *MockReaderCacheMockRecorder) BatchRecord(ctx, bizs, bizIds, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchRecord", reflect.TypeOf((*MockReaderCache)(nil).BatchRecord), ctx, bizs, bizIds, uids)
+}
+
+// Count mocks base method.
+func (m *MockReaderCache) Count(ctx context.Context, biz string, bizId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Count", ctx, biz, bizId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Count indicates an expected call of Count.
+func (mr *MockReaderCacheMockRecorder) Count(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Count", reflect.TypeOf((*MockReaderCache)(nil).Count), ctx, biz, bizId)
+}
+
+// Forget mocks base method.
+func (m *MockReaderCache) Forget(ctx context.Context, bizs []string, bizIds, uids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Forget", ctx, bizs, bizIds, uids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Forget indicates an expected call of Forget.
+func (mr *MockReaderCacheMockRecorder) Forget(ctx, bizs, bizIds, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Forget", reflect.TypeOf((*MockReaderCache)(nil).Forget), ctx, bizs, bizIds, uids)
+}
+
+// MarkDirty mocks base method.
+func (m *MockReaderCache) MarkDirty(ctx context.Context, cnts []cache.ReaderCnt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkDirty", ctx, cnts)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkDirty indicates an expected call of MarkDirty.
+func (mr *MockReaderCacheMockRecorder) MarkDirty(ctx, cnts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkDirty", reflect.TypeOf((*MockReaderCache)(nil).MarkDirty), ctx, cnts)
+}
+
+// Record mocks base method.
+func (m *MockReaderCache) Record(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockReaderCacheMockRecorder) Record(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockReaderCache)(nil).Record), ctx, biz, bizId, uid)
+}
+
+// TakeDirty mocks base method.
+func (m *MockReaderCache) TakeDirty(ctx context.Context, n int64) ([]cache.ReaderCnt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TakeDirty", ctx, n)
+	ret0, _ := ret[0].([]cache.ReaderCnt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TakeDirty indicates an expected call of TakeDirty.
+func (mr *MockReaderCacheMockRecorder) TakeDirty(ctx, n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TakeDirty", reflect.TypeOf((*MockReaderCache)(nil).TakeDirty), ctx, n)
+}
@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+)
+
+type NotificationCache interface {
+	// GetUnreadCnt 每种类型的未读数
+	GetUnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error)
+	SetUnreadCnt(ctx context.Context, uid int64, cnts map[domain.NotificationType]int64) error
+	// DelUnreadCnt 有新通知或者标记已读之后删掉，下次读的时候重新数
+	DelUnreadCnt(ctx context.Context, uid int64) error
+}
+
+type RedisNotificationCache struct {
+	cmd        redis.Cmdable
+	expiration time.Duration
+}
+
+func NewRedisNotificationCache(cmd redis.Cmdable) NotificationCache {
+	return &RedisNotificationCache{
+		cmd:        cmd,
+		expiration: 10 * time.Minute,
+	}
+}
+
+func (r *RedisNotificationCache) GetUnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error) {
+	res, err := r.cmd.HGetAll(ctx, r.key(uid)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(res) == 0 {
+		return nil, ErrKeyNotExist
+	}
+	cnts := make(map[domain.NotificationType]int64, len(res))
+	for typ, val := range res {
+		cnts[domain.NotificationType(typ)], _ = strconv.ParseInt(val, 10, 64)
+	}
+	return cnts, nil
+}
+
+func (r *RedisNotificationCache) SetUnreadCnt(ctx context.Context, uid int64, cnts map[domain.NotificationType]int64) error {
+	// 每种类型都写进去，全是 0 的时候也不是空的 hash
+	vals := make([]any, 0, len(domain.NotificationTypes)*2)
+	for _, typ := range domain.NotificationTypes {
+		vals = append(vals, string(typ), cnts[typ])
+	}
+	key := r.key(uid)
+	err := r.cmd.HMSet(ctx, key, vals...).Err()
+	if err != nil {
+		return err
+	}
+	return r.cmd.Expire(ctx, key, r.expiration).Err()
+}
+
+func (r *RedisNotificationCache) DelUnreadCnt(ctx context.Context, uid int64) error {
+	return r.cmd.Del(ctx, r.key(uid)).Err()
+}
+
+func (r *RedisNotificationCache) key(uid int64) string {
+	return fmt.Sprintf("notification:unread:%d", uid)
+}
@@ -0,0 +1,101 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"sync"
+	"time"
+	"webook/internal/domain"
+)
+
+var ErrRankingExpired = errors.New("本地热榜已经过期")
+
+type RankingCache interface {
+	Set(ctx context.Context, arts []domain.Article) error
+	Get(ctx context.Context) ([]domain.Article, error)
+}
+
+type RankingRedisCache struct {
+	cmd redis.Cmdable
+	key string
+	// 要比计算热榜的间隔长，计算失败一两次还能用上一次的结果
+	expiration time.Duration
+}
+
+func NewRankingRedisCache(cmd redis.Cmdable) RankingCache {
+	return &RankingRedisCache{
+		cmd:        cmd,
+		key:        "ranking:article:hot",
+		expiration: time.Hour,
+	}
+}
+
+func (r *RankingRedisCache) Set(ctx context.Context, arts []domain.Article) error {
+	// 热榜只需要标题，不存内容。复制一份再清空，调用方的切片可能已经放进了本地缓存
+	res := make([]domain.Article, len(arts))
+	copy(res, arts)
+	for i := range res {
+		res[i].Content = ""
+	}
+	val, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	return r.cmd.Set(ctx, r.key, val, r.expiration).Err()
+}
+
+func (r *RankingRedisCache) Get(ctx context.Context) ([]domain.Article, error) {
+	val, err := r.cmd.Get(ctx, r.key).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var res []domain.Article
+	err = json.Unmarshal(val, &res)
+	return res, err
+}
+
+// RankingLocalCache 本地的一份热榜，读的时候先看它，redis 挂了也能用过期的兜底
+type RankingLocalCache struct {
+	mu   sync.RWMutex
+	arts []domain.Article
+	ddl  time.Time
+	// 本地的过期时间要短，不然别的实例算出来的新热榜要很久才能看到
+	expiration time.Duration
+	now        func() time.Time
+}
+
+func NewRankingLocalCache() *RankingLocalCache {
+	return &RankingLocalCache{
+		expiration: time.Minute,
+		now:        time.Now,
+	}
+}
+
+func (r *RankingLocalCache) Set(ctx context.Context, arts []domain.Article) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.arts = arts
+	r.ddl = r.now().Add(r.expiration)
+	return nil
+}
+
+func (r *RankingLocalCache) Get(ctx context.Context) ([]domain.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.arts == nil || r.now().After(r.ddl) {
+		return nil, ErrRankingExpired
+	}
+	return r.arts, nil
+}
+
+// ForceGet 不管有没有过期，只要有就返回
+func (r *RankingLocalCache) ForceGet(ctx context.Context) ([]domain.Article, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.arts == nil {
+		return nil, ErrRankingExpired
+	}
+	return r.arts, nil
+}
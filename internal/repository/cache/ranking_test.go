@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRankingLocalCache(t *testing.T) {
+	now := time.Now()
+	c := NewRankingLocalCache()
+	c.now = func() time.Time {
+		return now
+	}
+	ctx := context.Background()
+	_, err := c.Get(ctx)
+	assert.Equal(t, ErrRankingExpired, err)
+
+	arts := []domain.Article{{Id: 1}, {Id: 2}}
+	_ = c.Set(ctx, arts)
+	res, err := c.Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, arts, res)
+
+	// 过期之后 Get 拿不到，ForceGet 还能兜底
+	now = now.Add(c.expiration + time.Second)
+	_, err = c.Get(ctx)
+	assert.Equal(t, ErrRankingExpired, err)
+	res, err = c.ForceGet(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, arts, res)
+}
+
+func TestRankingRedisCache_Set(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	// redis 里面不存内容
+	cmd.EXPECT().Set(gomock.Any(), "ranking:article:hot", gomock.Any(), time.Hour).
+		DoAndReturn(func(ctx context.Context, key string, val any, expiration time.Duration) *redis.StatusCmd {
+			var stored []domain.Article
+			assert.NoError(t, json.Unmarshal(val.([]byte), &stored))
+			assert.Equal(t, []domain.Article{{Id: 1, Title: "标题"}}, stored)
+			return redis.NewStatusResult("OK", nil)
+		})
+	arts := []domain.Article{{Id: 1, Title: "标题", Content: "内容"}}
+	err := NewRankingRedisCache(cmd).Set(context.Background(), arts)
+	assert.NoError(t, err)
+	// 调用方的切片不能被改掉
+	assert.Equal(t, "内容", arts[0].Content)
+}
@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	//go:embed lua/take_read_cnt.lua
+	luaTakeReadCnt string
+	//go:embed lua/ack_read_cnt.lua
+	luaAckReadCnt string
+)
+
+var ErrReadCntLockLost = errors.New("阅读数刷新锁已经过期")
+
+// 三个 key 用同一个 hash tag，保证在 redis cluster 里面落在同一个槽
+const (
+	readCntPendingKey  = "interactive:{read_cnt}:pending"
+	readCntFlushingKey = "interactive:{read_cnt}:flushing"
+	readCntLockKey     = "interactive:{read_cnt}:lock"
+)
+
+// ReadCntDelta 一个资源在缓冲区里面累计的阅读数
+type ReadCntDelta struct {
+	Biz   string
+	BizId int64
+	Cnt   int64
+}
+
+// ReadCntBuffer 阅读数的写缓冲，先在 redis 里面按 <biz, bizId> 聚合，再批量刷到数据库
+//
+// 只要 Add 返回成功，这次阅读就不会丢，除非 redis 本身丢数据（取决于 AOF 的配置）。
+// 刷新是 Take -> 写数据库 -> Ack 三步，进程在中间崩溃的话那一批会留在 flushing 里面，
+// 锁过期之后由下一次 Take 重新刷，所以是至少一次：
+// 只有写完数据库还没来得及 Ack 的那一批会被重复计数。
+type ReadCntBuffer interface {
+	Add(ctx context.Context, biz string, bizId int64, delta int64) error
+	// AddBatch 用一个 pipeline 批量加
+	AddBatch(ctx context.Context, deltas []ReadCntDelta) error
+	// Take 拿到锁之后取出一批，拿不到锁或者没有数据的时候返回空
+	Take(ctx context.Context, owner string, lease time.Duration) ([]ReadCntDelta, error)
+	// Ack 释放锁，done 为 true 的时候同时删除这一批
+	Ack(ctx context.Context, owner string, done bool) error
+	// Pending 还没有写到数据库的阅读数，包括正在刷新的
+	Pending(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error)
+	// Depth 待刷新的资源个数
+	Depth(ctx context.Context) (int64, error)
+}
+
+type RedisReadCntBuffer struct {
+	cmd redis.Cmdable
+}
+
+func NewRedisReadCntBuffer(cmd redis.Cmdable) ReadCntBuffer {
+	return &RedisReadCntBuffer{
+		cmd: cmd,
+	}
+}
+
+func (r *RedisReadCntBuffer) Add(ctx context.Context, biz string, bizId int64, delta int64) error {
+	return r.cmd.HIncrBy(ctx, readCntPendingKey, bizMember(biz, bizId), delta).Err()
+}
+
+func (r *RedisReadCntBuffer) AddBatch(ctx context.Context, deltas []ReadCntDelta) error {
+	if len(deltas) == 0 {
+		return nil
+	}
+	pipe := r.cmd.Pipeline()
+	for _, d := range deltas {
+		pipe.HIncrBy(ctx, readCntPendingKey, bizMember(d.Biz, d.BizId), d.Cnt)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisReadCntBuffer) Take(ctx context.Context, owner string, lease time.Duration) ([]ReadCntDelta, error) {
+	vals, err := r.cmd.Eval(ctx, luaTakeReadCnt,
+		[]string{readCntPendingKey, readCntFlushingKey, readCntLockKey},
+		owner, lease.Milliseconds()).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	res := make([]ReadCntDelta, 0, len(vals)/2)
+	for i := 0; i+1 < len(vals); i += 2 {
+		biz, bizId, ok := parseBizMember(vals[i])
+		if !ok {
+			continue
+		}
+		cnt, er := strconv.ParseInt(vals[i+1], 10, 64)
+		if er != nil || cnt == 0 {
+			continue
+		}
+		res = append(res, ReadCntDelta{Biz: biz, BizId: bizId, Cnt: cnt})
+	}
+	return res, nil
+}
+
+func (r *RedisReadCntBuffer) Ack(ctx context.Context, owner string, done bool) error {
+	flag := "0"
+	if done {
+		flag = "1"
+	}
+	res, err := r.cmd.Eval(ctx, luaAckReadCnt,
+		[]string{readCntFlushingKey, readCntLockKey}, owner, flag).Int()
+	if err != nil {
+		return err
+	}
+	if res == 0 {
+		return ErrReadCntLockLost
+	}
+	return nil
+}
+
+func (r *RedisReadCntBuffer) Pending(ctx context.Context, biz string, bizIds []int64) (map[int64]int64, error) {
+	res := make(map[int64]int64, len(bizIds))
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	fields := make([]string, 0, len(bizIds))
+	for _, bizId := range bizIds {
+		fields = append(fields, bizMember(biz, bizId))
+	}
+	pipe := r.cmd.Pipeline()
+	pending := pipe.HMGet(ctx, readCntPendingKey, fields...)
+	flushing := pipe.HMGet(ctx, readCntFlushingKey, fields...)
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, cmd := range []*redis.SliceCmd{pending, flushing} {
+		for idx, val := range cmd.Val() {
+			str, ok := val.(string)
+			if !ok {
+				continue
+			}
+			cnt, _ := strconv.ParseInt(str, 10, 64)
+			res[bizIds[idx]] += cnt
+		}
+	}
+	return res, nil
+}
+
+func (r *RedisReadCntBuffer) Depth(ctx context.Context) (int64, error) {
+	return r.cmd.HLen(ctx, readCntPendingKey).Result()
+}
+
+// bizMember 用 biz:bizId 作为 hash 的字段或者 set 的成员
+func bizMember(biz string, bizId int64) string {
+	return fmt.Sprintf("%s:%d", biz, bizId)
+}
+
+func parseBizMember(member string) (string, int64, bool) {
+	idx := strings.LastIndexByte(member, ':')
+	if idx <= 0 {
+		return "", 0, false
+	}
+	bizId, err := strconv.ParseInt(member[idx+1:], 10, 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return member[:idx], bizId, true
+}
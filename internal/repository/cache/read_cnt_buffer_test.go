@@ -0,0 +1,124 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisReadCntBuffer_Take(t *testing.T) {
+	keys := []string{readCntPendingKey, readCntFlushingKey, readCntLockKey}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		want    []ReadCntDelta
+		wantErr error
+	}{
+		{
+			name: "取出一批",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult([]interface{}{
+					"article:1", "3",
+					"article:2", "10",
+					// 解析不了的直接跳过
+					"bad", "1",
+				}, nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaTakeReadCnt, keys,
+					"owner", int64(60000)).Return(mockRes)
+				return cmd
+			},
+			want: []ReadCntDelta{
+				{Biz: "article", BizId: 1, Cnt: 3},
+				{Biz: "article", BizId: 2, Cnt: 10},
+			},
+		},
+		{
+			name: "拿不到锁或者没有数据",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult([]interface{}{}, nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaTakeReadCnt, keys,
+					"owner", int64(60000)).Return(mockRes)
+				return cmd
+			},
+			want: []ReadCntDelta{},
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(nil, errors.New("redis error"))
+				cmd.EXPECT().Eval(gomock.Any(), luaTakeReadCnt, keys,
+					"owner", int64(60000)).Return(mockRes)
+				return cmd
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			b := NewRedisReadCntBuffer(tc.mock(ctrl))
+			res, err := b.Take(context.Background(), "owner", time.Minute)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
+
+func TestRedisReadCntBuffer_Ack(t *testing.T) {
+	keys := []string{readCntFlushingKey, readCntLockKey}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		done    bool
+		wantErr error
+	}{
+		{
+			name: "刷新成功",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(1), nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaAckReadCnt, keys, "owner", "1").Return(mockRes)
+				return cmd
+			},
+			done: true,
+		},
+		{
+			name: "刷新失败只释放锁",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(1), nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaAckReadCnt, keys, "owner", "0").Return(mockRes)
+				return cmd
+			},
+		},
+		{
+			name: "锁已经过期",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				mockRes := redis.NewCmdResult(int64(0), nil)
+				cmd.EXPECT().Eval(gomock.Any(), luaAckReadCnt, keys, "owner", "1").Return(mockRes)
+				return cmd
+			},
+			done:    true,
+			wantErr: ErrReadCntLockLost,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			b := NewRedisReadCntBuffer(tc.mock(ctrl))
+			err := b.Ack(context.Background(), "owner", tc.done)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+// 有新读者的资源，定时把它们的独立读者数持久化到数据库
+const readerDirtyKey = "interactive:reader:dirty"
+
+// ReaderCnt 一个资源的独立读者数
+type ReaderCnt struct {
+	Biz   string
+	BizId int64
+	Cnt   int64
+}
+
+// ReaderCache 用 HyperLogLog 统计独立读者数，同时做阅读数的去重
+type ReaderCache interface {
+	// Record 记录一次阅读，返回这次阅读要不要计入阅读数
+	// 同一个用户在去重窗口内重复阅读只算一次，uid 为 0 的匿名阅读不去重
+	Record(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
+	// BatchRecord 用一个 pipeline 批量记录，返回值和参数一一对应
+	BatchRecord(ctx context.Context, bizs []string, bizIds []int64, uids []int64) ([]bool, error)
+	// Forget 删掉去重标记，后面计数失败的时候调用，不然重试的时候这些阅读会被当成重复的
+	Forget(ctx context.Context, bizs []string, bizIds []int64, uids []int64) error
+	// Count 独立读者数，误差在 1% 左右
+	Count(ctx context.Context, biz string, bizId int64) (int64, error)
+	// TakeDirty 取出最多 n 个有新读者的资源和它们当前的独立读者数
+	TakeDirty(ctx context.Context, n int64) ([]ReaderCnt, error)
+	// MarkDirty 持久化失败的时候放回去
+	MarkDirty(ctx context.Context, cnts []ReaderCnt) error
+}
+
+type RedisReaderCache struct {
+	cmd redis.Cmdable
+	// 去重窗口，0 表示不去重
+	window time.Duration
+}
+
+func NewRedisReaderCache(cmd redis.Cmdable, window time.Duration) ReaderCache {
+	return &RedisReaderCache{
+		cmd:    cmd,
+		window: window,
+	}
+}
+
+func (r *RedisReaderCache) Record(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
+	res, err := r.BatchRecord(ctx, []string{biz}, []int64{bizId}, []int64{uid})
+	if err != nil {
+		return false, err
+	}
+	return res[0], nil
+}
+
+func (r *RedisReaderCache) BatchRecord(ctx context.Context, bizs []string, bizIds []int64, uids []int64) ([]bool, error) {
+	if len(bizs) == 0 {
+		return nil, nil
+	}
+	pipe := r.cmd.Pipeline()
+	dedups := make([]*redis.BoolCmd, len(bizs))
+	for i := range bizs {
+		if uids[i] <= 0 {
+			continue
+		}
+		if r.window > 0 {
+			dedups[i] = pipe.SetNX(ctx, r.dedupKey(bizs[i], bizIds[i], uids[i]), 1, r.window)
+		}
+		pipe.PFAdd(ctx, r.key(bizs[i], bizIds[i]), uids[i])
+		pipe.SAdd(ctx, readerDirtyKey, bizMember(bizs[i], bizIds[i]))
+	}
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]bool, len(bizs))
+	for i, cmd := range dedups {
+		// 没有去重的都算
+		res[i] = cmd == nil || cmd.Val()
+	}
+	return res, nil
+}
+
+func (r *RedisReaderCache) Forget(ctx context.Context, bizs []string, bizIds []int64, uids []int64) error {
+	if r.window <= 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(bizs))
+	for i := range bizs {
+		if uids[i] > 0 {
+			keys = append(keys, r.dedupKey(bizs[i], bizIds[i], uids[i]))
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return r.cmd.Del(ctx, keys...).Err()
+}
+
+func (r *RedisReaderCache) Count(ctx context.Context, biz string, bizId int64) (int64, error) {
+	return r.cmd.PFCount(ctx, r.key(biz, bizId)).Result()
+}
+
+func (r *RedisReaderCache) TakeDirty(ctx context.Context, n int64) ([]ReaderCnt, error) {
+	members, err := r.cmd.SPopN(ctx, readerDirtyKey, n).Result()
+	if err != nil || len(members) == 0 {
+		return nil, err
+	}
+	res := make([]ReaderCnt, 0, len(members))
+	for _, m := range members {
+		biz, bizId, ok := parseBizMember(m)
+		if !ok {
+			continue
+		}
+		res = append(res, ReaderCnt{Biz: biz, BizId: bizId})
+	}
+	pipe := r.cmd.Pipeline()
+	cmds := make([]*redis.IntCmd, 0, len(res))
+	for _, rc := range res {
+		cmds = append(cmds, pipe.PFCount(ctx, r.key(rc.Biz, rc.BizId)))
+	}
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		_ = r.MarkDirty(ctx, res)
+		return nil, err
+	}
+	for i, cmd := range cmds {
+		res[i].Cnt = cmd.Val()
+	}
+	return res, nil
+}
+
+func (r *RedisReaderCache) MarkDirty(ctx context.Context, cnts []ReaderCnt) error {
+	if len(cnts) == 0 {
+		return nil
+	}
+	members := make([]interface{}, 0, len(cnts))
+	for _, rc := range cnts {
+		members = append(members, bizMember(rc.Biz, rc.BizId))
+	}
+	return r.cmd.SAdd(ctx, readerDirtyKey, members...).Err()
+}
+
+func (r *RedisReaderCache) key(biz string, bizId int64) string {
+	return fmt.Sprintf("interactive:reader:%s:%d", biz, bizId)
+}
+
+func (r *RedisReaderCache) dedupKey(biz string, bizId int64, uid int64) string {
+	return fmt.Sprintf("interactive:read_dedup:%s:%d:%d", biz, bizId, uid)
+}
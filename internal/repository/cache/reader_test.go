@@ -0,0 +1,180 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisReaderCache_Record(t *testing.T) {
+	const dedupKey = "interactive:read_dedup:article:1:2"
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) redis.Cmdable
+		window time.Duration
+		uid    int64
+
+		wantCounted bool
+		wantErr     error
+	}{
+		{
+			name: "第一次阅读",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				pipe.EXPECT().SetNX(gomock.Any(), dedupKey, 1, time.Minute).Return(redis.NewBoolResult(true, nil))
+				pipe.EXPECT().PFAdd(gomock.Any(), "interactive:reader:article:1", int64(2)).Return(redis.NewIntResult(1, nil))
+				pipe.EXPECT().SAdd(gomock.Any(), readerDirtyKey, "article:1").Return(redis.NewIntResult(1, nil))
+				pipe.EXPECT().Exec(gomock.Any()).Return(nil, nil)
+				return cmd
+			},
+			window:      time.Minute,
+			uid:         2,
+			wantCounted: true,
+		},
+		{
+			name: "窗口内重复阅读不计数，但还是独立读者",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				// key 还在，SETNX 失败
+				pipe.EXPECT().SetNX(gomock.Any(), dedupKey, 1, time.Minute).Return(redis.NewBoolResult(false, nil))
+				pipe.EXPECT().PFAdd(gomock.Any(), "interactive:reader:article:1", int64(2)).Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().SAdd(gomock.Any(), readerDirtyKey, "article:1").Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().Exec(gomock.Any()).Return(nil, nil)
+				return cmd
+			},
+			window:      time.Minute,
+			uid:         2,
+			wantCounted: false,
+		},
+		{
+			name: "窗口过了再读重新计数",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				// key 过期了，SETNX 又能成功
+				pipe.EXPECT().SetNX(gomock.Any(), dedupKey, 1, time.Minute).Return(redis.NewBoolResult(true, nil))
+				pipe.EXPECT().PFAdd(gomock.Any(), "interactive:reader:article:1", int64(2)).Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().SAdd(gomock.Any(), readerDirtyKey, "article:1").Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().Exec(gomock.Any()).Return(nil, nil)
+				return cmd
+			},
+			window:      time.Minute,
+			uid:         2,
+			wantCounted: true,
+		},
+		{
+			name: "不去重",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				pipe.EXPECT().PFAdd(gomock.Any(), "interactive:reader:article:1", int64(2)).Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().SAdd(gomock.Any(), readerDirtyKey, "article:1").Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().Exec(gomock.Any()).Return(nil, nil)
+				return cmd
+			},
+			uid:         2,
+			wantCounted: true,
+		},
+		{
+			name: "匿名阅读不去重也不算独立读者",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				pipe.EXPECT().Exec(gomock.Any()).Return(nil, nil)
+				return cmd
+			},
+			window:      time.Minute,
+			wantCounted: true,
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				pipe := redismocks.NewMockPipeliner(ctrl)
+				cmd.EXPECT().Pipeline().Return(pipe)
+				pipe.EXPECT().SetNX(gomock.Any(), dedupKey, 1, time.Minute).Return(redis.NewBoolResult(false, nil))
+				pipe.EXPECT().PFAdd(gomock.Any(), "interactive:reader:article:1", int64(2)).Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().SAdd(gomock.Any(), readerDirtyKey, "article:1").Return(redis.NewIntResult(0, nil))
+				pipe.EXPECT().Exec(gomock.Any()).Return(nil, errors.New("redis error"))
+				return cmd
+			},
+			window:  time.Minute,
+			uid:     2,
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRedisReaderCache(tc.mock(ctrl), tc.window)
+			counted, err := c.Record(context.Background(), "article", 1, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCounted, counted)
+		})
+	}
+}
+
+func TestRedisReaderCache_Forget(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(ctrl *gomock.Controller) redis.Cmdable
+		window time.Duration
+		uids   []int64
+
+		wantErr error
+	}{
+		{
+			name: "删掉登录用户的去重标记",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Del(gomock.Any(), "interactive:read_dedup:article:1:2").
+					Return(redis.NewIntResult(1, nil))
+				return cmd
+			},
+			window: time.Minute,
+			// 匿名阅读没有去重标记
+			uids: []int64{2, 0},
+		},
+		{
+			name: "不去重的时候什么都不做",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismocks.NewMockCmdable(ctrl)
+			},
+			uids: []int64{2, 0},
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Del(gomock.Any(), "interactive:read_dedup:article:1:2").
+					Return(redis.NewIntResult(0, errors.New("redis error")))
+				return cmd
+			},
+			window:  time.Minute,
+			uids:    []int64{2, 0},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRedisReaderCache(tc.mock(ctrl), tc.window)
+			err := c.Forget(context.Background(), []string{"article", "article"}, []int64{1, 1}, tc.uids)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"time"
+)
+
+type RelatedCache interface {
+	Set(ctx context.Context, artId int64, ids []int64) error
+	// Get 没有算过或者已经过期返回 ErrKeyNotExist
+	Get(ctx context.Context, artId int64) ([]int64, error)
+}
+
+type RedisRelatedCache struct {
+	cmd redis.Cmdable
+	// 要比计算的间隔长得多，计算失败几次还能用上一次的结果
+	// 太久没有更新的文章不再计算，过期以后用热榜兜底
+	expiration time.Duration
+}
+
+func NewRedisRelatedCache(cmd redis.Cmdable) RelatedCache {
+	return &RedisRelatedCache{
+		cmd:        cmd,
+		expiration: 24 * time.Hour,
+	}
+}
+
+func (r *RedisRelatedCache) Set(ctx context.Context, artId int64, ids []int64) error {
+	val, err := json.Marshal(ids)
+	if err != nil {
+		return err
+	}
+	return r.cmd.Set(ctx, r.key(artId), val, r.expiration).Err()
+}
+
+func (r *RedisRelatedCache) Get(ctx context.Context, artId int64) ([]int64, error) {
+	val, err := r.cmd.Get(ctx, r.key(artId)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	var ids []int64
+	err = json.Unmarshal(val, &ids)
+	return ids, err
+}
+
+func (r *RedisRelatedCache) key(artId int64) string {
+	return fmt.Sprintf("related:article:%d", artId)
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"github.com/go-redis/redis/v8"
+	"strconv"
+	"time"
+	"webook/internal/domain"
+)
+
+var (
+	//go:embed lua/trending_incr.lua
+	luaTrendingIncr string
+	//go:embed lua/trending_top.lua
+	luaTrendingTop string
+)
+
+type TrendingCache interface {
+	// Incr 给当前时间桶里面的资源加分，key 是 bizId
+	Incr(ctx context.Context, biz string, scores map[int64]float64) error
+	// Top 合并最近 window 里面的时间桶，返回分数最高的 limit 个
+	Top(ctx context.Context, biz string, window time.Duration, limit int) ([]domain.TrendingItem, error)
+}
+
+// RedisTrendingCache 每个时间桶一个 zset，过了 Retention 自动过期
+// 查询的时候把窗口里面的桶合并成一个 zset，合并结果缓存 MergeTTL，避免每次请求都合并
+type RedisTrendingCache struct {
+	cmd redis.Cmdable
+
+	BucketSize time.Duration
+	// Retention 能查询的最大窗口
+	Retention time.Duration
+	MergeTTL  time.Duration
+	now       func() time.Time
+}
+
+func NewRedisTrendingCache(cmd redis.Cmdable) *RedisTrendingCache {
+	return &RedisTrendingCache{
+		cmd:        cmd,
+		BucketSize: 5 * time.Minute,
+		Retention:  24 * time.Hour,
+		MergeTTL:   30 * time.Second,
+		now:        time.Now,
+	}
+}
+
+func (r *RedisTrendingCache) Incr(ctx context.Context, biz string, scores map[int64]float64) error {
+	if len(scores) == 0 {
+		return nil
+	}
+	args := make([]any, 0, len(scores)*2+1)
+	// 最老的桶要在窗口完全滑过去之后才能过期
+	args = append(args, (r.Retention + r.BucketSize).Milliseconds())
+	for bizId, score := range scores {
+		args = append(args, bizId, score)
+	}
+	return r.cmd.Eval(ctx, luaTrendingIncr, []string{r.bucketKey(biz, r.now())}, args...).Err()
+}
+
+func (r *RedisTrendingCache) Top(ctx context.Context, biz string, window time.Duration, limit int) ([]domain.TrendingItem, error) {
+	// 当前的桶还没满也算进去
+	n := int(window / r.BucketSize)
+	if n < 1 {
+		n = 1
+	}
+	keys := make([]string, 0, n+1)
+	keys = append(keys, fmt.Sprintf("trending:%s:merged:%d", biz, int64(window.Seconds())))
+	now := r.now()
+	for i := 0; i < n; i++ {
+		keys = append(keys, r.bucketKey(biz, now.Add(-time.Duration(i)*r.BucketSize)))
+	}
+	res, err := r.cmd.Eval(ctx, luaTrendingTop, keys, r.MergeTTL.Milliseconds(), limit).StringSlice()
+	if err != nil {
+		return nil, err
+	}
+	items := make([]domain.TrendingItem, 0, len(res)/2)
+	for i := 0; i+1 < len(res); i += 2 {
+		bizId, err := strconv.ParseInt(res[i], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		score, err := strconv.ParseFloat(res[i+1], 64)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, domain.TrendingItem{BizId: bizId, Score: score})
+	}
+	return items, nil
+}
+
+func (r *RedisTrendingCache) bucketKey(biz string, t time.Time) string {
+	return fmt.Sprintf("trending:%s:%d", biz, t.Truncate(r.BucketSize).Unix())
+}
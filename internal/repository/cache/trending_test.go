@@ -0,0 +1,85 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisTrendingCache_Incr(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	cmd := redismocks.NewMockCmdable(ctrl)
+	// 1700000100 正好是一个 5 分钟桶的起点
+	cmd.EXPECT().Eval(gomock.Any(), luaTrendingIncr, []string{"trending:article:1700000100"},
+		int64((24*time.Hour + 5*time.Minute).Milliseconds()), int64(1), float64(5)).
+		Return(redis.NewCmdResult(int64(1), nil))
+	c := NewRedisTrendingCache(cmd)
+	c.now = func() time.Time {
+		return now
+	}
+	err := c.Incr(context.Background(), "article", map[int64]float64{1: 5})
+	assert.NoError(t, err)
+}
+
+func TestRedisTrendingCache_Top(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	testCases := []struct {
+		name      string
+		window    time.Duration
+		mock      func(ctrl *gomock.Controller) redis.Cmdable
+		wantItems []domain.TrendingItem
+		wantErr   error
+	}{
+		{
+			name:   "合并最近一刻钟",
+			window: 15 * time.Minute,
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaTrendingTop, []string{
+					"trending:article:merged:900",
+					"trending:article:1700000100",
+					"trending:article:1699999800",
+					"trending:article:1699999500",
+				}, int64(30000), 2).
+					Return(redis.NewCmdResult([]interface{}{"3", "15", "1", "6.5"}, nil))
+				return cmd
+			},
+			wantItems: []domain.TrendingItem{
+				{BizId: 3, Score: 15},
+				{BizId: 1, Score: 6.5},
+			},
+		},
+		{
+			name:   "redis返回error",
+			window: 5 * time.Minute,
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaTrendingTop, gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(nil, errors.New("redis error")))
+				return cmd
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewRedisTrendingCache(tc.mock(ctrl))
+			c.now = func() time.Time {
+				return now
+			}
+			items, err := c.Top(context.Background(), "article", tc.window, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantItems, items)
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/go-redis/redis/v8"
 	"time"
 	"webook/internal/domain"
+	"webook/pkg/cachex"
 )
 
 const ErrKeyNotExist = redis.Nil
@@ -53,7 +54,7 @@ func (c *RedisUserCache) Set(ctx context.Context, du domain.User) error {
 	if err != nil {
 		return err
 	}
-	return c.cmd.Set(ctx, key, data, c.expiration).Err()
+	return c.cmd.Set(ctx, key, data, cachex.Jitter(c.expiration)).Err()
 }
 
 // MemoryUserCache 基于本地缓存实现
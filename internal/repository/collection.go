@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+)
+
+var (
+	ErrCollectionNotFound     = dao.ErrRecordNotFound
+	ErrCollectionNoPermission = dao.ErrCollectionNoPermission
+)
+
+type CollectionRepository interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	Update(ctx context.Context, c domain.Collection) error
+	Delete(ctx context.Context, id int64, uid int64) error
+	FindById(ctx context.Context, id int64) (domain.Collection, error)
+	FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error)
+	FindItems(ctx context.Context, cid int64, uid int64, offset int, limit int) ([]domain.CollectionItem, error)
+}
+
+type CachedCollectionRepository struct {
+	dao       dao.CollectionDAO
+	intrCache cache.InteractiveCache
+}
+
+func NewCachedCollectionRepository(dao dao.CollectionDAO, intrCache cache.InteractiveCache) CollectionRepository {
+	return &CachedCollectionRepository{
+		dao:       dao,
+		intrCache: intrCache,
+	}
+}
+
+func (c *CachedCollectionRepository) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	return c.dao.Insert(ctx, c.toEntity(col))
+}
+
+func (c *CachedCollectionRepository) Update(ctx context.Context, col domain.Collection) error {
+	return c.dao.Update(ctx, c.toEntity(col))
+}
+
+func (c *CachedCollectionRepository) Delete(ctx context.Context, id int64, uid int64) error {
+	decrs, err := c.dao.Delete(ctx, id, uid)
+	if err != nil {
+		return err
+	}
+	for _, item := range decrs {
+		err = c.intrCache.DecrCollectCntIfPresent(ctx, item.Biz, item.BizId)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *CachedCollectionRepository) FindById(ctx context.Context, id int64) (domain.Collection, error) {
+	col, err := c.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Collection{}, err
+	}
+	return c.toDomain(col), nil
+}
+
+func (c *CachedCollectionRepository) FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]domain.Collection, error) {
+	cols, err := c.dao.FindByUid(ctx, uid, onlyPublic)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Collection, domain.Collection](cols, func(idx int, src dao.Collection) domain.Collection {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedCollectionRepository) FindItems(ctx context.Context, cid int64, uid int64, offset int, limit int) ([]domain.CollectionItem, error) {
+	items, err := c.dao.FindItems(ctx, cid, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.CollectionItem](items, func(idx int, src dao.UserCollectionBiz) domain.CollectionItem {
+		return domain.CollectionItem{
+			Id:    src.Id,
+			Cid:   src.Cid,
+			Uid:   src.Uid,
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: src.Ctime,
+			Utime: src.Utime,
+		}
+	}), nil
+}
+
+func (c *CachedCollectionRepository) toEntity(col domain.Collection) dao.Collection {
+	return dao.Collection{
+		Id:         col.Id,
+		Uid:        col.Uid,
+		Name:       col.Name,
+		Visibility: col.Visibility.ToUint8(),
+	}
+}
+
+func (c *CachedCollectionRepository) toDomain(col dao.Collection) domain.Collection {
+	return domain.Collection{
+		Id:         col.Id,
+		Uid:        col.Uid,
+		Name:       col.Name,
+		Visibility: domain.CollectionVisibility(col.Visibility),
+		Ctime:      col.Ctime,
+		Utime:      col.Utime,
+	}
+}
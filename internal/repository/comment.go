@@ -0,0 +1,140 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/ecodeclub/ekit/slice"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+)
+
+var ErrCommentNotFound = dao.ErrRecordNotFound
+
+type CommentRepository interface {
+	CreateComment(ctx context.Context, c domain.Comment) (int64, error)
+	DeleteComment(ctx context.Context, c domain.Comment) error
+	FindById(ctx context.Context, id int64) (domain.Comment, error)
+	FindByBiz(ctx context.Context, biz string, bizId int64, sort domain.CommentSort,
+		cursor domain.CommentCursor, limit int) ([]domain.Comment, error)
+	FindReplies(ctx context.Context, rid int64, lastId int64, limit int) ([]domain.Comment, error)
+	Like(ctx context.Context, id int64, uid int64) error
+	CancelLike(ctx context.Context, id int64, uid int64) error
+}
+
+type CachedCommentRepository struct {
+	dao       dao.CommentDAO
+	intrCache cache.InteractiveCache
+}
+
+func NewCachedCommentRepository(dao dao.CommentDAO, intrCache cache.InteractiveCache) CommentRepository {
+	return &CachedCommentRepository{
+		dao:       dao,
+		intrCache: intrCache,
+	}
+}
+
+func (c *CachedCommentRepository) CreateComment(ctx context.Context, cmt domain.Comment) (int64, error) {
+	id, err := c.dao.Insert(ctx, c.toEntity(cmt))
+	if err != nil {
+		return 0, err
+	}
+	return id, c.intrCache.IncrCommentCntIfPresent(ctx, cmt.Biz, cmt.BizId)
+}
+
+func (c *CachedCommentRepository) DeleteComment(ctx context.Context, cmt domain.Comment) error {
+	cnt, err := c.dao.Delete(ctx, c.toEntity(cmt))
+	if err != nil {
+		return err
+	}
+	if cnt == 0 {
+		return nil
+	}
+	return c.intrCache.DecrCommentCntIfPresent(ctx, cmt.Biz, cmt.BizId, cnt)
+}
+
+func (c *CachedCommentRepository) FindById(ctx context.Context, id int64) (domain.Comment, error) {
+	cmt, err := c.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	return c.toDomain(cmt), nil
+}
+
+func (c *CachedCommentRepository) FindByBiz(ctx context.Context, biz string, bizId int64,
+	sort domain.CommentSort, cursor domain.CommentCursor, limit int) ([]domain.Comment, error) {
+	var (
+		cmts []dao.Comment
+		err  error
+	)
+	switch sort {
+	case domain.CommentSortHot:
+		cmts, err = c.dao.FindHotByBiz(ctx, biz, bizId, cursor.LastLikeCnt, cursor.LastId, limit)
+	default:
+		cmts, err = c.dao.FindByBiz(ctx, biz, bizId, cursor.LastId, limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Comment, domain.Comment](cmts, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedCommentRepository) FindReplies(ctx context.Context, rid int64, lastId int64, limit int) ([]domain.Comment, error) {
+	cmts, err := c.dao.FindRepliesByRid(ctx, rid, lastId, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Comment, domain.Comment](cmts, func(idx int, src dao.Comment) domain.Comment {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedCommentRepository) Like(ctx context.Context, id int64, uid int64) error {
+	return c.dao.InsertLikeInfo(ctx, id, uid)
+}
+
+func (c *CachedCommentRepository) CancelLike(ctx context.Context, id int64, uid int64) error {
+	return c.dao.DeleteLikeInfo(ctx, id, uid)
+}
+
+func (c *CachedCommentRepository) toEntity(cmt domain.Comment) dao.Comment {
+	res := dao.Comment{
+		Id:      cmt.Id,
+		Uid:     cmt.Commentator.Id,
+		Biz:     cmt.Biz,
+		BizId:   cmt.BizId,
+		Content: cmt.Content,
+	}
+	if cmt.RootComment != nil {
+		res.RootId = sql.NullInt64{Int64: cmt.RootComment.Id, Valid: true}
+	}
+	if cmt.ParentComment != nil {
+		res.ParentId = sql.NullInt64{Int64: cmt.ParentComment.Id, Valid: true}
+	}
+	return res
+}
+
+func (c *CachedCommentRepository) toDomain(cmt dao.Comment) domain.Comment {
+	res := domain.Comment{
+		Id:    cmt.Id,
+		Biz:   cmt.Biz,
+		BizId: cmt.BizId,
+		Commentator: domain.User{
+			Id: cmt.Uid,
+		},
+		Content:  cmt.Content,
+		LikeCnt:  cmt.LikeCnt,
+		ReplyCnt: cmt.ReplyCnt,
+		Ctime:    cmt.Ctime,
+		Utime:    cmt.Utime,
+	}
+	if cmt.RootId.Valid {
+		res.RootComment = &domain.Comment{Id: cmt.RootId.Int64}
+	}
+	if cmt.ParentId.Valid {
+		res.ParentComment = &domain.Comment{Id: cmt.ParentId.Int64}
+	}
+	return res
+}
@@ -6,6 +6,7 @@ import (
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
+	"webook/internal/domain"
 )
 
 type GormArticleDAO struct {
@@ -25,6 +26,47 @@ func (g *GormArticleDAO) GetPubByArtId(ctx context.Context, artId int64) (Articl
 	return art, nil
 }
 
+// GetPubByArtIds 批量获取线上库文章，不过滤状态，由上层决定怎么展示
+func (g *GormArticleDAO) GetPubByArtIds(ctx context.Context, artIds []int64) ([]ArticlePublish, error) {
+	var arts []ArticlePublish
+	err := g.db.WithContext(ctx).Model(&ArticlePublish{}).
+		Where("id in ?", artIds).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GormArticleDAO) GetPubIdsByAuthor(ctx context.Context, uid int64) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&ArticlePublish{}).
+		Where("author_id = ?", uid).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+func (g *GormArticleDAO) GetPubBriefsByAuthor(ctx context.Context, uid int64) ([]ArticlePublish, error) {
+	var arts []ArticlePublish
+	err := g.db.WithContext(ctx).Model(&ArticlePublish{}).
+		Select("id", "title", "author_id", "status", "ctime", "utime").
+		Where("author_id = ?", uid).
+		Find(&arts).Error
+	return arts, err
+}
+
+func (g *GormArticleDAO) ListPub(ctx context.Context, start int64, lastUtime int64, lastId int64, limit int) ([]ArticlePublish, error) {
+	var arts []ArticlePublish
+	db := g.db.WithContext(ctx).Model(&ArticlePublish{}).
+		Select("id", "title", "author_id", "status", "ctime", "utime").
+		Where("utime > ? and status = ?", start, domain.ArticleStatusPublished.ToUint8())
+	if lastId > 0 {
+		// 游标翻页，扫描的过程中有文章更新也不会重复或者漏掉没变的文章
+		db = db.Where("utime < ? or (utime = ? and id < ?)", lastUtime, lastUtime, lastId)
+	}
+	err := db.Order("utime desc, id desc").
+		Limit(limit).
+		Find(&arts).Error
+	return arts, err
+}
+
 // GetByAuthor 根据作者ID获取文章列表
 func (g *GormArticleDAO) GetByAuthor(ctx context.Context, limit, offset int, uid int64) ([]Article, error) {
 	var arts []Article
@@ -0,0 +1,57 @@
+package dao
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGormArticleDAO_ListPub(t *testing.T) {
+	testCases := []struct {
+		name      string
+		mock      func(mock sqlmock.Sqlmock)
+		lastUtime int64
+		lastId    int64
+		wantIds   []int64
+	}{
+		{
+			name: "第一批",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id`,`title`,`author_id`,`status`,`ctime`,`utime` FROM `article_publishes` "+
+					"WHERE utime > \\? and status = \\? ORDER BY utime desc, id desc LIMIT 2").
+					WithArgs(int64(100), uint8(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(3, 300).AddRow(2, 200))
+			},
+			wantIds: []int64{3, 2},
+		},
+		{
+			name: "从上一批的最后一篇后面开始",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT `id`,`title`,`author_id`,`status`,`ctime`,`utime` FROM `article_publishes` "+
+					"WHERE \\(utime > \\? and status = \\?\\) AND \\(utime < \\? or \\(utime = \\? and id < \\?\\)\\) "+
+					"ORDER BY utime desc, id desc LIMIT 2").
+					WithArgs(int64(100), uint8(2), int64(200), int64(200), int64(2)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "utime"}).AddRow(1, 200))
+			},
+			lastUtime: 200,
+			lastId:    2,
+			wantIds:   []int64{1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormArticleDAO(db)
+			arts, err := dao.ListPub(context.Background(), 100, tc.lastUtime, tc.lastId, 2)
+			assert.NoError(t, err)
+			ids := make([]int64, 0, len(arts))
+			for _, art := range arts {
+				ids = append(ids, art.Id)
+			}
+			assert.Equal(t, tc.wantIds, ids)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
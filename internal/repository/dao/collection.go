@@ -0,0 +1,148 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"time"
+)
+
+var ErrCollectionNoPermission = errors.New("收藏夹不存在或者不属于该用户")
+
+type CollectionDAO interface {
+	Insert(ctx context.Context, c Collection) (int64, error)
+	// Update 修改名字和可见性
+	Update(ctx context.Context, c Collection) error
+	// Delete 删除收藏夹和里面的收藏，返回因此收藏数减一的那些资源
+	Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error)
+	FindById(ctx context.Context, id int64) (Collection, error)
+	FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error)
+	FindItems(ctx context.Context, cid int64, uid int64, offset int, limit int) ([]UserCollectionBiz, error)
+}
+
+type GormCollectionDAO struct {
+	db *gorm.DB
+}
+
+func NewGormCollectionDAO(db *gorm.DB) CollectionDAO {
+	return &GormCollectionDAO{
+		db: db,
+	}
+}
+
+func (g *GormCollectionDAO) Insert(ctx context.Context, c Collection) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Create(&c).Error
+	return c.Id, err
+}
+
+func (g *GormCollectionDAO) Update(ctx context.Context, c Collection) error {
+	res := g.db.WithContext(ctx).Model(&Collection{}).
+		Where("id = ? and uid = ?", c.Id, c.Uid).
+		Updates(map[string]interface{}{
+			"name":       c.Name,
+			"visibility": c.Visibility,
+			"utime":      time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrCollectionNoPermission
+	}
+	return nil
+}
+
+func (g *GormCollectionDAO) Delete(ctx context.Context, id int64, uid int64) ([]UserCollectionBiz, error) {
+	now := time.Now().UnixMilli()
+	var decrs []UserCollectionBiz
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("id = ? and uid = ?", id, uid).Delete(&Collection{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrCollectionNoPermission
+		}
+		var items []UserCollectionBiz
+		err := tx.Where("cid = ? and uid = ?", id, uid).Find(&items).Error
+		if err != nil {
+			return err
+		}
+		err = tx.Where("cid = ? and uid = ?", id, uid).Delete(&UserCollectionBiz{}).Error
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			var cnt int64
+			err = tx.Model(&UserCollectionBiz{}).
+				Where("uid = ? and biz = ? and biz_id = ?", uid, item.Biz, item.BizId).
+				Count(&cnt).Error
+			if err != nil {
+				return err
+			}
+			if cnt > 0 {
+				// 还在别的收藏夹里面
+				continue
+			}
+			err = tx.Model(&Interactive{}).Where("biz_id = ? and biz = ? ", item.BizId, item.Biz).
+				Updates(map[string]interface{}{
+					"collect_cnt": gorm.Expr("collect_cnt - 1"),
+					"utime":       now,
+				}).Error
+			if err != nil {
+				return err
+			}
+			err = incrDaily(tx, item.Biz, item.BizId, "collect_cnt", -1, now)
+			if err != nil {
+				return err
+			}
+			decrs = append(decrs, item)
+		}
+		return nil
+	})
+	return decrs, err
+}
+
+func (g *GormCollectionDAO) FindById(ctx context.Context, id int64) (Collection, error) {
+	var res Collection
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (g *GormCollectionDAO) FindByUid(ctx context.Context, uid int64, onlyPublic bool) ([]Collection, error) {
+	var res []Collection
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if onlyPublic {
+		db = db.Where("visibility = ?", CollectionVisibilityPublic)
+	}
+	err := db.Order("id asc").Find(&res).Error
+	return res, err
+}
+
+func (g *GormCollectionDAO) FindItems(ctx context.Context, cid int64, uid int64, offset int, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	err := g.db.WithContext(ctx).
+		Where("cid = ? and uid = ?", cid, uid).
+		Order("utime desc").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+const (
+	CollectionVisibilityPrivate uint8 = 0
+	CollectionVisibilityPublic  uint8 = 1
+)
+
+// Collection 收藏夹
+type Collection struct {
+	Id         int64  `gorm:"primaryKey,autoIncrement"`
+	Uid        int64  `gorm:"index"`
+	Name       string `gorm:"type:varchar(128)"`
+	Visibility uint8
+	Ctime      int64
+	Utime      int64
+}
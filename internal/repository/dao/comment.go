@@ -0,0 +1,246 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type CommentDAO interface {
+	Insert(ctx context.Context, c Comment) (int64, error)
+	// Delete 删除评论，根评论会连同下面的回复一起删除，返回删除的条数
+	Delete(ctx context.Context, c Comment) (int64, error)
+	FindById(ctx context.Context, id int64) (Comment, error)
+	FindByIds(ctx context.Context, ids []int64) ([]Comment, error)
+	// FindByBiz 根评论 按id倒序 最新的在前
+	FindByBiz(ctx context.Context, biz string, bizId int64, lastId int64, limit int) ([]Comment, error)
+	// FindHotByBiz 根评论 按点赞数倒序 点赞数相同按id倒序
+	FindHotByBiz(ctx context.Context, biz string, bizId int64, lastLikeCnt int64, lastId int64, limit int) ([]Comment, error)
+	// FindRepliesByRid 某条根评论下的回复 按id正序
+	FindRepliesByRid(ctx context.Context, rid int64, lastId int64, limit int) ([]Comment, error)
+	InsertLikeInfo(ctx context.Context, id int64, uid int64) error
+	DeleteLikeInfo(ctx context.Context, id int64, uid int64) error
+}
+
+type GormCommentDAO struct {
+	db *gorm.DB
+}
+
+func NewGormCommentDAO(db *gorm.DB) CommentDAO {
+	return &GormCommentDAO{
+		db: db,
+	}
+}
+
+func (g *GormCommentDAO) Insert(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	c.Ctime = now
+	c.Utime = now
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Create(&c).Error
+		if err != nil {
+			return err
+		}
+		if c.RootId.Valid {
+			// 回复，根评论的回复数加一
+			err = tx.Model(&Comment{}).Where("id = ?", c.RootId.Int64).
+				Updates(map[string]interface{}{
+					"reply_cnt": gorm.Expr("reply_cnt + 1"),
+					"utime":     now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		// Upsert commentCnt
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"comment_cnt": gorm.Expr("comment_cnt + 1"),
+				"utime":       now,
+			}),
+		}).Create(&Interactive{
+			BizId:      c.BizId,
+			Biz:        c.Biz,
+			CommentCnt: 1,
+			Ctime:      now,
+			Utime:      now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return incrDaily(tx, c.Biz, c.BizId, "comment_cnt", 1, now)
+	})
+	return c.Id, err
+}
+
+func (g *GormCommentDAO) Delete(ctx context.Context, c Comment) (int64, error) {
+	now := time.Now().UnixMilli()
+	var cnt int64
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var res *gorm.DB
+		if c.RootId.Valid {
+			res = tx.Where("id = ?", c.Id).Delete(&Comment{})
+		} else {
+			// 根评论 整个楼一起删掉
+			res = tx.Where("id = ? or root_id = ?", c.Id, c.Id).Delete(&Comment{})
+		}
+		if res.Error != nil {
+			return res.Error
+		}
+		cnt = res.RowsAffected
+		if cnt == 0 {
+			return nil
+		}
+		if c.RootId.Valid {
+			err := tx.Model(&Comment{}).Where("id = ?", c.RootId.Int64).
+				Updates(map[string]interface{}{
+					"reply_cnt": gorm.Expr("reply_cnt - ?", cnt),
+					"utime":     now,
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		err := tx.Model(&Interactive{}).Where("biz_id = ? and biz = ?", c.BizId, c.Biz).
+			Updates(map[string]interface{}{
+				"comment_cnt": gorm.Expr("comment_cnt - ?", cnt),
+				"utime":       now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return incrDaily(tx, c.Biz, c.BizId, "comment_cnt", -cnt, now)
+	})
+	return cnt, err
+}
+
+func (g *GormCommentDAO) FindById(ctx context.Context, id int64) (Comment, error) {
+	var res Comment
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDAO) FindByIds(ctx context.Context, ids []int64) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).Where("id in ?", ids).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDAO) FindByBiz(ctx context.Context, biz string, bizId int64, lastId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	db := g.db.WithContext(ctx).
+		Where("biz = ? and biz_id = ? and root_id is null", biz, bizId)
+	if lastId > 0 {
+		db = db.Where("id < ?", lastId)
+	}
+	err := db.Order("id desc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDAO) FindHotByBiz(ctx context.Context, biz string, bizId int64, lastLikeCnt int64, lastId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	db := g.db.WithContext(ctx).
+		Where("biz = ? and biz_id = ? and root_id is null", biz, bizId)
+	if lastId > 0 {
+		// (like_cnt, id) 组成游标
+		db = db.Where("like_cnt < ? or (like_cnt = ? and id < ?)", lastLikeCnt, lastLikeCnt, lastId)
+	}
+	err := db.Order("like_cnt desc, id desc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+func (g *GormCommentDAO) FindRepliesByRid(ctx context.Context, rid int64, lastId int64, limit int) ([]Comment, error) {
+	var res []Comment
+	err := g.db.WithContext(ctx).
+		Where("root_id = ? and id > ?", rid, lastId).
+		Order("id asc").Limit(limit).Find(&res).Error
+	return res, err
+}
+
+// InsertLikeInfo 评论点赞 复用 UserLikeBiz biz 固定为 comment
+func (g *GormCommentDAO) InsertLikeInfo(ctx context.Context, id int64, uid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var like UserLikeBiz
+		err := tx.Where("uid = ? and biz_id = ? and biz = ?", uid, id, CommentBiz).
+			First(&like).Error
+		switch err {
+		case nil:
+			if like.Status == 1 {
+				// 已经点过赞了
+				return nil
+			}
+		case ErrRecordNotFound:
+		default:
+			return err
+		}
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"status": 1,
+				"utime":  now,
+			}),
+		}).Create(&UserLikeBiz{
+			Uid:    uid,
+			BizId:  id,
+			Biz:    CommentBiz,
+			Status: 1,
+			Ctime:  now,
+			Utime:  now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Model(&Comment{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"like_cnt": gorm.Expr("like_cnt + 1"),
+				"utime":    now,
+			}).Error
+	})
+}
+
+func (g *GormCommentDAO) DeleteLikeInfo(ctx context.Context, id int64, uid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&UserLikeBiz{}).
+			Where("uid = ? and biz_id = ? and biz = ? and status = ?", uid, id, CommentBiz, 1).
+			Updates(map[string]interface{}{
+				"status": 0,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			// 本来就没有点赞
+			return nil
+		}
+		return tx.Model(&Comment{}).Where("id = ?", id).
+			Updates(map[string]interface{}{
+				"like_cnt": gorm.Expr("like_cnt - 1"),
+				"utime":    now,
+			}).Error
+	})
+}
+
+// CommentBiz 评论自身作为被点赞的对象
+const CommentBiz = "comment"
+
+type Comment struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 评论者
+	Uid int64
+	// 被评论的对象 <biz,bizId> 查询根评论用到联合索引
+	Biz   string `gorm:"type:varchar(128);index:biz_type_id"`
+	BizId int64  `gorm:"index:biz_type_id"`
+	// 根评论id 为null表示自己是根评论
+	RootId sql.NullInt64 `gorm:"index"`
+	// 回复的评论id
+	ParentId sql.NullInt64 `gorm:"index"`
+	Content  string        `gorm:"type:text"`
+	LikeCnt  int64
+	ReplyCnt int64
+	Ctime    int64
+	Utime    int64
+}
@@ -0,0 +1,236 @@
+package dao
+
+import (
+	"context"
+	"database/sql"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestGormCommentDAO_Insert(t *testing.T) {
+	// 评论数和当天的增量
+	commentCnt := func(mock sqlmock.Sqlmock) {
+		mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE `comment_cnt`=comment_cnt \\+ 1").
+			WithArgs(anyArgs(11)...).WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec("INSERT INTO `interactive_dailies` .* ON DUPLICATE KEY UPDATE").
+			WithArgs(anyArgs(11)...).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		cmt     Comment
+		wantId  int64
+		wantErr error
+	}{
+		{
+			name: "根评论",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comments`").WithArgs(anyArgs(10)...).
+					WillReturnResult(sqlmock.NewResult(10, 1))
+				commentCnt(mock)
+				mock.ExpectCommit()
+			},
+			cmt:    Comment{Uid: 123, Biz: "article", BizId: 1, Content: "评论"},
+			wantId: 10,
+		},
+		{
+			name: "回复，根评论的回复数加一",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `comments`").WithArgs(anyArgs(10)...).
+					WillReturnResult(sqlmock.NewResult(11, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=reply_cnt \\+ 1").
+					WithArgs(sqlmock.AnyArg(), int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+				commentCnt(mock)
+				mock.ExpectCommit()
+			},
+			cmt: Comment{
+				Uid: 123, Biz: "article", BizId: 1, Content: "回复",
+				RootId:   sql.NullInt64{Int64: 5, Valid: true},
+				ParentId: sql.NullInt64{Int64: 7, Valid: true},
+			},
+			wantId: 11,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormCommentDAO(db)
+			id, err := dao.Insert(context.Background(), tc.cmt)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_Delete(t *testing.T) {
+	// 评论数减掉删除的条数，当天的增量也减掉
+	commentCnt := func(mock sqlmock.Sqlmock, cnt int64) {
+		mock.ExpectExec("UPDATE `interactives` SET `comment_cnt`=comment_cnt - \\?").
+			WithArgs(cnt, sqlmock.AnyArg(), int64(1), "article").WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec("INSERT INTO `interactive_dailies` .* ON DUPLICATE KEY UPDATE").
+			WithArgs(anyArgs(11)...).WillReturnResult(sqlmock.NewResult(1, 1))
+	}
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		cmt     Comment
+		wantCnt int64
+		wantErr error
+	}{
+		{
+			name: "删除根评论，整个楼一起删",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `comments` WHERE id = \\? or root_id = \\?").
+					WithArgs(int64(5), int64(5)).WillReturnResult(sqlmock.NewResult(0, 3))
+				commentCnt(mock, 3)
+				mock.ExpectCommit()
+			},
+			cmt:     Comment{Id: 5, Biz: "article", BizId: 1},
+			wantCnt: 3,
+		},
+		{
+			name: "删除回复，根评论的回复数减一",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `comments` WHERE id = \\?").
+					WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("UPDATE `comments` SET `reply_cnt`=reply_cnt - \\?").
+					WithArgs(int64(1), sqlmock.AnyArg(), int64(5)).WillReturnResult(sqlmock.NewResult(0, 1))
+				commentCnt(mock, 1)
+				mock.ExpectCommit()
+			},
+			cmt: Comment{
+				Id: 7, Biz: "article", BizId: 1,
+				RootId: sql.NullInt64{Int64: 5, Valid: true},
+			},
+			wantCnt: 1,
+		},
+		{
+			name: "已经删掉了，计数不变",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM `comments` WHERE id = \\?").
+					WithArgs(int64(7)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+			cmt: Comment{
+				Id: 7, Biz: "article", BizId: 1,
+				RootId: sql.NullInt64{Int64: 5, Valid: true},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormCommentDAO(db)
+			cnt, err := dao.Delete(context.Background(), tc.cmt)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantCnt, cnt)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_FindByBiz(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mock   func(mock sqlmock.Sqlmock)
+		lastId int64
+	}{
+		{
+			name: "第一页",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE biz = \\? and biz_id = \\? and root_id is null "+
+					"ORDER BY id desc LIMIT 2").
+					WithArgs("article", int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9).AddRow(8))
+			},
+		},
+		{
+			name: "按id游标翻页",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(biz = \\? and biz_id = \\? and root_id is null\\) "+
+					"AND id < \\? ORDER BY id desc LIMIT 2").
+					WithArgs("article", int64(1), int64(10)).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9).AddRow(8))
+			},
+			lastId: 10,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormCommentDAO(db)
+			res, err := dao.FindByBiz(context.Background(), "article", 1, tc.lastId, 2)
+			require.NoError(t, err)
+			assert.Equal(t, []Comment{{Id: 9}, {Id: 8}}, res)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_FindHotByBiz(t *testing.T) {
+	testCases := []struct {
+		name        string
+		mock        func(mock sqlmock.Sqlmock)
+		lastLikeCnt int64
+		lastId      int64
+	}{
+		{
+			name: "第一页",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE biz = \\? and biz_id = \\? and root_id is null "+
+					"ORDER BY like_cnt desc, id desc LIMIT 2").
+					WithArgs("article", int64(1)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "like_cnt"}).AddRow(3, 10).AddRow(9, 5))
+			},
+		},
+		{
+			name: "按点赞数和id游标翻页",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT \\* FROM `comments` WHERE \\(biz = \\? and biz_id = \\? and root_id is null\\) "+
+					"AND \\(like_cnt < \\? or \\(like_cnt = \\? and id < \\?\\)\\) ORDER BY like_cnt desc, id desc LIMIT 2").
+					WithArgs("article", int64(1), int64(20), int64(20), int64(7)).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "like_cnt"}).AddRow(3, 10).AddRow(9, 5))
+			},
+			lastLikeCnt: 20,
+			lastId:      7,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormCommentDAO(db)
+			res, err := dao.FindHotByBiz(context.Background(), "article", 1, tc.lastLikeCnt, tc.lastId, 2)
+			require.NoError(t, err)
+			assert.Equal(t, []Comment{{Id: 3, LikeCnt: 10}, {Id: 9, LikeCnt: 5}}, res)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormCommentDAO_FindRepliesByRid(t *testing.T) {
+	db, mock := newMockDB(t)
+	// 回复按时间正序
+	mock.ExpectQuery("SELECT \\* FROM `comments` WHERE root_id = \\? and id > \\? ORDER BY id asc LIMIT 2").
+		WithArgs(int64(5), int64(6)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "root_id"}).AddRow(7, 5).AddRow(8, 5))
+	dao := NewGormCommentDAO(db)
+	res, err := dao.FindRepliesByRid(context.Background(), 5, 6, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []Comment{
+		{Id: 7, RootId: sql.NullInt64{Int64: 5, Valid: true}},
+		{Id: 8, RootId: sql.NullInt64{Int64: 5, Valid: true}},
+	}, res)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
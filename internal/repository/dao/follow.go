@@ -0,0 +1,196 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type FollowDAO interface {
+	// Follow 返回 false 表示本来就关注了
+	Follow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// CancelFollow 返回 false 表示本来就没有关注
+	CancelFollow(ctx context.Context, follower int64, followee int64) (bool, error)
+	// FindFollowers followee 的粉丝，按 id 倒序，cursor 为 0 的时候从头开始
+	FindFollowers(ctx context.Context, followee int64, cursor int64, limit int) ([]FollowRelation, error)
+	FindFollowees(ctx context.Context, follower int64, cursor int64, limit int) ([]FollowRelation, error)
+	// FollowedIn followees 里面 follower 关注了的
+	FollowedIn(ctx context.Context, follower int64, followees []int64) ([]int64, error)
+	// FollowersIn followers 里面关注了 followee 的
+	FollowersIn(ctx context.Context, followee int64, followers []int64) ([]int64, error)
+	// GetStatistic 没有记录的时候返回全是 0 的统计
+	GetStatistic(ctx context.Context, uid int64) (FollowStatistic, error)
+	// CountNewFollowers [start, end) 之间关注了 followee 并且现在还没取消的粉丝数
+	CountNewFollowers(ctx context.Context, followee int64, start int64, end int64) (int64, error)
+}
+
+type GormFollowDAO struct {
+	db *gorm.DB
+}
+
+func NewGormFollowDAO(db *gorm.DB) FollowDAO {
+	return &GormFollowDAO{
+		db: db,
+	}
+}
+
+func (g *GormFollowDAO) Follow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	var incr bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 取消过的恢复，没有关注过的插入，已经关注了的什么都不做
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? and followee = ? and status = ?", follower, followee, 0).
+			Updates(map[string]interface{}{
+				"status": 1,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&FollowRelation{
+				Follower: follower,
+				Followee: followee,
+				Status:   1,
+				Ctime:    now,
+				Utime:    now,
+			})
+			if res.Error != nil || res.RowsAffected == 0 {
+				return res.Error
+			}
+		}
+		incr = true
+		return g.incrStatistic(tx, follower, followee, 1, now)
+	})
+	return incr, err
+}
+
+func (g *GormFollowDAO) CancelFollow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	var decr bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&FollowRelation{}).
+			Where("follower = ? and followee = ? and status = ?", follower, followee, 1).
+			Updates(map[string]interface{}{
+				"status": 0,
+				"utime":  now,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		decr = true
+		return g.incrStatistic(tx, follower, followee, -1, now)
+	})
+	return decr, err
+}
+
+// incrStatistic follower 的关注数和 followee 的粉丝数一起变
+func (g *GormFollowDAO) incrStatistic(tx *gorm.DB, follower int64, followee int64, delta int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"followees": gorm.Expr("followees + ?", delta),
+			"utime":     now,
+		}),
+	}).Create(&FollowStatistic{
+		Uid:       follower,
+		Followees: delta,
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"followers": gorm.Expr("followers + ?", delta),
+			"utime":     now,
+		}),
+	}).Create(&FollowStatistic{
+		Uid:       followee,
+		Followers: delta,
+		Ctime:     now,
+		Utime:     now,
+	}).Error
+}
+
+func (g *GormFollowDAO) FindFollowers(ctx context.Context, followee int64, cursor int64, limit int) ([]FollowRelation, error) {
+	return g.page(g.db.WithContext(ctx).Where("followee = ? and status = ?", followee, 1), cursor, limit)
+}
+
+func (g *GormFollowDAO) FindFollowees(ctx context.Context, follower int64, cursor int64, limit int) ([]FollowRelation, error) {
+	return g.page(g.db.WithContext(ctx).Where("follower = ? and status = ?", follower, 1), cursor, limit)
+}
+
+// page 用 id 做游标，粉丝再多翻到后面也不会变慢
+func (g *GormFollowDAO) page(db *gorm.DB, cursor int64, limit int) ([]FollowRelation, error) {
+	if cursor > 0 {
+		db = db.Where("id < ?", cursor)
+	}
+	var rs []FollowRelation
+	err := db.Order("id desc").Limit(limit).Find(&rs).Error
+	return rs, err
+}
+
+func (g *GormFollowDAO) FollowedIn(ctx context.Context, follower int64, followees []int64) ([]int64, error) {
+	var ids []int64
+	if len(followees) == 0 {
+		return ids, nil
+	}
+	err := g.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("follower = ? and followee in ? and status = ?", follower, followees, 1).
+		Pluck("followee", &ids).Error
+	return ids, err
+}
+
+func (g *GormFollowDAO) FollowersIn(ctx context.Context, followee int64, followers []int64) ([]int64, error) {
+	var ids []int64
+	if len(followers) == 0 {
+		return ids, nil
+	}
+	// 每个 follower 都能直接命中 (follower, followee) 唯一索引，不用扫 followee 所有的粉丝
+	err := g.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("follower in ? and followee = ? and status = ?", followers, followee, 1).
+		Pluck("follower", &ids).Error
+	return ids, err
+}
+
+func (g *GormFollowDAO) GetStatistic(ctx context.Context, uid int64) (FollowStatistic, error) {
+	var s FollowStatistic
+	err := g.db.WithContext(ctx).Where("uid = ?", uid).First(&s).Error
+	if err == ErrRecordNotFound {
+		return FollowStatistic{Uid: uid}, nil
+	}
+	return s, err
+}
+
+func (g *GormFollowDAO) CountNewFollowers(ctx context.Context, followee int64, start int64, end int64) (int64, error) {
+	var cnt int64
+	// 再关注的时候会更新 utime，所以 utime 就是最近一次关注的时间
+	err := g.db.WithContext(ctx).Model(&FollowRelation{}).
+		Where("followee = ? and status = ? and utime >= ? and utime < ?", followee, 1, start, end).
+		Count(&cnt).Error
+	return cnt, err
+}
+
+// FollowRelation 取消关注只改状态，再关注的时候复用这一行
+type FollowRelation struct {
+	Id       int64 `gorm:"primaryKey,autoIncrement"`
+	Follower int64 `gorm:"uniqueIndex:follower_followee;index:follower_status"`
+	Followee int64 `gorm:"uniqueIndex:follower_followee;index:followee_status"`
+	// 1 关注中，0 已取消
+	Status uint8 `gorm:"index:follower_status;index:followee_status"`
+	Ctime  int64
+	Utime  int64
+}
+
+// FollowStatistic 粉丝数和关注数单独存，大 V 有几百万粉丝，不能每次 count
+type FollowStatistic struct {
+	Id        int64 `gorm:"primaryKey,autoIncrement"`
+	Uid       int64 `gorm:"uniqueIndex"`
+	Followers int64
+	Followees int64
+	Ctime     int64
+	Utime     int64
+}
@@ -0,0 +1,139 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type HistoryDAO interface {
+	// Record 批量记录阅读，已经有的只更新阅读时间，不动进度
+	Record(ctx context.Context, hs []ReadHistory) error
+	// UpdateProgress 没有记录的时候顺便插入
+	UpdateProgress(ctx context.Context, uid int64, artId int64, progress uint8) error
+	// List 按最近阅读时间倒序
+	List(ctx context.Context, uid int64, offset int, limit int) ([]ReadHistory, error)
+	// ListUnfinished 读了一部分，进度小于 maxProgress，并且 since 之后读过的
+	ListUnfinished(ctx context.Context, uid int64, maxProgress uint8, since int64, limit int) ([]ReadHistory, error)
+	// Delete artIds 为空的时候清空
+	Delete(ctx context.Context, uid int64, artIds []int64) error
+	// FindPaused uids 里面暂停了阅读历史的
+	FindPaused(ctx context.Context, uids []int64) ([]int64, error)
+	SetPaused(ctx context.Context, uid int64, paused bool) error
+}
+
+type GormHistoryDAO struct {
+	db *gorm.DB
+}
+
+func NewGormHistoryDAO(db *gorm.DB) HistoryDAO {
+	return &GormHistoryDAO{
+		db: db,
+	}
+}
+
+func (g *GormHistoryDAO) Record(ctx context.Context, hs []ReadHistory) error {
+	if len(hs) == 0 {
+		return nil
+	}
+	now := time.Now().UnixMilli()
+	for i := range hs {
+		hs[i].Ctime = now
+		hs[i].Utime = now
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.AssignmentColumns([]string{"utime"}),
+	}).Create(&hs).Error
+}
+
+func (g *GormHistoryDAO) UpdateProgress(ctx context.Context, uid int64, artId int64, progress uint8) error {
+	now := time.Now().UnixMilli()
+	// 往回翻也是有效的进度，直接覆盖
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"progress": progress,
+			"utime":    now,
+		}),
+	}).Create(&ReadHistory{
+		Uid:      uid,
+		ArtId:    artId,
+		Progress: progress,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+}
+
+func (g *GormHistoryDAO) List(ctx context.Context, uid int64, offset int, limit int) ([]ReadHistory, error) {
+	var res []ReadHistory
+	err := g.db.WithContext(ctx).
+		Where("uid = ?", uid).
+		Order("utime desc").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GormHistoryDAO) ListUnfinished(ctx context.Context, uid int64, maxProgress uint8, since int64, limit int) ([]ReadHistory, error) {
+	var res []ReadHistory
+	err := g.db.WithContext(ctx).
+		Where("uid = ? and utime > ? and progress > ? and progress < ?", uid, since, 0, maxProgress).
+		Order("utime desc").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GormHistoryDAO) Delete(ctx context.Context, uid int64, artIds []int64) error {
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if len(artIds) > 0 {
+		db = db.Where("art_id in ?", artIds)
+	}
+	return db.Delete(&ReadHistory{}).Error
+}
+
+func (g *GormHistoryDAO) FindPaused(ctx context.Context, uids []int64) ([]int64, error) {
+	var res []int64
+	if len(uids) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Model(&ReadHistorySetting{}).
+		Where("uid in ? and paused = ?", uids, true).
+		Pluck("uid", &res).Error
+	return res, err
+}
+
+func (g *GormHistoryDAO) SetPaused(ctx context.Context, uid int64, paused bool) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"paused": paused,
+			"utime":  now,
+		}),
+	}).Create(&ReadHistorySetting{
+		Uid:    uid,
+		Paused: paused,
+		Ctime:  now,
+		Utime:  now,
+	}).Error
+}
+
+// ReadHistory 一个用户一篇文章一行，再读只更新 utime
+type ReadHistory struct {
+	Id    int64 `gorm:"primaryKey,autoIncrement"`
+	Uid   int64 `gorm:"uniqueIndex:uid_art;index:uid_utime"`
+	ArtId int64 `gorm:"uniqueIndex:uid_art"`
+	// 0 到 100
+	Progress uint8
+	Ctime    int64
+	Utime    int64 `gorm:"index:uid_utime"`
+}
+
+// ReadHistorySetting 暂停以后不再记录，已经有的历史不删
+type ReadHistorySetting struct {
+	Id     int64 `gorm:"primaryKey,autoIncrement"`
+	Uid    int64 `gorm:"uniqueIndex"`
+	Paused bool
+	Ctime  int64
+	Utime  int64
+}
@@ -10,11 +10,64 @@ import (
 )
 
 func InitTables(db *gorm.DB) error {
-	return db.AutoMigrate(&User{},
+	// 收藏改成可以收藏到多个收藏夹，老的 <uid,biz,bizId> 唯一索引要删掉
+	if db.Migrator().HasIndex(&UserCollectionBiz{}, "uid_biz_type_id") {
+		err := db.Migrator().DropIndex(&UserCollectionBiz{}, "uid_biz_type_id")
+		if err != nil {
+			return err
+		}
+	}
+	err := db.AutoMigrate(&User{},
 		&Article{},
 		&UserLikeBiz{},
 		&UserCollectionBiz{},
+		&Interactive{},
+		&InteractiveDaily{},
+		&Comment{},
+		&ShareLink{},
+		&ShareChannel{},
+		&ShareRead{},
+		&Collection{},
+		&UserReactionBiz{},
+		&InteractiveReaction{},
+		&Job{},
+		&FollowRelation{},
+		&FollowStatistic{},
+		&Notification{},
+		&NotificationActor{},
+		&NotificationOptOut{},
+		&ArticleTag{},
+		&ReadHistory{},
+		&ReadHistorySetting{},
+		&Migration{},
 	)
+	if err != nil {
+		return err
+	}
+	// 表情是后来加的，把原来的点赞迁移过去
+	return runMigration(db, "likes_to_reactions", migrateLikesToReactions)
+}
+
+// Migration 已经完成的数据迁移，迁移和记录在同一个事务里面，失败了下次启动再跑
+type Migration struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Name  string `gorm:"type:varchar(128);uniqueIndex"`
+	Ctime int64
+}
+
+func runMigration(db *gorm.DB, name string, fn func(tx *gorm.DB) error) error {
+	var cnt int64
+	err := db.Model(&Migration{}).Where("name = ?", name).Count(&cnt).Error
+	if err != nil || cnt > 0 {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		err := fn(tx)
+		if err != nil {
+			return err
+		}
+		return tx.Create(&Migration{Name: name, Ctime: time.Now().UnixMilli()}).Error
+	})
 }
 
 func InitCollection(mdb *mongo.Database) error {
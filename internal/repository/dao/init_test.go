@@ -0,0 +1,66 @@
+package dao
+
+import (
+	"errors"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestRunMigration(t *testing.T) {
+	done := func(mock sqlmock.Sqlmock, cnt int64) {
+		mock.ExpectQuery("SELECT count\\(\\*\\) FROM `migrations`").WithArgs("likes_to_reactions").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(cnt))
+	}
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		fnErr   error
+		wantRun bool
+		wantErr error
+	}{
+		{
+			name: "迁移成功之后记录下来",
+			mock: func(mock sqlmock.Sqlmock) {
+				done(mock, 0)
+				mock.ExpectBegin()
+				mock.ExpectExec("INSERT INTO `migrations`").WithArgs(anyArgs(2)...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantRun: true,
+		},
+		{
+			name: "已经迁移过了不再跑",
+			mock: func(mock sqlmock.Sqlmock) {
+				done(mock, 1)
+			},
+		},
+		{
+			name: "迁移失败不记录，下次启动再跑",
+			mock: func(mock sqlmock.Sqlmock) {
+				done(mock, 0)
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fnErr:   errors.New("迁移失败"),
+			wantRun: true,
+			wantErr: errors.New("迁移失败"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			var run bool
+			err := runMigration(db, "likes_to_reactions", func(tx *gorm.DB) error {
+				run = true
+				return tc.fnErr
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRun, run)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
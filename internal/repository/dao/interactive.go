@@ -2,19 +2,48 @@ package dao
 
 import (
 	"context"
+	"fmt"
+	"github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"sort"
 	"time"
 )
 
 type InteractiveDAO interface {
 	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
-	InsertLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error
-	DeleteLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error
-	InsertCollectionInfo(ctx context.Context, cb UserCollectionBiz) error
+	// BatchIncrReadCnt 批量增加阅读数，ReadCnt 是增量
+	BatchIncrReadCnt(ctx context.Context, intrs []Interactive) error
+	// BatchSetReaderCnt 批量更新独立读者数，只会变大
+	BatchSetReaderCnt(ctx context.Context, intrs []Interactive) error
+	// FindDaily 按天汇总的增量，bizIds 有多个的时候是它们的和
+	FindDaily(ctx context.Context, biz string, bizIds []int64, startDay string, endDay string) ([]InteractiveDaily, error)
+	// SumByBizIds 一批资源的计数之和
+	SumByBizIds(ctx context.Context, biz string, bizIds []int64) (Interactive, error)
+	// TopByBizIds 一批资源里面 field 最大的 limit 个，field 只能是 read_cnt 或者 like_cnt
+	TopByBizIds(ctx context.Context, biz string, bizIds []int64, field string, limit int) ([]Interactive, error)
+	// InsertReaction 点表情，默认表情同时记点赞，返回 false 表示已经点过了
+	InsertReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) (bool, error)
+	// DeleteReaction 取消表情，返回 false 表示本来就没有点
+	DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) (bool, error)
+	// GetReactions 用户对这个资源点过的表情
+	GetReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]UserReactionBiz, error)
+	// GetReactionCnts 一批资源每种表情的数量，数量为 0 的不返回
+	GetReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]InteractiveReaction, error)
+	InsertCollectionInfo(ctx context.Context, cb UserCollectionBiz) (bool, error)
+	DeleteCollectionInfo(ctx context.Context, biz string, bizId int64, cid int64, uid int64) (bool, error)
+	MoveCollectionInfo(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error
 	GetLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserLikeBiz, error)
 	GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error)
 	GetInteractiveInfo(ctx context.Context, biz string, bizId int64) (Interactive, error)
+	// GetInteractiveInfos 批量查询计数，uid 大于 0 的时候顺便查出是否点赞、收藏
+	// 没有互动记录的 bizId 不会出现在结果里面
+	GetInteractiveInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserInteractive, error)
+	// FindLikesByUid 用户点赞过的资源，按照点赞时间倒序
+	FindLikesByUid(ctx context.Context, biz string, uid int64, offset int, limit int) ([]UserLikeBiz, error)
+	// FindCollectionsByUid 用户收藏过的资源，收藏到多个收藏夹的只算一条，按照最近收藏时间倒序
+	// onlyPublic 为 true 的时候只看公开收藏夹里面的
+	FindCollectionsByUid(ctx context.Context, biz string, uid int64, onlyPublic bool, offset int, limit int) ([]UserCollectionBiz, error)
 }
 
 type GormInteractiveDAO struct {
@@ -29,6 +58,82 @@ func (g *GormInteractiveDAO) GetInteractiveInfo(ctx context.Context, biz string,
 	return res, err
 }
 
+func (g *GormInteractiveDAO) SumByBizIds(ctx context.Context, biz string, bizIds []int64) (Interactive, error) {
+	var res Interactive
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Model(&Interactive{}).
+		Select("COALESCE(SUM(read_cnt), 0) AS read_cnt, COALESCE(SUM(like_cnt), 0) AS like_cnt, "+
+			"COALESCE(SUM(collect_cnt), 0) AS collect_cnt, COALESCE(SUM(comment_cnt), 0) AS comment_cnt").
+		Where("biz = ? and biz_id in ?", biz, bizIds).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GormInteractiveDAO) TopByBizIds(ctx context.Context, biz string, bizIds []int64, field string, limit int) ([]Interactive, error) {
+	var res []Interactive
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	if field != "read_cnt" && field != "like_cnt" {
+		return nil, fmt.Errorf("不支持按照 %s 排序", field)
+	}
+	err := g.db.WithContext(ctx).Model(&Interactive{}).
+		Where("biz = ? and biz_id in ?", biz, bizIds).
+		Order(field + " desc").Order("biz_id desc").
+		Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GormInteractiveDAO) GetInteractiveInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]UserInteractive, error) {
+	var res []UserInteractive
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	db := g.db.WithContext(ctx).Model(&Interactive{})
+	if uid > 0 {
+		// 点赞和收藏的标记用子查询一次查出来，不用每篇文章查一次
+		db = db.Select("interactives.*, "+
+			"EXISTS(SELECT 1 FROM user_like_bizs l WHERE l.uid = ? AND l.biz = interactives.biz "+
+			"AND l.biz_id = interactives.biz_id AND l.status = 1) AS liked, "+
+			"EXISTS(SELECT 1 FROM user_collection_bizs c WHERE c.uid = ? AND c.biz = interactives.biz "+
+			"AND c.biz_id = interactives.biz_id) AS collected", uid, uid)
+	}
+	err := db.Where("biz = ? and biz_id in ?", biz, bizIds).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GormInteractiveDAO) FindLikesByUid(ctx context.Context, biz string, uid int64, offset int, limit int) ([]UserLikeBiz, error) {
+	var res []UserLikeBiz
+	err := g.db.WithContext(ctx).
+		Where("uid = ? and biz = ? and status = ?", uid, biz, 1).
+		Order("utime desc").
+		Offset(offset).Limit(limit).
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GormInteractiveDAO) FindCollectionsByUid(ctx context.Context, biz string, uid int64, onlyPublic bool,
+	offset int, limit int) ([]UserCollectionBiz, error) {
+	var res []UserCollectionBiz
+	db := g.db.WithContext(ctx).Model(&UserCollectionBiz{}).
+		Select("uid, biz, biz_id, MIN(ctime) AS ctime, MAX(utime) AS utime").
+		Where("uid = ? and biz = ?", uid, biz)
+	if onlyPublic {
+		// 默认收藏夹是私密的，所以只看公开的自建收藏夹
+		db = db.Where("cid IN (?)", g.db.Model(&Collection{}).Select("id").
+			Where("uid = ? and visibility = ?", uid, CollectionVisibilityPublic))
+	}
+	err := db.Group("uid, biz, biz_id").
+		Order("utime desc").
+		Offset(offset).Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
 func (g *GormInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, bizId int64, uid int64) (UserCollectionBiz, error) {
 	var res UserCollectionBiz
 	err := g.db.WithContext(ctx).Model(&UserCollectionBiz{}).
@@ -45,18 +150,51 @@ func (g *GormInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId
 	return res, err
 }
 
-func (g *GormInteractiveDAO) InsertCollectionInfo(ctx context.Context, cb UserCollectionBiz) error {
+// InsertCollectionInfo 收藏到某个收藏夹，同一篇文章可以收藏到多个收藏夹
+// 收藏数按人算，只有第一次收藏的时候加一，返回是否加了收藏数
+func (g *GormInteractiveDAO) InsertCollectionInfo(ctx context.Context, cb UserCollectionBiz) (bool, error) {
 	now := time.Now().UnixMilli()
 	cb.Ctime = now
 	cb.Utime = now
-	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	var incr bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := g.checkCollection(tx, cb.Cid, cb.Uid)
+		if err != nil {
+			return err
+		}
+		// 锁住这个人对这篇文章的收藏记录，并发收藏到不同的收藏夹的时候只有一个会加收藏数
+		var cids []int64
+		err = tx.Model(&UserCollectionBiz{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? and biz = ? and biz_id = ?", cb.Uid, cb.Biz, cb.BizId).
+			Pluck("cid", &cids).Error
+		if err != nil {
+			return err
+		}
+		for _, cid := range cids {
+			if cid == cb.Cid {
+				// 重复收藏到同一个收藏夹
+				return nil
+			}
+		}
 		// insert
-		err := tx.Create(&cb).Error
+		err = tx.Create(&cb).Error
+		if me, ok := err.(*mysql.MySQLError); ok {
+			const duplicateErr uint16 = 1062
+			if duplicateErr == me.Number {
+				// 并发重复收藏到同一个收藏夹，当成已经收藏过了
+				return nil
+			}
+		}
 		if err != nil {
 			return err
 		}
-		// Upsert likeCnt
-		return tx.Clauses(clause.OnConflict{
+		if len(cids) > 0 {
+			// 已经在别的收藏夹里面了
+			return nil
+		}
+		incr = true
+		// Upsert collectCnt
+		err = tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
 				"collect_cnt": gorm.Expr("collect_cnt + 1"),
 				"utime":       now,
@@ -68,87 +206,200 @@ func (g *GormInteractiveDAO) InsertCollectionInfo(ctx context.Context, cb UserCo
 			Ctime:      now,
 			Utime:      now,
 		}).Error
+		if err != nil {
+			return err
+		}
+		return incrDaily(tx, cb.Biz, cb.BizId, "collect_cnt", 1, now)
+	})
+	return incr, err
+}
+
+// DeleteCollectionInfo 从某个收藏夹中取消收藏，不在任何收藏夹里面了才减收藏数，返回是否减了收藏数
+func (g *GormInteractiveDAO) DeleteCollectionInfo(ctx context.Context, biz string, bizId int64, cid int64, uid int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	var decr bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Where("uid = ? and biz = ? and biz_id = ? and cid = ?", uid, biz, bizId, cid).
+			Delete(&UserCollectionBiz{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return nil
+		}
+		var cnt int64
+		err := tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? and biz = ? and biz_id = ?", uid, biz, bizId).
+			Count(&cnt).Error
+		if err != nil || cnt > 0 {
+			return err
+		}
+		decr = true
+		err = tx.Model(&Interactive{}).Where("biz_id = ? and biz = ? ", bizId, biz).
+			Updates(map[string]interface{}{
+				"collect_cnt": gorm.Expr("collect_cnt - 1"),
+				"utime":       now,
+			}).Error
+		if err != nil {
+			return err
+		}
+		return incrDaily(tx, biz, bizId, "collect_cnt", -1, now)
 	})
+	return decr, err
 }
 
-// InsertLikeInfo
-func (g *GormInteractiveDAO) InsertLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error {
+// MoveCollectionInfo 移动到另一个收藏夹，收藏数不变
+func (g *GormInteractiveDAO) MoveCollectionInfo(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error {
 	now := time.Now().UnixMilli()
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Upsert
-		err := tx.Clauses(clause.OnConflict{
-			DoUpdates: clause.Assignments(map[string]interface{}{
-				"status": 1,
-				"utime":  now,
-			}),
-		}).Create(&UserLikeBiz{
-			Uid:    uid,
-			BizId:  bizId,
-			Biz:    biz,
-			Status: 1,
-			Ctime:  now,
-			Utime:  now,
-		}).Error
+		err := g.checkCollection(tx, toCid, uid)
 		if err != nil {
 			return err
 		}
-		// Upsert likeCnt
-		return tx.Clauses(clause.OnConflict{
+		var cnt int64
+		err = tx.Model(&UserCollectionBiz{}).
+			Where("uid = ? and biz = ? and biz_id = ? and cid = ?", uid, biz, bizId, toCid).
+			Count(&cnt).Error
+		if err != nil {
+			return err
+		}
+		if fromCid == toCid {
+			// 移到原来的收藏夹，不能走下面删掉原来那条的分支，不然收藏就没了，计数也对不上
+			if cnt == 0 {
+				return ErrRecordNotFound
+			}
+			return nil
+		}
+		from := tx.Where("uid = ? and biz = ? and biz_id = ? and cid = ?", uid, biz, bizId, fromCid)
+		if cnt > 0 {
+			// 目标收藏夹里面已经有了，删掉原来的就可以
+			return from.Delete(&UserCollectionBiz{}).Error
+		}
+		res := from.Model(&UserCollectionBiz{}).Updates(map[string]interface{}{
+			"cid":   toCid,
+			"utime": now,
+		})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRecordNotFound
+		}
+		return nil
+	})
+}
+
+// checkCollection 只能收藏到自己的收藏夹，0 是默认收藏夹
+func (g *GormInteractiveDAO) checkCollection(tx *gorm.DB, cid int64, uid int64) error {
+	if cid == 0 {
+		return nil
+	}
+	err := tx.Where("id = ? and uid = ?", cid, uid).First(&Collection{}).Error
+	if err == ErrRecordNotFound {
+		return ErrCollectionNoPermission
+	}
+	return err
+}
+
+func (g *GormInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	now := time.Now().UnixMilli()
+	// 新帖子，没有统计，插入数据
+	// 更新帖子，更新阅读数
+	// Upsert语义
+
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
 			DoUpdates: clause.Assignments(map[string]interface{}{
-				"like_cnt": gorm.Expr("like_cnt + 1"),
+				// 更新数据不能先取出原数据加一，再更新回数据库，会导致并发问题
+				"read_cnt": gorm.Expr("read_cnt + 1"),
 				"utime":    now,
 			}),
 		}).Create(&Interactive{
 			BizId:   bizId,
 			Biz:     biz,
-			LikeCnt: 1,
+			ReadCnt: 1,
 			Ctime:   now,
 			Utime:   now,
 		}).Error
+		if err != nil {
+			return err
+		}
+		return incrDaily(tx, biz, bizId, "read_cnt", 1, now)
 	})
 }
 
-func (g *GormInteractiveDAO) DeleteLikeInfo(ctx context.Context, biz string, bizId int64, uid int64) error {
+func (g *GormInteractiveDAO) BatchIncrReadCnt(ctx context.Context, intrs []Interactive) error {
+	if len(intrs) == 0 {
+		return nil
+	}
 	now := time.Now().UnixMilli()
+	for i := range intrs {
+		intrs[i].Id = 0
+		intrs[i].Ctime = now
+		intrs[i].Utime = now
+	}
+	// 按照唯一索引排序，多个实例同时刷的时候加锁顺序一致，避免死锁
+	sort.Slice(intrs, func(i, j int) bool {
+		if intrs[i].Biz != intrs[j].Biz {
+			return intrs[i].Biz < intrs[j].Biz
+		}
+		return intrs[i].BizId < intrs[j].BizId
+	})
+	dailies := make([]InteractiveDaily, 0, len(intrs))
+	day := statsDay(now)
+	for _, intr := range intrs {
+		dailies = append(dailies, InteractiveDaily{
+			Biz:     intr.Biz,
+			BizId:   intr.BizId,
+			Day:     day,
+			ReadCnt: intr.ReadCnt,
+			Ctime:   now,
+			Utime:   now,
+		})
+	}
+	// INSERT ... ON DUPLICATE KEY UPDATE 每 500 条一个语句，总数和当天的增量在一个事务里面
 	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// 必然存在，所以Update 即可
-		err := tx.Model(UserLikeBiz{}).
-			Where("uid = ? and biz_id = ? and biz = ? ", uid, bizId, biz).
-			Updates(map[string]interface{}{
-				"status": 0,
-				"utime":  now,
-			}).Error
+		err := tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"read_cnt": gorm.Expr("read_cnt + VALUES(read_cnt)"),
+				"utime":    now,
+			}),
+		}).CreateInBatches(&intrs, 500).Error
 		if err != nil {
 			return err
 		}
-		// Update likeCnt
-		return tx.Model(&Interactive{}).Where("biz_id = ? and biz = ? ", bizId, biz).
-			Updates(map[string]interface{}{
-				"like_cnt": gorm.Expr("like_cnt - 1"),
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"read_cnt": gorm.Expr("read_cnt + VALUES(read_cnt)"),
 				"utime":    now,
-			}).Error
+			}),
+		}).CreateInBatches(&dailies, 500).Error
 	})
 }
 
-func (g *GormInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+func (g *GormInteractiveDAO) BatchSetReaderCnt(ctx context.Context, intrs []Interactive) error {
+	if len(intrs) == 0 {
+		return nil
+	}
 	now := time.Now().UnixMilli()
-	// 新帖子，没有统计，插入数据
-	// 更新帖子，更新阅读数
-	// Upsert语义
-
+	for i := range intrs {
+		intrs[i].Id = 0
+		intrs[i].Ctime = now
+		intrs[i].Utime = now
+	}
+	sort.Slice(intrs, func(i, j int) bool {
+		if intrs[i].Biz != intrs[j].Biz {
+			return intrs[i].Biz < intrs[j].Biz
+		}
+		return intrs[i].BizId < intrs[j].BizId
+	})
+	// redis 的数据丢了的话 HyperLogLog 会从 0 开始，不能把数据库里面的覆盖小
 	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
 		DoUpdates: clause.Assignments(map[string]interface{}{
-			// 更新数据不能先取出原数据加一，再更新回数据库，会导致并发问题
-			"read_cnt": gorm.Expr("read_cnt + 1"),
-			"utime":    now,
+			"reader_cnt": gorm.Expr("GREATEST(reader_cnt, VALUES(reader_cnt))"),
+			"utime":      now,
 		}),
-	}).Create(&Interactive{
-		BizId:   bizId,
-		Biz:     biz,
-		ReadCnt: 1,
-		Ctime:   now,
-		Utime:   now,
-	}).Error
+	}).CreateInBatches(&intrs, 500).Error
 }
 
 func NewGormInteractiveDAO(db *gorm.DB) InteractiveDAO {
@@ -163,6 +414,7 @@ type Interactive struct {
 	BizId      int64  `gorm:"uniqueIndex:biz_type_id"`
 	Biz        string `gorm:"type:varchar(128);uniqueIndex:biz_type_id"`
 	ReadCnt    int64  // 阅读数
+	ReaderCnt  int64  // 独立读者数 定时从 redis 的 HyperLogLog 同步过来
 	LikeCnt    int64  //点赞数
 	CollectCnt int64  //收藏数
 	CommentCnt int64  //评论数
@@ -171,27 +423,34 @@ type Interactive struct {
 	Utime      int64
 }
 
+// UserInteractive 批量查询的结果，计数加上某个用户是否点赞、收藏
+type UserInteractive struct {
+	Interactive
+	Liked     bool
+	Collected bool
+}
+
 type UserLikeBiz struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
 	// 唯一索引
-	Uid    int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	BizId  int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	Biz    string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id"`
-	Status uint
+	Uid int64 `gorm:"uniqueIndex:uid_biz_type_id"`
+	// biz_type_id_status_utime 给相关文章查最近点赞的用户用
+	BizId  int64  `gorm:"uniqueIndex:uid_biz_type_id;index:biz_type_id_status_utime,priority:2"`
+	Biz    string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id;index:biz_type_id_status_utime,priority:1"`
+	Status uint   `gorm:"index:biz_type_id_status_utime,priority:3"`
 	Ctime  int64
-	Utime  int64
+	Utime  int64 `gorm:"index:biz_type_id_status_utime,priority:4"`
 }
 
 type UserCollectionBiz struct {
 	Id int64 `gorm:"primaryKey,autoIncrement"`
-	// 唯一索引
-	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id"`
-	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id"`
-	// 收藏夹id 可以进行冗余操作，加上收藏夹名字
-	// uid,biz,bizid只能收藏一次，
-	//也可以对uid,biz,bizid,cid进行联合索引,效果就是可以对同个文件在不同收藏夹进行收藏，b站那边是这样的
-	Cid   int64 `gorm:"index"`
+	// 唯一索引 <uid,biz,bizId,cid>
+	// 同一篇文章可以收藏到不同的收藏夹，b站那边是这样的
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id_cid"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id_cid"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id_cid"`
+	// 收藏夹id 0 是默认收藏夹
+	Cid   int64 `gorm:"uniqueIndex:uid_biz_type_id_cid;index"`
 	Ctime int64
 	Utime int64
 }
@@ -0,0 +1,72 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"webook/internal/domain"
+)
+
+// statsDay 毫秒时间戳对应的统计日期
+func statsDay(ms int64) string {
+	return domain.StatsDay(time.UnixMilli(ms))
+}
+
+// InteractiveDaily 每个资源每天的计数增量，取消点赞、取消收藏、删除评论会记成负数
+type InteractiveDaily struct {
+	Id         int64  `gorm:"primaryKey,autoIncrement"`
+	BizId      int64  `gorm:"uniqueIndex:biz_type_id_day"`
+	Biz        string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_day"`
+	Day        string `gorm:"type:char(10);uniqueIndex:biz_type_id_day"`
+	ReadCnt    int64
+	LikeCnt    int64
+	CollectCnt int64
+	CommentCnt int64
+	Ctime      int64
+	Utime      int64
+}
+
+// incrDaily 在计数的事务里面顺便记一笔当天的增量，field 是 InteractiveDaily 的计数列
+func incrDaily(tx *gorm.DB, biz string, bizId int64, field string, delta int64, now int64) error {
+	daily := InteractiveDaily{
+		Biz:   biz,
+		BizId: bizId,
+		Day:   statsDay(now),
+		Ctime: now,
+		Utime: now,
+	}
+	switch field {
+	case "read_cnt":
+		daily.ReadCnt = delta
+	case "like_cnt":
+		daily.LikeCnt = delta
+	case "collect_cnt":
+		daily.CollectCnt = delta
+	case "comment_cnt":
+		daily.CommentCnt = delta
+	}
+	return tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			field:   gorm.Expr(field+" + ?", delta),
+			"utime": now,
+		}),
+	}).Create(&daily).Error
+}
+
+// FindDaily 一批资源在 [startDay, endDay] 之间每天的增量之和，没有数据的日子不返回
+func (g *GormInteractiveDAO) FindDaily(ctx context.Context, biz string, bizIds []int64,
+	startDay string, endDay string) ([]InteractiveDaily, error) {
+	var res []InteractiveDaily
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Model(&InteractiveDaily{}).
+		Select("day, SUM(read_cnt) AS read_cnt, SUM(like_cnt) AS like_cnt, "+
+			"SUM(collect_cnt) AS collect_cnt, SUM(comment_cnt) AS comment_cnt").
+		Where("biz = ? and biz_id in ? and day >= ? and day <= ?", biz, bizIds, startDay, endDay).
+		Group("day").
+		Order("day asc").
+		Scan(&res).Error
+	return res, err
+}
@@ -0,0 +1,102 @@
+package dao
+
+import (
+	"context"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+)
+
+func TestIncrDaily(t *testing.T) {
+	// UTC 的 3 月 13 号下午，统计时区已经是 14 号了
+	now := time.Date(2024, 3, 13, 16, 30, 0, 0, time.UTC).UnixMilli()
+	testCases := []struct {
+		name  string
+		field string
+		delta int64
+		mock  func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:  "点赞",
+			field: "like_cnt",
+			delta: 1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO `interactive_dailies` .* ON DUPLICATE KEY UPDATE "+
+					"`like_cnt`=like_cnt \\+ \\?,`utime`=\\?").
+					WithArgs(int64(1), "article", "2024-03-14", int64(0), int64(1), int64(0), int64(0),
+						now, now, int64(1), now).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+		{
+			name:  "取消收藏记成负数",
+			field: "collect_cnt",
+			delta: -1,
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO `interactive_dailies` .* ON DUPLICATE KEY UPDATE "+
+					"`collect_cnt`=collect_cnt \\+ \\?,`utime`=\\?").
+					WithArgs(int64(1), "article", "2024-03-14", int64(0), int64(0), int64(-1), int64(0),
+						now, now, int64(-1), now).
+					WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			err := incrDaily(db.WithContext(context.Background()), "article", 1, tc.field, tc.delta, now)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormInteractiveDAO_FindDaily(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		bizIds  []int64
+		wantRes []InteractiveDaily
+	}{
+		{
+			name: "按天汇总",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("SELECT day, SUM\\(read_cnt\\) AS read_cnt, .* FROM `interactive_dailies` "+
+					"WHERE biz = \\? and biz_id in \\(\\?,\\?\\) and day >= \\? and day <= \\? GROUP BY `day` ORDER BY day asc").
+					WithArgs("article", int64(1), int64(2), "2024-03-01", "2024-03-31").
+					WillReturnRows(sqlmock.NewRows([]string{"day", "read_cnt", "like_cnt", "collect_cnt", "comment_cnt"}).
+						AddRow("2024-03-02", 10, 2, 1, 0).
+						AddRow("2024-03-05", 3, -1, 0, 1))
+			},
+			bizIds: []int64{1, 2},
+			wantRes: []InteractiveDaily{
+				{Day: "2024-03-02", ReadCnt: 10, LikeCnt: 2, CollectCnt: 1},
+				{Day: "2024-03-05", ReadCnt: 3, LikeCnt: -1, CommentCnt: 1},
+			},
+		},
+		{
+			name: "没有文章不查数据库",
+			mock: func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormInteractiveDAO(db)
+			res, err := dao.FindDaily(context.Background(), "article", tc.bizIds, "2024-03-01", "2024-03-31")
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStatsDay(t *testing.T) {
+	// 统计日期按东八区算
+	assert.Equal(t, "2024-03-14", statsDay(time.Date(2024, 3, 13, 16, 0, 0, 0, time.UTC).UnixMilli()))
+	assert.Equal(t, "2024-03-13", statsDay(time.Date(2024, 3, 13, 15, 59, 0, 0, time.UTC).UnixMilli()))
+}
@@ -0,0 +1,192 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"webook/internal/domain"
+)
+
+// UserReactionBiz 用户对资源点的表情，一个用户可以对同一个资源点多种表情
+// 默认表情 domain.ReactionLike 同时写 UserLikeBiz，原来按点赞查询的地方不用改
+type UserReactionBiz struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	Uid      int64  `gorm:"uniqueIndex:uid_biz_type_id_reaction"`
+	BizId    int64  `gorm:"uniqueIndex:uid_biz_type_id_reaction"`
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id_reaction"`
+	Reaction string `gorm:"type:varchar(32);uniqueIndex:uid_biz_type_id_reaction"`
+	Status   uint
+	Ctime    int64
+	Utime    int64
+}
+
+// InteractiveReaction 资源每种表情的数量，默认表情的数量和 Interactive.LikeCnt 一致
+type InteractiveReaction struct {
+	Id       int64  `gorm:"primaryKey,autoIncrement"`
+	BizId    int64  `gorm:"uniqueIndex:biz_type_id_reaction"`
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_reaction"`
+	Reaction string `gorm:"type:varchar(32);uniqueIndex:biz_type_id_reaction"`
+	Cnt      int64
+	Ctime    int64
+	Utime    int64
+}
+
+// InsertReaction 返回 false 表示之前已经点过这个表情了，计数不变
+func (g *GormInteractiveDAO) InsertReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) (bool, error) {
+	now := time.Now().UnixMilli()
+	var incr bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 取消过的恢复，没有点过的插入，已经点过的什么都不做
+		res := tx.Model(&UserReactionBiz{}).
+			Where("uid = ? and biz = ? and biz_id = ? and reaction = ? and status = ?", uid, biz, bizId, reaction, 0).
+			Updates(map[string]interface{}{
+				"status": 1,
+				"utime":  now,
+			})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			res = tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&UserReactionBiz{
+				Uid:      uid,
+				Biz:      biz,
+				BizId:    bizId,
+				Reaction: reaction,
+				Status:   1,
+				Ctime:    now,
+				Utime:    now,
+			})
+			if res.Error != nil || res.RowsAffected == 0 {
+				return res.Error
+			}
+		}
+		incr = true
+		return g.incrReaction(tx, biz, bizId, uid, reaction, 1, now)
+	})
+	return incr, err
+}
+
+// DeleteReaction 返回 false 表示本来就没有点这个表情
+func (g *GormInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) (bool, error) {
+	now := time.Now().UnixMilli()
+	var decr bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&UserReactionBiz{}).
+			Where("uid = ? and biz = ? and biz_id = ? and reaction = ? and status = ?", uid, biz, bizId, reaction, 1).
+			Updates(map[string]interface{}{
+				"status": 0,
+				"utime":  now,
+			})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		decr = true
+		return g.incrReaction(tx, biz, bizId, uid, reaction, -1, now)
+	})
+	return decr, err
+}
+
+// incrReaction 更新表情计数，默认表情顺便维护原来的点赞记录和点赞数
+func (g *GormInteractiveDAO) incrReaction(tx *gorm.DB, biz string, bizId int64, uid int64,
+	reaction string, delta int64, now int64) error {
+	err := tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"cnt":   gorm.Expr("cnt + ?", delta),
+			"utime": now,
+		}),
+	}).Create(&InteractiveReaction{
+		Biz:      biz,
+		BizId:    bizId,
+		Reaction: reaction,
+		Cnt:      delta,
+		Ctime:    now,
+		Utime:    now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	if reaction != domain.ReactionLike {
+		// 保证有互动记录，只点了别的表情的资源也能查到计数
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"utime": now,
+			}),
+		}).Create(&Interactive{
+			BizId: bizId,
+			Biz:   biz,
+			Ctime: now,
+			Utime: now,
+		}).Error
+	}
+	var status uint
+	if delta > 0 {
+		status = 1
+	}
+	err = tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"status": status,
+			"utime":  now,
+		}),
+	}).Create(&UserLikeBiz{
+		Uid:    uid,
+		BizId:  bizId,
+		Biz:    biz,
+		Status: status,
+		Ctime:  now,
+		Utime:  now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	err = tx.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"like_cnt": gorm.Expr("like_cnt + ?", delta),
+			"utime":    now,
+		}),
+	}).Create(&Interactive{
+		BizId:   bizId,
+		Biz:     biz,
+		LikeCnt: delta,
+		Ctime:   now,
+		Utime:   now,
+	}).Error
+	if err != nil {
+		return err
+	}
+	return incrDaily(tx, biz, bizId, "like_cnt", delta, now)
+}
+
+func (g *GormInteractiveDAO) GetReactions(ctx context.Context, biz string, bizId int64, uid int64) ([]UserReactionBiz, error) {
+	var res []UserReactionBiz
+	err := g.db.WithContext(ctx).
+		Where("uid = ? and biz = ? and biz_id = ? and status = ?", uid, biz, bizId, 1).
+		Order("id asc").
+		Find(&res).Error
+	return res, err
+}
+
+func (g *GormInteractiveDAO) GetReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]InteractiveReaction, error) {
+	var res []InteractiveReaction
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).
+		Where("biz = ? and biz_id in ? and cnt > 0", biz, bizIds).
+		Find(&res).Error
+	return res, err
+}
+
+// migrateLikesToReactions 把原来的点赞迁移成默认表情，点赞一直同时写 UserLikeBiz，重复跑结果也一样
+// 评论的点赞也放在 UserLikeBiz 里面，不迁移
+func migrateLikesToReactions(tx *gorm.DB) error {
+	err := tx.Exec("INSERT INTO user_reaction_bizs (uid, biz, biz_id, reaction, status, ctime, utime) "+
+		"SELECT uid, biz, biz_id, ?, status, ctime, utime FROM user_like_bizs WHERE biz <> ? "+
+		"ON DUPLICATE KEY UPDATE status = VALUES(status)", domain.ReactionLike, CommentBiz).Error
+	if err != nil {
+		return err
+	}
+	return tx.Exec("INSERT INTO interactive_reactions (biz, biz_id, reaction, cnt, ctime, utime) "+
+		"SELECT biz, biz_id, ?, like_cnt, ctime, utime FROM interactives WHERE like_cnt > 0 "+
+		"ON DUPLICATE KEY UPDATE cnt = VALUES(cnt)", domain.ReactionLike).Error
+}
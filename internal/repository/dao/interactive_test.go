@@ -0,0 +1,267 @@
+package dao
+
+import (
+	"context"
+	"database/sql/driver"
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	gomysql "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"testing"
+)
+
+func TestGormInteractiveDAO_MoveCollectionInfo(t *testing.T) {
+	collection := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery("SELECT \\* FROM `collections`").WithArgs(anyArgs(2)...).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "uid"}).AddRow(2, 1))
+	}
+	targetCnt := func(mock sqlmock.Sqlmock, cnt int64) {
+		mock.ExpectQuery("SELECT count\\(\\*\\) FROM `user_collection_bizs`").WithArgs(anyArgs(4)...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(cnt))
+	}
+	testCases := []struct {
+		name    string
+		mock    func(mock sqlmock.Sqlmock)
+		fromCid int64
+		toCid   int64
+		wantErr error
+	}{
+		{
+			name: "移动成功",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				collection(mock)
+				targetCnt(mock, 0)
+				mock.ExpectExec("UPDATE `user_collection_bizs` SET").WithArgs(anyArgs(6)...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fromCid: 0,
+			toCid:   2,
+		},
+		{
+			name: "目标收藏夹已经有了，删掉原来的",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				collection(mock)
+				targetCnt(mock, 1)
+				mock.ExpectExec("DELETE FROM `user_collection_bizs`").WithArgs(anyArgs(4)...).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			fromCid: 0,
+			toCid:   2,
+		},
+		{
+			name: "同一个收藏夹什么都不做",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				collection(mock)
+				targetCnt(mock, 1)
+				mock.ExpectCommit()
+			},
+			fromCid: 2,
+			toCid:   2,
+		},
+		{
+			name: "同一个收藏夹，但是没有收藏",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				collection(mock)
+				targetCnt(mock, 0)
+				mock.ExpectRollback()
+			},
+			fromCid: 2,
+			toCid:   2,
+			wantErr: ErrRecordNotFound,
+		},
+		{
+			name: "别人的收藏夹",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT \\* FROM `collections`").WithArgs(anyArgs(2)...).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "uid"}))
+				mock.ExpectRollback()
+			},
+			fromCid: 0,
+			toCid:   3,
+			wantErr: ErrCollectionNoPermission,
+		},
+		{
+			name: "原收藏夹里面没有",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				collection(mock)
+				targetCnt(mock, 0)
+				mock.ExpectExec("UPDATE `user_collection_bizs` SET").WithArgs(anyArgs(6)...).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			fromCid: 0,
+			toCid:   2,
+			wantErr: ErrRecordNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormInteractiveDAO(db)
+			err := dao.MoveCollectionInfo(context.Background(), "article", 1, tc.fromCid, tc.toCid, 1)
+			assert.Equal(t, tc.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestGormInteractiveDAO_InsertCollectionInfo(t *testing.T) {
+	// 加锁查这个人已经收藏到了哪些收藏夹
+	cids := func(mock sqlmock.Sqlmock, cids ...int64) {
+		rows := sqlmock.NewRows([]string{"cid"})
+		for _, cid := range cids {
+			rows.AddRow(cid)
+		}
+		mock.ExpectQuery("SELECT `cid` FROM `user_collection_bizs` WHERE .* FOR UPDATE").
+			WithArgs(anyArgs(3)...).WillReturnRows(rows)
+	}
+	testCases := []struct {
+		name     string
+		mock     func(mock sqlmock.Sqlmock)
+		wantIncr bool
+		wantErr  error
+	}{
+		{
+			name: "第一次收藏，加收藏数",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				cids(mock)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").WithArgs(anyArgs(6)...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE").WithArgs(anyArgs(11)...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO `interactive_dailies` .* ON DUPLICATE KEY UPDATE").WithArgs(anyArgs(11)...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			wantIncr: true,
+		},
+		{
+			name: "已经在别的收藏夹里面了，不加收藏数",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				cids(mock, 2)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").WithArgs(anyArgs(6)...).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "重复收藏到同一个收藏夹",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				cids(mock, 0)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "并发重复收藏，唯一索引冲突当成已经收藏",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				cids(mock)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").WithArgs(anyArgs(6)...).
+					WillReturnError(&gomysql.MySQLError{Number: 1062})
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "插入失败",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				cids(mock)
+				mock.ExpectExec("INSERT INTO `user_collection_bizs`").WithArgs(anyArgs(6)...).
+					WillReturnError(&gomysql.MySQLError{Number: 1205})
+				mock.ExpectRollback()
+			},
+			wantErr: &gomysql.MySQLError{Number: 1205},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormInteractiveDAO(db)
+			incr, err := dao.InsertCollectionInfo(context.Background(), UserCollectionBiz{
+				Uid:   1,
+				Biz:   "article",
+				BizId: 1,
+				// 默认收藏夹，不用查收藏夹
+				Cid: 0,
+			})
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantIncr, incr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func newMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	sqlDB, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	db, err := gorm.Open(mysql.New(mysql.Config{
+		Conn:                      sqlDB,
+		SkipInitializeWithVersion: true,
+	}), &gorm.Config{
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return db, mock
+}
+
+// anyArgs sqlmock 不写参数的时候要求 SQL 没有参数
+func anyArgs(n int) []driver.Value {
+	res := make([]driver.Value, n)
+	for i := range res {
+		res[i] = sqlmock.AnyArg()
+	}
+	return res
+}
+
+func TestGormInteractiveDAO_BatchSetReaderCnt(t *testing.T) {
+	testCases := []struct {
+		name  string
+		mock  func(mock sqlmock.Sqlmock)
+		intrs []Interactive
+	}{
+		{
+			name: "只会把独立读者数往大了改",
+			mock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO `interactives` .* ON DUPLICATE KEY UPDATE " +
+					"`reader_cnt`=GREATEST\\(reader_cnt, VALUES\\(reader_cnt\\)\\),`utime`=\\?").
+					// 两行每行 10 列，再加上 utime
+					WithArgs(anyArgs(2*10 + 1)...).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+			},
+			intrs: []Interactive{
+				{Biz: "article", BizId: 2, ReaderCnt: 3},
+				{Biz: "article", BizId: 1, ReaderCnt: 5},
+			},
+		},
+		{
+			name: "没有数据不查数据库",
+			mock: func(mock sqlmock.Sqlmock) {},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := newMockDB(t)
+			tc.mock(mock)
+			dao := NewGormInteractiveDAO(db)
+			err := dao.BatchSetReaderCnt(context.Background(), tc.intrs)
+			assert.NoError(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,195 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"webook/internal/domain"
+)
+
+var (
+	ErrJobNotHold       = errors.New("任务不属于当前实例")
+	ErrJobStatusChanged = errors.New("任务状态不允许该操作")
+)
+
+type JobDAO interface {
+	// Upsert 按名字注册任务，已经存在的只更新定义，不影响调度状态
+	Upsert(ctx context.Context, j Job) error
+	// Preempt 抢一个到期的任务，心跳超过 timeout 的任务认为执行的实例已经挂了，也可以抢
+	Preempt(ctx context.Context, owner string, timeout time.Duration) (Job, error)
+	Heartbeat(ctx context.Context, id int64, owner string) error
+	// Release 执行完释放任务，设置下一次执行时间
+	Release(ctx context.Context, id int64, owner string, next int64) error
+	FindById(ctx context.Context, id int64) (Job, error)
+	List(ctx context.Context) ([]Job, error)
+	Pause(ctx context.Context, id int64) error
+	Resume(ctx context.Context, id int64, next int64) error
+	// Trigger 把下一次执行时间改成现在
+	Trigger(ctx context.Context, id int64) error
+}
+
+type GormJobDAO struct {
+	db *gorm.DB
+}
+
+func NewGormJobDAO(db *gorm.DB) JobDAO {
+	return &GormJobDAO{
+		db: db,
+	}
+}
+
+func (g *GormJobDAO) Upsert(ctx context.Context, j Job) error {
+	now := time.Now().UnixMilli()
+	j.Ctime = now
+	j.Utime = now
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "name"}},
+		DoUpdates: clause.Assignments(map[string]any{
+			"executor":   j.Executor,
+			"cfg":        j.Cfg,
+			"expression": j.Expression,
+			"utime":      now,
+		}),
+	}).Create(&j).Error
+}
+
+func (g *GormJobDAO) Preempt(ctx context.Context, owner string, timeout time.Duration) (Job, error) {
+	db := g.db.WithContext(ctx)
+	// 别的实例可能同时在抢同一个任务，CAS 失败了就重新找一个
+	for i := 0; i < 3; i++ {
+		now := time.Now().UnixMilli()
+		var j Job
+		err := db.Where("(status = ? and next_time <= ?) or (status = ? and heartbeat < ?)",
+			domain.JobStatusWaiting.ToUint8(), now,
+			domain.JobStatusRunning.ToUint8(), now-timeout.Milliseconds()).
+			Order("next_time").
+			First(&j).Error
+		if err != nil {
+			return Job{}, err
+		}
+		res := db.Model(&Job{}).Where("id = ? and version = ?", j.Id, j.Version).
+			Updates(map[string]any{
+				"status":    domain.JobStatusRunning.ToUint8(),
+				"owner":     owner,
+				"heartbeat": now,
+				"version":   j.Version + 1,
+				"utime":     now,
+			})
+		if res.Error != nil {
+			return Job{}, res.Error
+		}
+		if res.RowsAffected == 1 {
+			j.Status = domain.JobStatusRunning.ToUint8()
+			j.Owner = owner
+			j.Heartbeat = now
+			j.Version++
+			j.Utime = now
+			return j, nil
+		}
+	}
+	return Job{}, ErrRecordNotFound
+}
+
+func (g *GormJobDAO) Heartbeat(ctx context.Context, id int64, owner string) error {
+	now := time.Now().UnixMilli()
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and status = ?", id, owner, domain.JobStatusRunning.ToUint8()).
+		Updates(map[string]any{
+			"heartbeat": now,
+			"utime":     now,
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobNotHold
+	}
+	return nil
+}
+
+func (g *GormJobDAO) Release(ctx context.Context, id int64, owner string, next int64) error {
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and owner = ? and status = ?", id, owner, domain.JobStatusRunning.ToUint8()).
+		Updates(map[string]any{
+			"status":    domain.JobStatusWaiting.ToUint8(),
+			"owner":     "",
+			"next_time": next,
+			"utime":     time.Now().UnixMilli(),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobNotHold
+	}
+	return nil
+}
+
+func (g *GormJobDAO) FindById(ctx context.Context, id int64) (Job, error) {
+	var j Job
+	err := g.db.WithContext(ctx).Where("id = ?", id).First(&j).Error
+	return j, err
+}
+
+func (g *GormJobDAO) List(ctx context.Context) ([]Job, error) {
+	var jobs []Job
+	err := g.db.WithContext(ctx).Order("id").Find(&jobs).Error
+	return jobs, err
+}
+
+// Pause 正在执行的任务也可以暂停，执行的实例下一次心跳的时候会发现并停下来
+func (g *GormJobDAO) Pause(ctx context.Context, id int64) error {
+	return g.updateStatus(ctx, id, []uint8{domain.JobStatusWaiting.ToUint8(), domain.JobStatusRunning.ToUint8()},
+		map[string]any{
+			"status": domain.JobStatusPaused.ToUint8(),
+			"owner":  "",
+		})
+}
+
+func (g *GormJobDAO) Resume(ctx context.Context, id int64, next int64) error {
+	return g.updateStatus(ctx, id, []uint8{domain.JobStatusPaused.ToUint8()},
+		map[string]any{
+			"status":    domain.JobStatusWaiting.ToUint8(),
+			"next_time": next,
+		})
+}
+
+func (g *GormJobDAO) Trigger(ctx context.Context, id int64) error {
+	return g.updateStatus(ctx, id, []uint8{domain.JobStatusWaiting.ToUint8()},
+		map[string]any{
+			"next_time": time.Now().UnixMilli(),
+		})
+}
+
+func (g *GormJobDAO) updateStatus(ctx context.Context, id int64, from []uint8, updates map[string]any) error {
+	updates["utime"] = time.Now().UnixMilli()
+	res := g.db.WithContext(ctx).Model(&Job{}).
+		Where("id = ? and status in ?", id, from).
+		Updates(updates)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrJobStatusChanged
+	}
+	return nil
+}
+
+type Job struct {
+	Id         int64  `gorm:"primaryKey,autoIncrement"`
+	Name       string `gorm:"type:varchar(128);uniqueIndex"`
+	Executor   string `gorm:"type:varchar(128)"`
+	Cfg        string
+	Expression string `gorm:"type:varchar(128)"`
+	Status     uint8  `gorm:"index:idx_status_next_time"`
+	NextTime   int64  `gorm:"index:idx_status_next_time"`
+	// Owner 正在执行的实例，Heartbeat 是它最后一次心跳的时间
+	Owner     string `gorm:"type:varchar(128)"`
+	Heartbeat int64
+	// Version 抢占的时候做 CAS
+	Version int64
+	Ctime   int64
+	Utime   int64
+}
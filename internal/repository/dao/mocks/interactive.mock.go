@@ -0,0 +1,321 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/dao/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/dao/interactive.go -package=daomocks -destination=./internal/repository/dao/mocks/interactive.mock.go
+//
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveDAO is a mock of InteractiveDAO interface.
+type MockInteractiveDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveDAOMockRecorder
+}
+
+// MockInteractiveDAOMockRecorder is the mock recorder for MockInteractiveDAO.
+type MockInteractiveDAOMockRecorder struct {
+	mock *MockInteractiveDAO
+}
+
+// NewMockInteractiveDAO creates a new mock instance.
+func NewMockInteractiveDAO(ctrl *gomock.Controller) *MockInteractiveDAO {
+	mock := &MockInteractiveDAO{ctrl: ctrl}
+	mock.recorder = &MockInteractiveDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveDAO) EXPECT() *MockInteractiveDAOMockRecorder {
+	return m.recorder
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) BatchIncrReadCnt(ctx context.Context, intrs []dao.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, intrs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchIncrReadCnt(ctx, intrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchIncrReadCnt), ctx, intrs)
+}
+
+// BatchSetReaderCnt mocks base method.
+func (m *MockInteractiveDAO) BatchSetReaderCnt(ctx context.Context, intrs []dao.Interactive) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchSetReaderCnt", ctx, intrs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchSetReaderCnt indicates an expected call of BatchSetReaderCnt.
+func (mr *MockInteractiveDAOMockRecorder) BatchSetReaderCnt(ctx, intrs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchSetReaderCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).BatchSetReaderCnt), ctx, intrs)
+}
+
+// DeleteCollectionInfo mocks base method.
+func (m *MockInteractiveDAO) DeleteCollectionInfo(ctx context.Context, biz string, bizId, cid, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionInfo", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCollectionInfo indicates an expected call of DeleteCollectionInfo.
+func (mr *MockInteractiveDAOMockRecorder) DeleteCollectionInfo(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteCollectionInfo), ctx, biz, bizId, cid, uid)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockInteractiveDAO) DeleteReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockInteractiveDAOMockRecorder) DeleteReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).DeleteReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// FindCollectionsByUid mocks base method.
+func (m *MockInteractiveDAO) FindCollectionsByUid(ctx context.Context, biz string, uid int64, onlyPublic bool, offset, limit int) ([]dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindCollectionsByUid", ctx, biz, uid, onlyPublic, offset, limit)
+	ret0, _ := ret[0].([]dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindCollectionsByUid indicates an expected call of FindCollectionsByUid.
+func (mr *MockInteractiveDAOMockRecorder) FindCollectionsByUid(ctx, biz, uid, onlyPublic, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindCollectionsByUid", reflect.TypeOf((*MockInteractiveDAO)(nil).FindCollectionsByUid), ctx, biz, uid, onlyPublic, offset, limit)
+}
+
+// FindDaily mocks base method.
+func (m *MockInteractiveDAO) FindDaily(ctx context.Context, biz string, bizIds []int64, startDay, endDay string) ([]dao.InteractiveDaily, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDaily", ctx, biz, bizIds, startDay, endDay)
+	ret0, _ := ret[0].([]dao.InteractiveDaily)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDaily indicates an expected call of FindDaily.
+func (mr *MockInteractiveDAOMockRecorder) FindDaily(ctx, biz, bizIds, startDay, endDay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDaily", reflect.TypeOf((*MockInteractiveDAO)(nil).FindDaily), ctx, biz, bizIds, startDay, endDay)
+}
+
+// FindLikesByUid mocks base method.
+func (m *MockInteractiveDAO) FindLikesByUid(ctx context.Context, biz string, uid int64, offset, limit int) ([]dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLikesByUid", ctx, biz, uid, offset, limit)
+	ret0, _ := ret[0].([]dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLikesByUid indicates an expected call of FindLikesByUid.
+func (mr *MockInteractiveDAOMockRecorder) FindLikesByUid(ctx, biz, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLikesByUid", reflect.TypeOf((*MockInteractiveDAO)(nil).FindLikesByUid), ctx, biz, uid, offset, limit)
+}
+
+// GetCollectInfo mocks base method.
+func (m *MockInteractiveDAO) GetCollectInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserCollectionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCollectInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserCollectionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCollectInfo indicates an expected call of GetCollectInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetCollectInfo(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCollectInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetCollectInfo), ctx, biz, bizId, uid)
+}
+
+// GetInteractiveInfo mocks base method.
+func (m *MockInteractiveDAO) GetInteractiveInfo(ctx context.Context, biz string, bizId int64) (dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInteractiveInfo", ctx, biz, bizId)
+	ret0, _ := ret[0].(dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInteractiveInfo indicates an expected call of GetInteractiveInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetInteractiveInfo(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInteractiveInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetInteractiveInfo), ctx, biz, bizId)
+}
+
+// GetInteractiveInfos mocks base method.
+func (m *MockInteractiveDAO) GetInteractiveInfos(ctx context.Context, biz string, bizIds []int64, uid int64) ([]dao.UserInteractive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInteractiveInfos", ctx, biz, bizIds, uid)
+	ret0, _ := ret[0].([]dao.UserInteractive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInteractiveInfos indicates an expected call of GetInteractiveInfos.
+func (mr *MockInteractiveDAOMockRecorder) GetInteractiveInfos(ctx, biz, bizIds, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInteractiveInfos", reflect.TypeOf((*MockInteractiveDAO)(nil).GetInteractiveInfos), ctx, biz, bizIds, uid)
+}
+
+// GetLikeInfo mocks base method.
+func (m *MockInteractiveDAO) GetLikeInfo(ctx context.Context, biz string, bizId, uid int64) (dao.UserLikeBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLikeInfo", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(dao.UserLikeBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLikeInfo indicates an expected call of GetLikeInfo.
+func (mr *MockInteractiveDAOMockRecorder) GetLikeInfo(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLikeInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).GetLikeInfo), ctx, biz, bizId, uid)
+}
+
+// GetReactionCnts mocks base method.
+func (m *MockInteractiveDAO) GetReactionCnts(ctx context.Context, biz string, bizIds []int64) ([]dao.InteractiveReaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactionCnts", ctx, biz, bizIds)
+	ret0, _ := ret[0].([]dao.InteractiveReaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactionCnts indicates an expected call of GetReactionCnts.
+func (mr *MockInteractiveDAOMockRecorder) GetReactionCnts(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactionCnts", reflect.TypeOf((*MockInteractiveDAO)(nil).GetReactionCnts), ctx, biz, bizIds)
+}
+
+// GetReactions mocks base method.
+func (m *MockInteractiveDAO) GetReactions(ctx context.Context, biz string, bizId, uid int64) ([]dao.UserReactionBiz, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactions", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].([]dao.UserReactionBiz)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactions indicates an expected call of GetReactions.
+func (mr *MockInteractiveDAOMockRecorder) GetReactions(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockInteractiveDAO)(nil).GetReactions), ctx, biz, bizId, uid)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveDAO) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveDAOMockRecorder) IncrReadCnt(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveDAO)(nil).IncrReadCnt), ctx, biz, bizId)
+}
+
+// InsertCollectionInfo mocks base method.
+func (m *MockInteractiveDAO) InsertCollectionInfo(ctx context.Context, cb dao.UserCollectionBiz) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertCollectionInfo", ctx, cb)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertCollectionInfo indicates an expected call of InsertCollectionInfo.
+func (mr *MockInteractiveDAOMockRecorder) InsertCollectionInfo(ctx, cb any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertCollectionInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertCollectionInfo), ctx, cb)
+}
+
+// InsertReaction mocks base method.
+func (m *MockInteractiveDAO) InsertReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InsertReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InsertReaction indicates an expected call of InsertReaction.
+func (mr *MockInteractiveDAOMockRecorder) InsertReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InsertReaction", reflect.TypeOf((*MockInteractiveDAO)(nil).InsertReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// MoveCollectionInfo mocks base method.
+func (m *MockInteractiveDAO) MoveCollectionInfo(ctx context.Context, biz string, bizId, fromCid, toCid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionInfo", ctx, biz, bizId, fromCid, toCid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionInfo indicates an expected call of MoveCollectionInfo.
+func (mr *MockInteractiveDAOMockRecorder) MoveCollectionInfo(ctx, biz, bizId, fromCid, toCid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionInfo", reflect.TypeOf((*MockInteractiveDAO)(nil).MoveCollectionInfo), ctx, biz, bizId, fromCid, toCid, uid)
+}
+
+// SumByBizIds mocks base method.
+func (m *MockInteractiveDAO) SumByBizIds(ctx context.Context, biz string, bizIds []int64) (dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumByBizIds", ctx, biz, bizIds)
+	ret0, _ := ret[0].(dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumByBizIds indicates an expected call of SumByBizIds.
+func (mr *MockInteractiveDAOMockRecorder) SumByBizIds(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumByBizIds", reflect.TypeOf((*MockInteractiveDAO)(nil).SumByBizIds), ctx, biz, bizIds)
+}
+
+// TopByBizIds mocks base method.
+func (m *MockInteractiveDAO) TopByBizIds(ctx context.Context, biz string, bizIds []int64, field string, limit int) ([]dao.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopByBizIds", ctx, biz, bizIds, field, limit)
+	ret0, _ := ret[0].([]dao.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopByBizIds indicates an expected call of TopByBizIds.
+func (mr *MockInteractiveDAOMockRecorder) TopByBizIds(ctx, biz, bizIds, field, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopByBizIds", reflect.TypeOf((*MockInteractiveDAO)(nil).TopByBizIds), ctx, biz, bizIds, field, limit)
+}
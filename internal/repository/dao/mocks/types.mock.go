@@ -0,0 +1,203 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/dao/types.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/dao/types.go -package=daomocks -destination=./internal/repository/dao/mocks/types.mock.go
+//
+// Package daomocks is a generated GoMock package.
+package daomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	dao "webook/internal/repository/dao"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleDAO is a mock of ArticleDAO interface.
+type MockArticleDAO struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleDAOMockRecorder
+}
+
+// MockArticleDAOMockRecorder is the mock recorder for MockArticleDAO.
+type MockArticleDAOMockRecorder struct {
+	mock *MockArticleDAO
+}
+
+// NewMockArticleDAO creates a new mock instance.
+func NewMockArticleDAO(ctrl *gomock.Controller) *MockArticleDAO {
+	mock := &MockArticleDAO{ctrl: ctrl}
+	mock.recorder = &MockArticleDAOMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleDAO) EXPECT() *MockArticleDAOMockRecorder {
+	return m.recorder
+}
+
+// GetByArtId mocks base method.
+func (m *MockArticleDAO) GetByArtId(cxt context.Context, artId int64) (dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByArtId", cxt, artId)
+	ret0, _ := ret[0].(dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByArtId indicates an expected call of GetByArtId.
+func (mr *MockArticleDAOMockRecorder) GetByArtId(cxt, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByArtId", reflect.TypeOf((*MockArticleDAO)(nil).GetByArtId), cxt, artId)
+}
+
+// GetByAuthor mocks base method.
+func (m *MockArticleDAO) GetByAuthor(ctx context.Context, limit, offset int, uid int64) ([]dao.Article, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByAuthor", ctx, limit, offset, uid)
+	ret0, _ := ret[0].([]dao.Article)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByAuthor indicates an expected call of GetByAuthor.
+func (mr *MockArticleDAOMockRecorder) GetByAuthor(ctx, limit, offset, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetByAuthor), ctx, limit, offset, uid)
+}
+
+// GetPubBriefsByAuthor mocks base method.
+func (m *MockArticleDAO) GetPubBriefsByAuthor(ctx context.Context, uid int64) ([]dao.ArticlePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubBriefsByAuthor", ctx, uid)
+	ret0, _ := ret[0].([]dao.ArticlePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubBriefsByAuthor indicates an expected call of GetPubBriefsByAuthor.
+func (mr *MockArticleDAOMockRecorder) GetPubBriefsByAuthor(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubBriefsByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetPubBriefsByAuthor), ctx, uid)
+}
+
+// GetPubByArtId mocks base method.
+func (m *MockArticleDAO) GetPubByArtId(ctx context.Context, artId int64) (dao.ArticlePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByArtId", ctx, artId)
+	ret0, _ := ret[0].(dao.ArticlePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByArtId indicates an expected call of GetPubByArtId.
+func (mr *MockArticleDAOMockRecorder) GetPubByArtId(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByArtId", reflect.TypeOf((*MockArticleDAO)(nil).GetPubByArtId), ctx, artId)
+}
+
+// GetPubByArtIds mocks base method.
+func (m *MockArticleDAO) GetPubByArtIds(ctx context.Context, artIds []int64) ([]dao.ArticlePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubByArtIds", ctx, artIds)
+	ret0, _ := ret[0].([]dao.ArticlePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubByArtIds indicates an expected call of GetPubByArtIds.
+func (mr *MockArticleDAOMockRecorder) GetPubByArtIds(ctx, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubByArtIds", reflect.TypeOf((*MockArticleDAO)(nil).GetPubByArtIds), ctx, artIds)
+}
+
+// GetPubIdsByAuthor mocks base method.
+func (m *MockArticleDAO) GetPubIdsByAuthor(ctx context.Context, uid int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPubIdsByAuthor", ctx, uid)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPubIdsByAuthor indicates an expected call of GetPubIdsByAuthor.
+func (mr *MockArticleDAOMockRecorder) GetPubIdsByAuthor(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPubIdsByAuthor", reflect.TypeOf((*MockArticleDAO)(nil).GetPubIdsByAuthor), ctx, uid)
+}
+
+// Insert mocks base method.
+func (m *MockArticleDAO) Insert(ctx context.Context, art dao.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Insert", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Insert indicates an expected call of Insert.
+func (mr *MockArticleDAOMockRecorder) Insert(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Insert", reflect.TypeOf((*MockArticleDAO)(nil).Insert), ctx, art)
+}
+
+// ListPub mocks base method.
+func (m *MockArticleDAO) ListPub(ctx context.Context, start, lastUtime, lastId int64, limit int) ([]dao.ArticlePublish, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPub", ctx, start, lastUtime, lastId, limit)
+	ret0, _ := ret[0].([]dao.ArticlePublish)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPub indicates an expected call of ListPub.
+func (mr *MockArticleDAOMockRecorder) ListPub(ctx, start, lastUtime, lastId, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPub", reflect.TypeOf((*MockArticleDAO)(nil).ListPub), ctx, start, lastUtime, lastId, limit)
+}
+
+// Sync mocks base method.
+func (m *MockArticleDAO) Sync(ctx context.Context, art dao.Article) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, art)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockArticleDAOMockRecorder) Sync(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockArticleDAO)(nil).Sync), ctx, art)
+}
+
+// SyncStatus mocks base method.
+func (m *MockArticleDAO) SyncStatus(ctx context.Context, artId, uid int64, status uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncStatus", ctx, artId, uid, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncStatus indicates an expected call of SyncStatus.
+func (mr *MockArticleDAOMockRecorder) SyncStatus(ctx, artId, uid, status any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncStatus", reflect.TypeOf((*MockArticleDAO)(nil).SyncStatus), ctx, artId, uid, status)
+}
+
+// UpdateById mocks base method.
+func (m *MockArticleDAO) UpdateById(ctx context.Context, art dao.Article) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateById", ctx, art)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateById indicates an expected call of UpdateById.
+func (mr *MockArticleDAOMockRecorder) UpdateById(ctx, art any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateById", reflect.TypeOf((*MockArticleDAO)(nil).UpdateById), ctx, art)
+}
@@ -0,0 +1,170 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type NotificationDAO interface {
+	// Insert 聚合到同一个资源上同一种未读的通知里面，同一个人只算一次，返回 false 表示没有变化
+	Insert(ctx context.Context, n Notification, actor int64) (bool, error)
+	// List 按 id 倒序，cursor 为 0 的时候从头开始
+	List(ctx context.Context, uid int64, cursor int64, limit int, unreadOnly bool) ([]Notification, error)
+	// UnreadCnt 每种类型的未读数
+	UnreadCnt(ctx context.Context, uid int64) (map[string]int64, error)
+	// MarkRead ids 为空的时候全部标记成已读
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	// OptOuts 用户关掉了的通知类型
+	OptOuts(ctx context.Context, uid int64) ([]string, error)
+	SetOptOut(ctx context.Context, uid int64, typ string, optOut bool) error
+}
+
+type GormNotificationDAO struct {
+	db *gorm.DB
+}
+
+func NewGormNotificationDAO(db *gorm.DB) NotificationDAO {
+	return &GormNotificationDAO{
+		db: db,
+	}
+}
+
+func (g *GormNotificationDAO) Insert(ctx context.Context, n Notification, actor int64) (bool, error) {
+	now := time.Now().UnixMilli()
+	var changed bool
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 没有未读的就新建一条，唯一索引保证同时只有一条未读
+		err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&Notification{
+			Uid:   n.Uid,
+			Type:  n.Type,
+			Biz:   n.Biz,
+			BizId: n.BizId,
+			Ctime: now,
+			Utime: now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		var cur Notification
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("uid = ? AND type = ? AND biz = ? AND biz_id = ? AND read_id = ?", n.Uid, n.Type, n.Biz, n.BizId, 0).
+			First(&cur).Error
+		if err != nil {
+			return err
+		}
+		// 消息重复投递，或者取消之后又点了一次
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&NotificationActor{
+			Nid:   cur.Id,
+			Actor: actor,
+			Ctime: now,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			return res.Error
+		}
+		changed = true
+		return tx.Model(&Notification{}).Where("id = ?", cur.Id).Updates(map[string]interface{}{
+			"actor_cnt":  gorm.Expr("actor_cnt + 1"),
+			"last_actor": actor,
+			"utime":      now,
+		}).Error
+	})
+	return changed, err
+}
+
+func (g *GormNotificationDAO) List(ctx context.Context, uid int64, cursor int64, limit int, unreadOnly bool) ([]Notification, error) {
+	db := g.db.WithContext(ctx).Where("uid = ?", uid)
+	if unreadOnly {
+		db = db.Where("read_id = ?", 0)
+	}
+	if cursor > 0 {
+		db = db.Where("id < ?", cursor)
+	}
+	var ns []Notification
+	err := db.Order("id DESC").Limit(limit).Find(&ns).Error
+	return ns, err
+}
+
+func (g *GormNotificationDAO) UnreadCnt(ctx context.Context, uid int64) (map[string]int64, error) {
+	type cnt struct {
+		Type string
+		Cnt  int64
+	}
+	var cnts []cnt
+	err := g.db.WithContext(ctx).Model(&Notification{}).
+		Select("type, COUNT(*) AS cnt").
+		Where("uid = ? AND read_id = ?", uid, 0).
+		Group("type").Scan(&cnts).Error
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[string]int64, len(cnts))
+	for _, c := range cnts {
+		res[c.Type] = c.Cnt
+	}
+	return res, nil
+}
+
+func (g *GormNotificationDAO) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	db := g.db.WithContext(ctx).Model(&Notification{}).Where("uid = ? AND read_id = ?", uid, 0)
+	if len(ids) > 0 {
+		db = db.Where("id IN ?", ids)
+	}
+	// read_id 改成自己的 id，后面再来的动作会聚合到一条新的未读通知上
+	return db.Updates(map[string]interface{}{
+		"read_id": gorm.Expr("id"),
+		"utime":   time.Now().UnixMilli(),
+	}).Error
+}
+
+func (g *GormNotificationDAO) OptOuts(ctx context.Context, uid int64) ([]string, error) {
+	var types []string
+	err := g.db.WithContext(ctx).Model(&NotificationOptOut{}).
+		Where("uid = ?", uid).Pluck("type", &types).Error
+	return types, err
+}
+
+func (g *GormNotificationDAO) SetOptOut(ctx context.Context, uid int64, typ string, optOut bool) error {
+	if !optOut {
+		return g.db.WithContext(ctx).Where("uid = ? AND type = ?", uid, typ).
+			Delete(&NotificationOptOut{}).Error
+	}
+	return g.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&NotificationOptOut{
+		Uid:   uid,
+		Type:  typ,
+		Ctime: time.Now().UnixMilli(),
+	}).Error
+}
+
+// Notification 未读的时候 ReadId 是 0，已读之后改成自己的 Id
+// 这样 <uid, type, biz, biz_id, read_id> 唯一索引就能保证每个资源上每种通知最多一条未读
+type Notification struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// uid_id 二级索引带着主键，按 id 倒序翻页不用排序
+	Uid       int64  `gorm:"uniqueIndex:uid_type_biz_read;index:uid_read;index:uid_id"`
+	Type      string `gorm:"type:varchar(32);uniqueIndex:uid_type_biz_read"`
+	Biz       string `gorm:"type:varchar(128);uniqueIndex:uid_type_biz_read"`
+	BizId     int64  `gorm:"uniqueIndex:uid_type_biz_read"`
+	ReadId    int64  `gorm:"uniqueIndex:uid_type_biz_read;index:uid_read"`
+	LastActor int64
+	ActorCnt  int64
+	Ctime     int64
+	Utime     int64
+}
+
+// NotificationActor 聚合进一条通知的人，用来去重
+type NotificationActor struct {
+	Id    int64 `gorm:"primaryKey,autoIncrement"`
+	Nid   int64 `gorm:"uniqueIndex:nid_actor"`
+	Actor int64 `gorm:"uniqueIndex:nid_actor"`
+	Ctime int64
+}
+
+// NotificationOptOut 用户关掉的通知类型，没有记录就是开着的
+type NotificationOptOut struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	Uid   int64  `gorm:"uniqueIndex:uid_type"`
+	Type  string `gorm:"type:varchar(32);uniqueIndex:uid_type"`
+	Ctime int64
+}
@@ -0,0 +1,120 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+	"webook/internal/domain"
+)
+
+// RelatedDAO 相关推荐要用到的几种信号：标签、一起被点赞、同一个作者
+type RelatedDAO interface {
+	// SetTags 覆盖文章的标签
+	SetTags(ctx context.Context, artId int64, tags []string) error
+	GetTags(ctx context.Context, artId int64) ([]string, error)
+	// FindByTags 和 tags 有交集的文章，按共同标签数倒序
+	FindByTags(ctx context.Context, tags []string, exclude int64, limit int) ([]RelatedCnt, error)
+	// FindCoLiked 最近 likers 个点赞了 bizId 的用户，他们还点赞了哪些，按人数倒序
+	FindCoLiked(ctx context.Context, biz string, bizId int64, likers int, limit int) ([]RelatedCnt, error)
+	// FindRecentByAuthor 作者最近发表的文章
+	FindRecentByAuthor(ctx context.Context, author int64, exclude int64, limit int) ([]int64, error)
+}
+
+type GormRelatedDAO struct {
+	db *gorm.DB
+}
+
+func NewGormRelatedDAO(db *gorm.DB) RelatedDAO {
+	return &GormRelatedDAO{
+		db: db,
+	}
+}
+
+func (g *GormRelatedDAO) SetTags(ctx context.Context, artId int64, tags []string) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("art_id = ?", artId).Delete(&ArticleTag{}).Error
+		if err != nil || len(tags) == 0 {
+			return err
+		}
+		rows := make([]ArticleTag, 0, len(tags))
+		for _, tag := range tags {
+			rows = append(rows, ArticleTag{
+				ArtId: artId,
+				Tag:   tag,
+				Ctime: now,
+			})
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&rows).Error
+	})
+}
+
+func (g *GormRelatedDAO) GetTags(ctx context.Context, artId int64) ([]string, error) {
+	var tags []string
+	err := g.db.WithContext(ctx).Model(&ArticleTag{}).
+		Where("art_id = ?", artId).
+		Order("id").
+		Pluck("tag", &tags).Error
+	return tags, err
+}
+
+func (g *GormRelatedDAO) FindByTags(ctx context.Context, tags []string, exclude int64, limit int) ([]RelatedCnt, error) {
+	var res []RelatedCnt
+	if len(tags) == 0 {
+		return res, nil
+	}
+	err := g.db.WithContext(ctx).Model(&ArticleTag{}).
+		Select("art_id AS biz_id, COUNT(*) AS cnt").
+		Where("tag in ? and art_id != ?", tags, exclude).
+		Group("art_id").
+		Order("cnt desc, biz_id desc").
+		Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GormRelatedDAO) FindCoLiked(ctx context.Context, biz string, bizId int64, likers int, limit int) ([]RelatedCnt, error) {
+	var res []RelatedCnt
+	// MySQL 的 IN 子查询里面不支持 LIMIT，分两次查
+	var uids []int64
+	err := g.db.WithContext(ctx).Model(&UserLikeBiz{}).
+		Where("biz = ? and biz_id = ? and status = ?", biz, bizId, 1).
+		Order("utime desc").
+		Limit(likers).
+		Pluck("uid", &uids).Error
+	if err != nil || len(uids) == 0 {
+		return res, err
+	}
+	err = g.db.WithContext(ctx).Model(&UserLikeBiz{}).
+		Select("biz_id, COUNT(*) AS cnt").
+		Where("uid in ? and biz = ? and biz_id != ? and status = ?", uids, biz, bizId, 1).
+		Group("biz_id").
+		Order("cnt desc, biz_id desc").
+		Limit(limit).
+		Scan(&res).Error
+	return res, err
+}
+
+func (g *GormRelatedDAO) FindRecentByAuthor(ctx context.Context, author int64, exclude int64, limit int) ([]int64, error) {
+	var ids []int64
+	err := g.db.WithContext(ctx).Model(&ArticlePublish{}).
+		Where("author_id = ? and id != ? and status = ?", author, exclude, domain.ArticleStatusPublished.ToUint8()).
+		Order("utime desc").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	return ids, err
+}
+
+type RelatedCnt struct {
+	BizId int64
+	Cnt   int64
+}
+
+// ArticleTag 一篇文章一个标签一行，按标签找文章走 tag 上的索引
+type ArticleTag struct {
+	Id    int64  `gorm:"primaryKey,autoIncrement"`
+	ArtId int64  `gorm:"uniqueIndex:art_tag"`
+	Tag   string `gorm:"type:varchar(32);uniqueIndex:art_tag;index"`
+	Ctime int64
+}
@@ -0,0 +1,173 @@
+package dao
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type ShareDAO interface {
+	// Insert 记录一次分享，链接不存在就用传入的 code 创建，返回最终使用的链接
+	Insert(ctx context.Context, link ShareLink, channel string) (ShareLink, error)
+	FindByCode(ctx context.Context, code string) (ShareLink, error)
+	IncrClickCnt(ctx context.Context, code string) error
+	// IncrReadCnt 同一个用户通过同一个链接阅读只算一次
+	IncrReadCnt(ctx context.Context, code string, uid int64) error
+	// SumByBiz 汇总某个资源所有分享链接带来的点击和阅读
+	SumByBiz(ctx context.Context, biz string, bizId int64) (ShareLink, error)
+	FindChannels(ctx context.Context, biz string, bizId int64) ([]ShareChannel, error)
+}
+
+type GormShareDAO struct {
+	db *gorm.DB
+}
+
+func NewGormShareDAO(db *gorm.DB) ShareDAO {
+	return &GormShareDAO{
+		db: db,
+	}
+}
+
+func (g *GormShareDAO) Insert(ctx context.Context, link ShareLink, channel string) (ShareLink, error) {
+	now := time.Now().UnixMilli()
+	err := g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var existing ShareLink
+		err := tx.Where("uid = ? and biz = ? and biz_id = ?", link.Uid, link.Biz, link.BizId).
+			First(&existing).Error
+		switch err {
+		case nil:
+			link = existing
+		case ErrRecordNotFound:
+			link.Ctime = now
+			link.Utime = now
+			err = tx.Create(&link).Error
+			if err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+		// Upsert 渠道分享数
+		err = tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"share_cnt": gorm.Expr("share_cnt + 1"),
+				"utime":     now,
+			}),
+		}).Create(&ShareChannel{
+			Biz:      link.Biz,
+			BizId:    link.BizId,
+			Channel:  channel,
+			ShareCnt: 1,
+			Ctime:    now,
+			Utime:    now,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// Upsert shareCnt
+		return tx.Clauses(clause.OnConflict{
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"share_cnt": gorm.Expr("share_cnt + 1"),
+				"utime":     now,
+			}),
+		}).Create(&Interactive{
+			BizId:    link.BizId,
+			Biz:      link.Biz,
+			ShareCnt: 1,
+			Ctime:    now,
+			Utime:    now,
+		}).Error
+	})
+	return link, err
+}
+
+func (g *GormShareDAO) FindByCode(ctx context.Context, code string) (ShareLink, error) {
+	var res ShareLink
+	err := g.db.WithContext(ctx).Where("code = ?", code).First(&res).Error
+	return res, err
+}
+
+func (g *GormShareDAO) IncrClickCnt(ctx context.Context, code string) error {
+	return g.db.WithContext(ctx).Model(&ShareLink{}).Where("code = ?", code).
+		Updates(map[string]interface{}{
+			"click_cnt": gorm.Expr("click_cnt + 1"),
+			"utime":     time.Now().UnixMilli(),
+		}).Error
+}
+
+func (g *GormShareDAO) IncrReadCnt(ctx context.Context, code string, uid int64) error {
+	now := time.Now().UnixMilli()
+	return g.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		res := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&ShareRead{
+			Code:  code,
+			Uid:   uid,
+			Ctime: now,
+		})
+		if res.Error != nil || res.RowsAffected == 0 {
+			// 已经读过了
+			return res.Error
+		}
+		return tx.Model(&ShareLink{}).Where("code = ?", code).
+			Updates(map[string]interface{}{
+				"read_cnt": gorm.Expr("read_cnt + 1"),
+				"utime":    now,
+			}).Error
+	})
+}
+
+func (g *GormShareDAO) SumByBiz(ctx context.Context, biz string, bizId int64) (ShareLink, error) {
+	var res ShareLink
+	err := g.db.WithContext(ctx).Model(&ShareLink{}).
+		Select("coalesce(sum(click_cnt), 0) as click_cnt, coalesce(sum(read_cnt), 0) as read_cnt").
+		Where("biz = ? and biz_id = ?", biz, bizId).
+		Scan(&res).Error
+	res.Biz = biz
+	res.BizId = bizId
+	return res, err
+}
+
+func (g *GormShareDAO) FindChannels(ctx context.Context, biz string, bizId int64) ([]ShareChannel, error) {
+	var res []ShareChannel
+	err := g.db.WithContext(ctx).
+		Where("biz = ? and biz_id = ?", biz, bizId).
+		Order("share_cnt desc").
+		Find(&res).Error
+	return res, err
+}
+
+// ShareLink 一个用户对一个资源只有一个分享链接
+type ShareLink struct {
+	Id   int64  `gorm:"primaryKey,autoIncrement"`
+	Code string `gorm:"type:varchar(32);uniqueIndex"`
+	// 唯一索引 <uid,biz,bizId>
+	Uid   int64  `gorm:"uniqueIndex:uid_biz_type_id"`
+	Biz   string `gorm:"type:varchar(128);uniqueIndex:uid_biz_type_id;index:biz_type_id"`
+	BizId int64  `gorm:"uniqueIndex:uid_biz_type_id;index:biz_type_id"`
+	// 点击数 阅读数
+	ClickCnt int64
+	ReadCnt  int64
+	Ctime    int64
+	Utime    int64
+}
+
+// ShareChannel 按渠道统计的分享数
+type ShareChannel struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 唯一索引 <biz,bizId,channel>
+	Biz      string `gorm:"type:varchar(128);uniqueIndex:biz_type_id_channel"`
+	BizId    int64  `gorm:"uniqueIndex:biz_type_id_channel"`
+	Channel  string `gorm:"type:varchar(32);uniqueIndex:biz_type_id_channel"`
+	ShareCnt int64
+	Ctime    int64
+	Utime    int64
+}
+
+// ShareRead 通过分享链接阅读过的用户，用来去重
+type ShareRead struct {
+	Id int64 `gorm:"primaryKey,autoIncrement"`
+	// 唯一索引 <code,uid>
+	Code  string `gorm:"type:varchar(32);uniqueIndex:code_uid"`
+	Uid   int64  `gorm:"uniqueIndex:code_uid"`
+	Ctime int64
+}
@@ -13,4 +13,12 @@ type ArticleDAO interface {
 	GetByArtId(cxt context.Context, artId int64) (Article, error)
 
 	GetPubByArtId(ctx context.Context, artId int64) (ArticlePublish, error)
+	GetPubByArtIds(ctx context.Context, artIds []int64) ([]ArticlePublish, error)
+	// GetPubIdsByAuthor 作者发表过的所有文章 ID，包括已经撤回的
+	GetPubIdsByAuthor(ctx context.Context, uid int64) ([]int64, error)
+	// GetPubBriefsByAuthor 作者发表过的所有文章，不查内容
+	GetPubBriefsByAuthor(ctx context.Context, uid int64) ([]ArticlePublish, error)
+	// ListPub 更新时间在 start 之后的已发表文章，按 (utime, id) 倒序，不查内容
+	// 从 (lastUtime, lastId) 后面开始取，第一批 lastId 传 0
+	ListPub(ctx context.Context, start int64, lastUtime int64, lastId int64, limit int) ([]ArticlePublish, error)
 }
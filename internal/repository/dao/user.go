@@ -15,12 +15,15 @@ var (
 )
 
 type UserDAO interface {
-	Insert(ctx context.Context, u User) error
+	Insert(ctx context.Context, u User) (int64, error)
 	FindByEmail(ctx context.Context, email string) (User, error)
 	InsertInfo(ctx context.Context, u User) error
 	FindById(ctx context.Context, uid int64) (User, error)
 	FindByPhone(ctx context.Context, phone string) (User, error)
 	FindByWechat(ctx context.Context, OpenId string) (User, error)
+	// UpdatePrivacy 隐私设置是 bool，Updates 结构体会忽略零值，所以单独更新
+	UpdatePrivacy(ctx context.Context, uid int64, likesPublic bool) error
+	FindByIds(ctx context.Context, ids []int64) ([]User, error)
 }
 
 type GormUserDAO struct {
@@ -39,7 +42,7 @@ func (dao *GormUserDAO) FindByWechat(ctx context.Context, openId string) (User,
 	return u, err
 }
 
-func (dao *GormUserDAO) Insert(ctx context.Context, u User) error {
+func (dao *GormUserDAO) Insert(ctx context.Context, u User) (int64, error) {
 	now := time.Now().UnixMilli()
 	u.Ctime = now
 	u.Utime = now
@@ -48,10 +51,10 @@ func (dao *GormUserDAO) Insert(ctx context.Context, u User) error {
 		const duplicateErr uint16 = 1062
 		if duplicateErr == me.Number {
 			// todo 用户冲突，邮箱 唯一索引冲突
-			return ErrDuplicateUser
+			return 0, ErrDuplicateUser
 		}
 	}
-	return err
+	return u.Id, err
 }
 
 func (dao *GormUserDAO) FindByEmail(ctx context.Context, email string) (User, error) {
@@ -66,12 +69,26 @@ func (dao *GormUserDAO) InsertInfo(ctx context.Context, u User) error {
 	return dao.db.WithContext(ctx).Updates(&u).Error
 }
 
+func (dao *GormUserDAO) UpdatePrivacy(ctx context.Context, uid int64, likesPublic bool) error {
+	return dao.db.WithContext(ctx).Model(&User{}).Where("id = ?", uid).
+		Updates(map[string]interface{}{
+			"likes_public": likesPublic,
+			"utime":        time.Now().UnixMilli(),
+		}).Error
+}
+
 func (dao *GormUserDAO) FindById(ctx context.Context, uid int64) (User, error) {
 	var u User
 	err := dao.db.WithContext(ctx).Where("id=?", uid).First(&u).Error
 	return u, err
 }
 
+func (dao *GormUserDAO) FindByIds(ctx context.Context, ids []int64) ([]User, error) {
+	var res []User
+	err := dao.db.WithContext(ctx).Where("id in ?", ids).Find(&res).Error
+	return res, err
+}
+
 func (dao *GormUserDAO) FindByPhone(ctx context.Context, phone string) (User, error) {
 	var u User
 	err := dao.db.WithContext(ctx).Where("phone=?", phone).First(&u).Error
@@ -98,8 +115,10 @@ type User struct {
 	//服务器 go应用 数据库
 	WetchatOpenId  sql.NullString `gorm:"unique"`
 	WetchatUnionId sql.NullString
-	Ctime          int64
-	Utime          int64
+	// 点赞记录是否公开
+	LikesPublic bool
+	Ctime       int64
+	Utime       int64
 
 	//json
 	//Addr string
@@ -12,13 +12,13 @@ import (
 	"testing"
 )
 
-// todo errors:xecQuery 'INSERT INTO `users` (`email`,`phone`,`password`,`nickname`,`birthday`,`about_me`,`ctime`,`utime`) VALUES (?,?,?,?,?,?,?,?)', arguments do not match: expected 0, but got 8 arguments
 func TestGormUserDAO_Insert(t *testing.T) {
 	testCases := []struct {
 		name    string
 		sqlmock func(t *testing.T) *sql.DB
 		ctx     context.Context
 		user    User
+		wantId  int64
 		wantErr error
 	}{
 		{
@@ -27,18 +27,19 @@ func TestGormUserDAO_Insert(t *testing.T) {
 				db, mock, err := sqlmock.New()
 				assert.NoError(t, err)
 				mockRes := sqlmock.NewResult(1, 1)
-				mock.ExpectExec("(INSERT INTO `users` ).*").WillReturnResult(mockRes)
+				mock.ExpectExec("(INSERT INTO `users` ).*").WithArgs(anyArgs(11)...).WillReturnResult(mockRes)
 				return db
 			},
-			ctx:  context.Background(),
-			user: User{},
+			ctx:    context.Background(),
+			user:   User{},
+			wantId: 1,
 		},
 		{
 			name: "插入失败-邮箱冲突",
 			sqlmock: func(t *testing.T) *sql.DB {
 				db, mock, err := sqlmock.New()
 				assert.NoError(t, err)
-				mock.ExpectExec("^INSERT\\sINTO\\s`users`").WillReturnError(&mysqlDriver.MySQLError{Number: 1062})
+				mock.ExpectExec("^INSERT\\sINTO\\s`users`").WithArgs(anyArgs(11)...).WillReturnError(&mysqlDriver.MySQLError{Number: 1062})
 				return db
 			},
 			ctx:     context.Background(),
@@ -49,7 +50,7 @@ func TestGormUserDAO_Insert(t *testing.T) {
 			sqlmock: func(t *testing.T) *sql.DB {
 				db, mock, err := sqlmock.New()
 				assert.NoError(t, err)
-				mock.ExpectExec("INSERT INTO `users` .*").
+				mock.ExpectExec("INSERT INTO `users` .*").WithArgs(anyArgs(11)...).
 					WillReturnError(errors.New("mock db error"))
 				return db
 			},
@@ -70,8 +71,9 @@ func TestGormUserDAO_Insert(t *testing.T) {
 			// 初始化 DB 不能出错，所以这里要断言必须为 nil
 			assert.NoError(t, err)
 			dao := NewGormUserDAO(db)
-			err = dao.Insert(tc.ctx, tc.user)
+			id, err := dao.Insert(tc.ctx, tc.user)
 			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
 		})
 	}
 }
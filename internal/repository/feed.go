@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+)
+
+// FeedRepository 关注流只放在 redis 里面，丢了可以从发件箱重建
+type FeedRepository interface {
+	AddOutbox(ctx context.Context, author int64, item domain.FeedItem) error
+	RemoveOutbox(ctx context.Context, author int64, artId int64) error
+	Outboxes(ctx context.Context, authors []int64, cursor int64, limit int) ([]domain.FeedItem, error)
+	Push(ctx context.Context, uids []int64, item domain.FeedItem) (int, error)
+	Remove(ctx context.Context, uids []int64, artIds []int64) error
+	Inbox(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FeedItem, error)
+	Touch(ctx context.Context, uid int64) (bool, error)
+	Rebuild(ctx context.Context, uid int64, items []domain.FeedItem) error
+	AddBigAuthor(ctx context.Context, author int64) error
+	BigAuthors(ctx context.Context) ([]int64, error)
+}
+
+type CachedFeedRepository struct {
+	cache cache.FeedCache
+}
+
+func NewCachedFeedRepository(cache cache.FeedCache) FeedRepository {
+	return &CachedFeedRepository{
+		cache: cache,
+	}
+}
+
+func (c *CachedFeedRepository) AddOutbox(ctx context.Context, author int64, item domain.FeedItem) error {
+	return c.cache.AddOutbox(ctx, author, item)
+}
+
+func (c *CachedFeedRepository) RemoveOutbox(ctx context.Context, author int64, artId int64) error {
+	return c.cache.RemoveOutbox(ctx, author, artId)
+}
+
+func (c *CachedFeedRepository) Outboxes(ctx context.Context, authors []int64, cursor int64, limit int) ([]domain.FeedItem, error) {
+	return c.cache.Outboxes(ctx, authors, cursor, limit)
+}
+
+func (c *CachedFeedRepository) Push(ctx context.Context, uids []int64, item domain.FeedItem) (int, error) {
+	return c.cache.Push(ctx, uids, item)
+}
+
+func (c *CachedFeedRepository) Remove(ctx context.Context, uids []int64, artIds []int64) error {
+	return c.cache.Remove(ctx, uids, artIds)
+}
+
+func (c *CachedFeedRepository) Inbox(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FeedItem, error) {
+	return c.cache.Inbox(ctx, uid, cursor, limit)
+}
+
+func (c *CachedFeedRepository) Touch(ctx context.Context, uid int64) (bool, error) {
+	return c.cache.Touch(ctx, uid)
+}
+
+func (c *CachedFeedRepository) Rebuild(ctx context.Context, uid int64, items []domain.FeedItem) error {
+	return c.cache.Rebuild(ctx, uid, items)
+}
+
+func (c *CachedFeedRepository) AddBigAuthor(ctx context.Context, author int64) error {
+	return c.cache.AddBigAuthor(ctx, author)
+}
+
+func (c *CachedFeedRepository) BigAuthors(ctx context.Context) ([]int64, error) {
+	return c.cache.BigAuthors(ctx)
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+	"webook/pkg/cachex"
+)
+
+type FollowRepository interface {
+	// Follow 返回这次是不是新关注的，重复关注返回 false
+	Follow(ctx context.Context, follower int64, followee int64) (bool, error)
+	CancelFollow(ctx context.Context, follower int64, followee int64) error
+	Followers(ctx context.Context, followee int64, cursor int64, limit int) ([]domain.FollowRelation, error)
+	Followees(ctx context.Context, follower int64, cursor int64, limit int) ([]domain.FollowRelation, error)
+	// FollowedIn followees 里面 follower 关注了的
+	FollowedIn(ctx context.Context, follower int64, followees []int64) ([]int64, error)
+	// FollowersIn followers 里面关注了 followee 的
+	FollowersIn(ctx context.Context, followee int64, followers []int64) ([]int64, error)
+	GetStatistic(ctx context.Context, uid int64) (domain.FollowStatistic, error)
+	// CountNewFollowers [start, end) 之间新增并且现在还在关注的粉丝数
+	CountNewFollowers(ctx context.Context, followee int64, start time.Time, end time.Time) (int64, error)
+}
+
+type CachedFollowRepository struct {
+	dao   dao.FollowDAO
+	cache cache.FollowCache
+	aside *cachex.Aside
+}
+
+func NewCachedFollowRepository(dao dao.FollowDAO, cache cache.FollowCache, aside *cachex.Aside) FollowRepository {
+	return &CachedFollowRepository{
+		dao:   dao,
+		cache: cache,
+		aside: aside,
+	}
+}
+
+func (c *CachedFollowRepository) Follow(ctx context.Context, follower int64, followee int64) (bool, error) {
+	incr, err := c.dao.Follow(ctx, follower, followee)
+	if err != nil || !incr {
+		return false, err
+	}
+	return true, c.cache.IncrStatisticIfPresent(ctx, follower, followee, 1)
+}
+
+func (c *CachedFollowRepository) CancelFollow(ctx context.Context, follower int64, followee int64) error {
+	decr, err := c.dao.CancelFollow(ctx, follower, followee)
+	if err != nil || !decr {
+		return err
+	}
+	return c.cache.IncrStatisticIfPresent(ctx, follower, followee, -1)
+}
+
+func (c *CachedFollowRepository) Followers(ctx context.Context, followee int64, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	rs, err := c.dao.FindFollowers(ctx, followee, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FollowRelation, domain.FollowRelation](rs, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedFollowRepository) Followees(ctx context.Context, follower int64, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	rs, err := c.dao.FindFollowees(ctx, follower, cursor, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.FollowRelation, domain.FollowRelation](rs, func(idx int, src dao.FollowRelation) domain.FollowRelation {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedFollowRepository) FollowedIn(ctx context.Context, follower int64, followees []int64) ([]int64, error) {
+	return c.dao.FollowedIn(ctx, follower, followees)
+}
+
+func (c *CachedFollowRepository) FollowersIn(ctx context.Context, followee int64, followers []int64) ([]int64, error) {
+	return c.dao.FollowersIn(ctx, followee, followers)
+}
+
+func (c *CachedFollowRepository) CountNewFollowers(ctx context.Context, followee int64, start time.Time, end time.Time) (int64, error) {
+	return c.dao.CountNewFollowers(ctx, followee, start.UnixMilli(), end.UnixMilli())
+}
+
+func (c *CachedFollowRepository) GetStatistic(ctx context.Context, uid int64) (domain.FollowStatistic, error) {
+	return cachex.Get(ctx, c.aside, cachex.Query[domain.FollowStatistic]{
+		Key: fmt.Sprintf("follow:statistic:%d", uid),
+		Get: func(ctx context.Context) (domain.FollowStatistic, error) {
+			return c.cache.GetStatistic(ctx, uid)
+		},
+		// 没有记录的时候回写全是 0 的统计，之后关注的时候直接加在上面
+		Load: func(ctx context.Context) (domain.FollowStatistic, error) {
+			s, err := c.dao.GetStatistic(ctx, uid)
+			if err != nil {
+				return domain.FollowStatistic{}, err
+			}
+			return domain.FollowStatistic{
+				Uid:       uid,
+				Followers: s.Followers,
+				Followees: s.Followees,
+			}, nil
+		},
+		Set: c.cache.SetStatistic,
+	})
+}
+
+func (c *CachedFollowRepository) toDomain(r dao.FollowRelation) domain.FollowRelation {
+	return domain.FollowRelation{
+		Id:       r.Id,
+		Follower: r.Follower,
+		Followee: r.Followee,
+		Ctime:    r.Ctime,
+	}
+}
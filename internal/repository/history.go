@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository/dao"
+)
+
+// HistoryRepository 阅读历史，每个人只看自己的，直接查数据库
+type HistoryRepository interface {
+	Record(ctx context.Context, hs []domain.ReadHistory) error
+	UpdateProgress(ctx context.Context, uid int64, artId int64, progress uint8) error
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.ReadHistory, error)
+	ListUnfinished(ctx context.Context, uid int64, maxProgress uint8, since time.Time, limit int) ([]domain.ReadHistory, error)
+	Delete(ctx context.Context, uid int64, artIds []int64) error
+	// PausedIn uids 里面暂停了阅读历史的
+	PausedIn(ctx context.Context, uids []int64) (map[int64]struct{}, error)
+	Paused(ctx context.Context, uid int64) (bool, error)
+	SetPaused(ctx context.Context, uid int64, paused bool) error
+}
+
+type DBHistoryRepository struct {
+	dao dao.HistoryDAO
+}
+
+func NewDBHistoryRepository(dao dao.HistoryDAO) HistoryRepository {
+	return &DBHistoryRepository{
+		dao: dao,
+	}
+}
+
+func (d *DBHistoryRepository) Record(ctx context.Context, hs []domain.ReadHistory) error {
+	return d.dao.Record(ctx, slice.Map[domain.ReadHistory, dao.ReadHistory](hs, func(idx int, src domain.ReadHistory) dao.ReadHistory {
+		return dao.ReadHistory{
+			Uid:   src.Uid,
+			ArtId: src.ArtId,
+		}
+	}))
+}
+
+func (d *DBHistoryRepository) UpdateProgress(ctx context.Context, uid int64, artId int64, progress uint8) error {
+	return d.dao.UpdateProgress(ctx, uid, artId, progress)
+}
+
+func (d *DBHistoryRepository) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.ReadHistory, error) {
+	hs, err := d.dao.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return d.toDomains(hs), nil
+}
+
+func (d *DBHistoryRepository) ListUnfinished(ctx context.Context, uid int64, maxProgress uint8, since time.Time, limit int) ([]domain.ReadHistory, error) {
+	hs, err := d.dao.ListUnfinished(ctx, uid, maxProgress, since.UnixMilli(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return d.toDomains(hs), nil
+}
+
+func (d *DBHistoryRepository) Delete(ctx context.Context, uid int64, artIds []int64) error {
+	return d.dao.Delete(ctx, uid, artIds)
+}
+
+func (d *DBHistoryRepository) PausedIn(ctx context.Context, uids []int64) (map[int64]struct{}, error) {
+	paused, err := d.dao.FindPaused(ctx, uids)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[int64]struct{}, len(paused))
+	for _, uid := range paused {
+		res[uid] = struct{}{}
+	}
+	return res, nil
+}
+
+func (d *DBHistoryRepository) Paused(ctx context.Context, uid int64) (bool, error) {
+	paused, err := d.dao.FindPaused(ctx, []int64{uid})
+	return len(paused) > 0, err
+}
+
+func (d *DBHistoryRepository) SetPaused(ctx context.Context, uid int64, paused bool) error {
+	return d.dao.SetPaused(ctx, uid, paused)
+}
+
+func (d *DBHistoryRepository) toDomains(hs []dao.ReadHistory) []domain.ReadHistory {
+	return slice.Map[dao.ReadHistory, domain.ReadHistory](hs, func(idx int, src dao.ReadHistory) domain.ReadHistory {
+		return domain.ReadHistory{
+			Uid:      src.Uid,
+			ArtId:    src.ArtId,
+			Progress: src.Progress,
+			Ctime:    src.Ctime,
+			Utime:    src.Utime,
+		}
+	})
+}
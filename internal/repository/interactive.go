@@ -2,24 +2,68 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/google/uuid"
+	"time"
 	"webook/internal/domain"
 	"webook/internal/repository/cache"
 	"webook/internal/repository/dao"
+	"webook/pkg/cachex"
+	"webook/pkg/logger"
 )
 
 type InteractiveRepository interface {
-	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
-	IncrLike(ctx context.Context, biz string, bizId int64, uid int64) error
-	DecrLike(ctx context.Context, biz string, bizId int64, uid int64) error
-	AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	// IncrReadCnt 同一个用户在去重窗口内的重复阅读不计入阅读数，但是会计入独立读者
+	IncrReadCnt(ctx context.Context, biz string, bizId int64, uid int64) error
+	// BatchIncrReadCnt bizs、bizIds 和 uids 一一对应，同一个资源可以出现多次
+	BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, uids []int64) error
+	// AddReaction 点表情，默认表情就是点赞，重复点不会重复计数，返回这次是不是新点的
+	AddReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) (bool, error)
+	DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+	// Reactions 用户对这个资源点过的表情
+	Reactions(ctx context.Context, biz string, bizId int64, uid int64) ([]string, error)
+	// AddCollectionItem 返回是不是第一次收藏，已经在别的收藏夹里面的不算
+	AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) (bool, error)
+	DeleteCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	MoveCollectionItem(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error
 	GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error)
 	Liked(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
 	Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error)
+	// GetInteractives 批量获取计数和 uid 是否点赞、收藏，uid 为 0 的时候不查点赞收藏
+	GetInteractives(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error)
+	LikeRecords(ctx context.Context, biz string, uid int64, offset int, limit int) ([]domain.InteractiveRecord, error)
+	CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool, offset int, limit int) ([]domain.InteractiveRecord, error)
+	// FlushReadCnt 把缓冲的阅读数刷到数据库，返回刷了多少个资源
+	FlushReadCnt(ctx context.Context) (int, error)
+	// ReadCntDepth 缓冲区里面待刷新的资源个数
+	ReadCntDepth(ctx context.Context) (int64, error)
+	// FlushReaderCnt 把有新读者的资源的独立读者数持久化到数据库，返回更新了多少个
+	FlushReaderCnt(ctx context.Context) (int, error)
+	// ReaderCnt 实时的独立读者数
+	ReaderCnt(ctx context.Context, biz string, bizId int64) (int64, error)
+	// DailyStats 按天的增量，多个资源的时候是它们的和，没有数据的日子不返回
+	DailyStats(ctx context.Context, biz string, bizIds []int64, startDay string, endDay string) ([]domain.InteractiveStat, error)
+	// SumInteractives 一批资源持久化了的计数之和，不包括还在缓冲区里面的阅读数
+	SumInteractives(ctx context.Context, biz string, bizIds []int64) (domain.InteractiveStat, error)
+	// TopInteractives 一批资源里面 field 最大的 limit 个，field 是 read_cnt 或者 like_cnt
+	TopInteractives(ctx context.Context, biz string, bizIds []int64, field string, limit int) ([]domain.Interactive, error)
 }
 
+// 刷新阅读数的锁要比一次刷新的时间长，进程崩溃之后最多过这么久别的实例会接手
+const readCntFlushLease = time.Minute
+
 type CachedInteractiveRepository struct {
-	dao   dao.InteractiveDAO
-	cache cache.InteractiveCache
+	dao     dao.InteractiveDAO
+	cache   cache.InteractiveCache
+	buffer  cache.ReadCntBuffer
+	readers cache.ReaderCache
+	aside   *cachex.Aside
+	// 点赞、收藏的时候顺便更新实时热度
+	trending TrendingRepository
+	// 刷新阅读数时锁的持有者，每个实例不一样
+	owner string
+	l     logger.Logger
 }
 
 func (c *CachedInteractiveRepository) Collected(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
@@ -47,58 +91,429 @@ func (c *CachedInteractiveRepository) Liked(ctx context.Context, biz string, biz
 }
 
 func (c *CachedInteractiveRepository) GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
-	intr, err := c.cache.GetInteractive(ctx, biz, bizId)
-	if err == nil {
-		return intr, nil
-	}
+	return cachex.Get(ctx, c.aside, cachex.Query[domain.Interactive]{
+		Key: fmt.Sprintf("interactive:%s:%d", biz, bizId),
+		Get: func(ctx context.Context) (domain.Interactive, error) {
+			return c.cache.GetInteractive(ctx, biz, bizId)
+		},
+		// 没有互动记录的时候回写全是 0 的计数，这就是空值，之后的计数变化直接加在上面
+		Load: func(ctx context.Context) (domain.Interactive, error) {
+			return c.loadInteractive(ctx, biz, bizId)
+		},
+	})
+}
+
+func (c *CachedInteractiveRepository) loadInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
 	ie, err := c.dao.GetInteractiveInfo(ctx, biz, bizId)
-	if err != nil {
+	switch err {
+	case nil:
+	case dao.ErrRecordNotFound:
+		ie = dao.Interactive{Biz: biz, BizId: bizId}
+	default:
 		return domain.Interactive{}, err
 	}
 	res := c.toDomain(ie)
-
+	reactions, err := c.reactionCnts(ctx, biz, []int64{bizId})
+	if err != nil {
+		return domain.Interactive{}, err
+	}
+	res.ReactionCnts = reactions[bizId]
+	// 数据库里面的阅读数不包括还在缓冲区里面的，加上之后才能回写缓存
+	pending, err := c.buffer.Pending(ctx, biz, []int64{bizId})
+	res.ReadCnt += pending[bizId]
+	if err != nil {
+		// 不知道缓冲区里面有多少，不能回写缓存
+		return res, nil
+	}
 	err = c.cache.SetInteractive(ctx, res)
 	if err != nil {
-		// 缓存失败，需要记录日志
+		c.l.Error("回写互动缓存失败", logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+	}
+	return res, nil
+}
+
+func (c *CachedInteractiveRepository) GetInteractives(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	cached, err := c.cache.GetInteractives(ctx, biz, bizIds)
+	if err != nil {
+		// 缓存出问题就全部去数据库查
+		cached = map[int64]domain.Interactive{}
+	}
+	// 点赞收藏没有缓存，uid 大于 0 的时候反正都要查数据库，计数顺便一起查出来
+	dbIds := bizIds
+	if uid <= 0 {
+		dbIds = make([]int64, 0, len(bizIds)-len(cached))
+		for _, bizId := range bizIds {
+			if _, ok := cached[bizId]; !ok {
+				dbIds = append(dbIds, bizId)
+			}
+		}
+	}
+	var ies []dao.UserInteractive
+	if len(dbIds) > 0 {
+		ies, err = c.dao.GetInteractiveInfos(ctx, biz, dbIds, uid)
+		if err != nil {
+			return nil, err
+		}
+	}
+	found := make(map[int64]dao.UserInteractive, len(ies))
+	for _, ie := range ies {
+		found[ie.BizId] = ie
+	}
+
+	missIds := make([]int64, 0, len(bizIds)-len(cached))
+	for _, bizId := range bizIds {
+		if _, ok := cached[bizId]; !ok {
+			missIds = append(missIds, bizId)
+		}
+	}
+	// 和 GetInteractive 一样，回写缓存的时候要加上缓冲区里面的阅读数
+	pending, er := c.buffer.Pending(ctx, biz, missIds)
+	reactions, err := c.reactionCnts(ctx, biz, missIds)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	misses := make([]domain.Interactive, 0, len(missIds))
+	for _, bizId := range bizIds {
+		intr, ok := cached[bizId]
+		ie, inDB := found[bizId]
+		if !ok {
+			if inDB {
+				intr = c.toDomain(ie.Interactive)
+			} else {
+				// 还没有互动记录，计数都是 0，也回写缓存，免得每次都打到数据库
+				intr = domain.Interactive{Biz: biz, BizId: bizId}
+			}
+			intr.ReadCnt += pending[bizId]
+			intr.ReactionCnts = reactions[bizId]
+			misses = append(misses, intr)
+		}
+		intr.Liked = ie.Liked
+		intr.Collected = ie.Collected
+		res[bizId] = intr
+	}
+	if er != nil {
+		// 阅读数不全，不能回写缓存
+		c.l.Error("查询缓冲区阅读数失败", logger.String("biz", biz), logger.Error(er))
+		return res, nil
+	}
+
+	err = c.cache.SetInteractives(ctx, misses)
+	if err != nil {
+		// 回写失败不影响返回结果
+		c.l.Error("批量回写互动缓存失败", logger.String("biz", biz), logger.Error(err))
+	}
+	return res, nil
+}
+
+func (c *CachedInteractiveRepository) LikeRecords(ctx context.Context, biz string, uid int64, offset int, limit int) ([]domain.InteractiveRecord, error) {
+	likes, err := c.dao.FindLikesByUid(ctx, biz, uid, offset, limit)
+	if err != nil {
+		return nil, err
 	}
-	return res, err
+	return slice.Map[dao.UserLikeBiz, domain.InteractiveRecord](likes, func(idx int, src dao.UserLikeBiz) domain.InteractiveRecord {
+		return domain.InteractiveRecord{
+			Uid:   src.Uid,
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: src.Ctime,
+			Utime: src.Utime,
+		}
+	}), nil
 }
 
-func (c *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
-	err := c.dao.InsertCollectionInfo(ctx, dao.UserCollectionBiz{
+func (c *CachedInteractiveRepository) CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool,
+	offset int, limit int) ([]domain.InteractiveRecord, error) {
+	cbs, err := c.dao.FindCollectionsByUid(ctx, biz, uid, onlyPublic, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserCollectionBiz, domain.InteractiveRecord](cbs, func(idx int, src dao.UserCollectionBiz) domain.InteractiveRecord {
+		return domain.InteractiveRecord{
+			Uid:   src.Uid,
+			Biz:   src.Biz,
+			BizId: src.BizId,
+			Ctime: src.Ctime,
+			Utime: src.Utime,
+		}
+	}), nil
+}
+
+func (c *CachedInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) (bool, error) {
+	incr, err := c.dao.InsertCollectionInfo(ctx, dao.UserCollectionBiz{
 		Biz:   biz,
 		BizId: bizId,
 		Cid:   cid,
 		Uid:   uid,
 	})
-	if err != nil {
+	if err != nil || !incr {
+		return false, err
+	}
+	c.logTrendingErr(c.trending.IncrCollect(ctx, biz, bizId, 1), biz, bizId)
+	return true, c.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) DeleteCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
+	decr, err := c.dao.DeleteCollectionInfo(ctx, biz, bizId, cid, uid)
+	if err != nil || !decr {
 		return err
 	}
-	return c.cache.IncrCollectCntIfPresent(ctx, biz, bizId)
+	c.logTrendingErr(c.trending.IncrCollect(ctx, biz, bizId, -1), biz, bizId)
+	return c.cache.DecrCollectCntIfPresent(ctx, biz, bizId)
 }
 
-func (c *CachedInteractiveRepository) IncrLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	err := c.dao.InsertLikeInfo(ctx, biz, bizId, uid)
-	if err != nil {
+func (c *CachedInteractiveRepository) MoveCollectionItem(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error {
+	return c.dao.MoveCollectionInfo(ctx, biz, bizId, fromCid, toCid, uid)
+}
+
+func (c *CachedInteractiveRepository) AddReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) (bool, error) {
+	incr, err := c.dao.InsertReaction(ctx, biz, bizId, uid, reaction)
+	if err != nil || !incr {
+		return false, err
+	}
+	if reaction == domain.ReactionLike {
+		c.logTrendingErr(c.trending.IncrLike(ctx, biz, bizId, 1), biz, bizId)
+	}
+	return true, c.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, 1)
+}
+
+func (c *CachedInteractiveRepository) DeleteReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	decr, err := c.dao.DeleteReaction(ctx, biz, bizId, uid, reaction)
+	if err != nil || !decr {
 		return err
 	}
-	return c.cache.IncrLikeCntIfPresent(ctx, biz, bizId)
+	if reaction == domain.ReactionLike {
+		c.logTrendingErr(c.trending.IncrLike(ctx, biz, bizId, -1), biz, bizId)
+	}
+	return c.cache.IncrReactionCntIfPresent(ctx, biz, bizId, reaction, -1)
 }
 
-func (c *CachedInteractiveRepository) DecrLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	err := c.dao.DeleteLikeInfo(ctx, biz, bizId, uid)
+func (c *CachedInteractiveRepository) Reactions(ctx context.Context, biz string, bizId int64, uid int64) ([]string, error) {
+	rs, err := c.dao.GetReactions(ctx, biz, bizId, uid)
 	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.UserReactionBiz, string](rs, func(idx int, src dao.UserReactionBiz) string {
+		return src.Reaction
+	}), nil
+}
+
+// logTrendingErr 实时热度只是参考，失败了不影响阅读、点赞、收藏，只记日志
+func (c *CachedInteractiveRepository) logTrendingErr(err error, biz string, bizId int64) {
+	if err != nil {
+		c.l.Error("更新实时热度失败", logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+	}
+}
+
+// reactionCnts 缓存没有命中的时候从数据库里面查每种表情的数量
+func (c *CachedInteractiveRepository) reactionCnts(ctx context.Context, biz string, bizIds []int64) (map[int64]map[string]int64, error) {
+	res := make(map[int64]map[string]int64, len(bizIds))
+	if len(bizIds) == 0 {
+		return res, nil
+	}
+	rs, err := c.dao.GetReactionCnts(ctx, biz, bizIds)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rs {
+		cnts, ok := res[r.BizId]
+		if !ok {
+			cnts = make(map[string]int64)
+			res[r.BizId] = cnts
+		}
+		cnts[r.Reaction] = r.Cnt
+	}
+	return res, nil
+}
+
+// IncrReadCnt 阅读数先记到缓冲区，由 FlushReadCnt 批量写到数据库
+// 缓存里面的计数直接加，所以读到的阅读数是实时的
+func (c *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64, uid int64) error {
+	counted, err := c.readers.Record(ctx, biz, bizId, uid)
+	if err != nil || !counted {
 		return err
 	}
-	return c.cache.DecrLikeCntIfPresent(ctx, biz, bizId)
+	err = c.buffer.Add(ctx, biz, bizId, 1)
+	if err != nil {
+		c.forgetReads(ctx, []string{biz}, []int64{bizId}, []int64{uid})
+		return err
+	}
+	c.logTrendingErr(c.trending.IncrRead(ctx, biz, []int64{bizId}), biz, bizId)
+	// 缓冲区已经记上了，这里再返回错误重试也只会被去重掉
+	err = c.cache.IncrReadCntIfPresent(ctx, biz, bizId)
+	if err != nil {
+		c.l.Error("更新缓存阅读数失败", logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+	}
+	return nil
 }
 
-func (c *CachedInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
-	err := c.dao.IncrReadCnt(ctx, biz, bizId)
+func (c *CachedInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds []int64, uids []int64) error {
+	counted, err := c.readers.BatchRecord(ctx, bizs, bizIds, uids)
 	if err != nil {
 		return err
 	}
-	return c.cache.IncrReadCntIfPresent(ctx, biz, bizId)
+	// 先在本地把同一个资源的合并掉
+	type key struct {
+		biz   string
+		bizId int64
+	}
+	idx := make(map[key]int, len(bizs))
+	deltas := make([]cache.ReadCntDelta, 0, len(bizs))
+	countedBizs := make([]string, 0, len(bizs))
+	countedBizIds := make([]int64, 0, len(bizs))
+	countedUids := make([]int64, 0, len(bizs))
+	for i := range bizs {
+		if !counted[i] {
+			continue
+		}
+		countedBizs = append(countedBizs, bizs[i])
+		countedBizIds = append(countedBizIds, bizIds[i])
+		countedUids = append(countedUids, uids[i])
+		k := key{biz: bizs[i], bizId: bizIds[i]}
+		if j, ok := idx[k]; ok {
+			deltas[j].Cnt++
+			continue
+		}
+		idx[k] = len(deltas)
+		deltas = append(deltas, cache.ReadCntDelta{Biz: bizs[i], BizId: bizIds[i], Cnt: 1})
+	}
+	err = c.buffer.AddBatch(ctx, deltas)
+	if err != nil {
+		c.forgetReads(ctx, countedBizs, countedBizIds, countedUids)
+		return err
+	}
+	// 只有真正计入阅读数的才算热度，去重掉的不算
+	c.incrTrendingReads(ctx, deltas)
+	cBizs := make([]string, 0, len(deltas))
+	cBizIds := make([]int64, 0, len(deltas))
+	cnts := make([]int64, 0, len(deltas))
+	for _, d := range deltas {
+		cBizs = append(cBizs, d.Biz)
+		cBizIds = append(cBizIds, d.BizId)
+		cnts = append(cnts, d.Cnt)
+	}
+	err = c.cache.BatchIncrReadCntIfPresent(ctx, cBizs, cBizIds, cnts)
+	if err != nil {
+		c.l.Error("批量更新缓存阅读数失败", logger.Int("cnt", len(deltas)), logger.Error(err))
+	}
+	return nil
+}
+
+func (c *CachedInteractiveRepository) incrTrendingReads(ctx context.Context, deltas []cache.ReadCntDelta) {
+	bizIds := make(map[string][]int64, 1)
+	for _, d := range deltas {
+		for j := int64(0); j < d.Cnt; j++ {
+			bizIds[d.Biz] = append(bizIds[d.Biz], d.BizId)
+		}
+	}
+	for biz, ids := range bizIds {
+		err := c.trending.IncrRead(ctx, biz, ids)
+		if err != nil {
+			c.l.Error("更新实时热度失败", logger.String("biz", biz), logger.Int("cnt", len(ids)), logger.Error(err))
+		}
+	}
+}
+
+// forgetReads 阅读数没有记上，把去重标记删掉，让重试的时候还能计入
+func (c *CachedInteractiveRepository) forgetReads(ctx context.Context, bizs []string, bizIds []int64, uids []int64) {
+	err := c.readers.Forget(ctx, bizs, bizIds, uids)
+	if err != nil {
+		c.l.Error("删除阅读去重标记失败", logger.Int("cnt", len(bizs)), logger.Error(err))
+	}
+}
+
+func (c *CachedInteractiveRepository) FlushReadCnt(ctx context.Context) (int, error) {
+	deltas, err := c.buffer.Take(ctx, c.owner, readCntFlushLease)
+	if err != nil || len(deltas) == 0 {
+		return 0, err
+	}
+	err = c.dao.BatchIncrReadCnt(ctx, slice.Map[cache.ReadCntDelta, dao.Interactive](deltas,
+		func(idx int, src cache.ReadCntDelta) dao.Interactive {
+			return dao.Interactive{
+				Biz:     src.Biz,
+				BizId:   src.BizId,
+				ReadCnt: src.Cnt,
+			}
+		}))
+	if err != nil {
+		// 这一批留在缓冲区里面，下次再刷
+		_ = c.buffer.Ack(ctx, c.owner, false)
+		return 0, err
+	}
+	return len(deltas), c.buffer.Ack(ctx, c.owner, true)
+}
+
+func (c *CachedInteractiveRepository) FlushReaderCnt(ctx context.Context) (int, error) {
+	// 一次最多 1000 个，剩下的下一次
+	cnts, err := c.readers.TakeDirty(ctx, 1000)
+	if err != nil || len(cnts) == 0 {
+		return 0, err
+	}
+	err = c.dao.BatchSetReaderCnt(ctx, slice.Map[cache.ReaderCnt, dao.Interactive](cnts,
+		func(idx int, src cache.ReaderCnt) dao.Interactive {
+			return dao.Interactive{
+				Biz:       src.Biz,
+				BizId:     src.BizId,
+				ReaderCnt: src.Cnt,
+			}
+		}))
+	if err != nil {
+		_ = c.readers.MarkDirty(ctx, cnts)
+		return 0, err
+	}
+	return len(cnts), nil
+}
+
+func (c *CachedInteractiveRepository) ReaderCnt(ctx context.Context, biz string, bizId int64) (int64, error) {
+	return c.readers.Count(ctx, biz, bizId)
+}
+
+func (c *CachedInteractiveRepository) ReadCntDepth(ctx context.Context) (int64, error) {
+	return c.buffer.Depth(ctx)
+}
+
+func (c *CachedInteractiveRepository) DailyStats(ctx context.Context, biz string, bizIds []int64,
+	startDay string, endDay string) ([]domain.InteractiveStat, error) {
+	dailies, err := c.dao.FindDaily(ctx, biz, bizIds, startDay, endDay)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.InteractiveDaily, domain.InteractiveStat](dailies,
+		func(idx int, src dao.InteractiveDaily) domain.InteractiveStat {
+			return domain.InteractiveStat{
+				Date:       src.Day,
+				ReadCnt:    src.ReadCnt,
+				LikeCnt:    src.LikeCnt,
+				CollectCnt: src.CollectCnt,
+				CommentCnt: src.CommentCnt,
+			}
+		}), nil
+}
+
+func (c *CachedInteractiveRepository) SumInteractives(ctx context.Context, biz string,
+	bizIds []int64) (domain.InteractiveStat, error) {
+	sum, err := c.dao.SumByBizIds(ctx, biz, bizIds)
+	if err != nil {
+		return domain.InteractiveStat{}, err
+	}
+	return domain.InteractiveStat{
+		ReadCnt:    sum.ReadCnt,
+		LikeCnt:    sum.LikeCnt,
+		CollectCnt: sum.CollectCnt,
+		CommentCnt: sum.CommentCnt,
+	}, nil
+}
+
+func (c *CachedInteractiveRepository) TopInteractives(ctx context.Context, biz string, bizIds []int64,
+	field string, limit int) ([]domain.Interactive, error) {
+	intrs, err := c.dao.TopByBizIds(ctx, biz, bizIds, field, limit)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Interactive, domain.Interactive](intrs, func(idx int, src dao.Interactive) domain.Interactive {
+		return c.toDomain(src)
+	}), nil
 }
 
 func (c *CachedInteractiveRepository) toDomain(ie dao.Interactive) domain.Interactive {
@@ -109,12 +524,23 @@ func (c *CachedInteractiveRepository) toDomain(ie dao.Interactive) domain.Intera
 		LikeCnt:    ie.LikeCnt,
 		ReadCnt:    ie.ReadCnt,
 		CollectCnt: ie.CollectCnt,
+		CommentCnt: ie.CommentCnt,
+		ShareCnt:   ie.ShareCnt,
+		ReaderCnt:  ie.ReaderCnt,
 	}
 }
 
-func NewCachedInteractiveRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache) InteractiveRepository {
+func NewCachedInteractiveRepository(dao dao.InteractiveDAO, cache cache.InteractiveCache,
+	buffer cache.ReadCntBuffer, readers cache.ReaderCache, aside *cachex.Aside,
+	trending TrendingRepository, l logger.Logger) InteractiveRepository {
 	return &CachedInteractiveRepository{
-		dao:   dao,
-		cache: cache,
+		dao:      dao,
+		cache:    cache,
+		buffer:   buffer,
+		readers:  readers,
+		aside:    aside,
+		trending: trending,
+		owner:    uuid.New().String(),
+		l:        l,
 	}
 }
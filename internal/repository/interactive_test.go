@@ -0,0 +1,286 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	cachemocks "webook/internal/repository/cache/mocks"
+	"webook/internal/repository/dao"
+	daomocks "webook/internal/repository/dao/mocks"
+	"webook/pkg/logger"
+)
+
+func TestCachedInteractiveRepository_GetInteractives(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer)
+		uid  int64
+
+		wantRes map[int64]domain.Interactive
+		wantErr error
+	}{
+		{
+			name: "全部命中缓存",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				c.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{
+						1: {Biz: "article", BizId: 1, ReadCnt: 10},
+						2: {Biz: "article", BizId: 2, ReadCnt: 20},
+					}, nil)
+				buffer.EXPECT().Pending(gomock.Any(), "article", []int64{}).Return(map[int64]int64{}, nil)
+				c.EXPECT().SetInteractives(gomock.Any(), []domain.Interactive{}).Return(nil)
+				return d, c, buffer
+			},
+			wantRes: map[int64]domain.Interactive{
+				1: {Biz: "article", BizId: 1, ReadCnt: 10},
+				2: {Biz: "article", BizId: 2, ReadCnt: 20},
+			},
+		},
+		{
+			name: "全部未命中，加上缓冲区阅读数回写",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				c.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(nil, errors.New("redis错误"))
+				d.EXPECT().GetInteractiveInfos(gomock.Any(), "article", []int64{1, 2}, int64(0)).
+					Return([]dao.UserInteractive{
+						{Interactive: dao.Interactive{Biz: "article", BizId: 1, ReadCnt: 5, LikeCnt: 3}},
+					}, nil)
+				buffer.EXPECT().Pending(gomock.Any(), "article", []int64{1, 2}).Return(map[int64]int64{1: 2}, nil)
+				d.EXPECT().GetReactionCnts(gomock.Any(), "article", []int64{1, 2}).
+					Return([]dao.InteractiveReaction{{Biz: "article", BizId: 1, Reaction: "like", Cnt: 3}}, nil)
+				c.EXPECT().SetInteractives(gomock.Any(), []domain.Interactive{
+					{Biz: "article", BizId: 1, ReadCnt: 7, LikeCnt: 3, ReactionCnts: map[string]int64{"like": 3}},
+					// 还没有互动记录也回写
+					{Biz: "article", BizId: 2},
+				}).Return(nil)
+				return d, c, buffer
+			},
+			wantRes: map[int64]domain.Interactive{
+				1: {Biz: "article", BizId: 1, ReadCnt: 7, LikeCnt: 3, ReactionCnts: map[string]int64{"like": 3}},
+				2: {Biz: "article", BizId: 2},
+			},
+		},
+		{
+			name: "部分未命中，只查没命中的",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				c.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{
+						1: {Biz: "article", BizId: 1, ReadCnt: 10},
+					}, nil)
+				d.EXPECT().GetInteractiveInfos(gomock.Any(), "article", []int64{2}, int64(0)).
+					Return([]dao.UserInteractive{
+						{Interactive: dao.Interactive{Biz: "article", BizId: 2, ReadCnt: 20}},
+					}, nil)
+				buffer.EXPECT().Pending(gomock.Any(), "article", []int64{2}).Return(map[int64]int64{}, nil)
+				d.EXPECT().GetReactionCnts(gomock.Any(), "article", []int64{2}).Return(nil, nil)
+				c.EXPECT().SetInteractives(gomock.Any(), []domain.Interactive{
+					{Biz: "article", BizId: 2, ReadCnt: 20},
+				}).Return(nil)
+				return d, c, buffer
+			},
+			wantRes: map[int64]domain.Interactive{
+				1: {Biz: "article", BizId: 1, ReadCnt: 10},
+				2: {Biz: "article", BizId: 2, ReadCnt: 20},
+			},
+		},
+		{
+			name: "登录用户命中缓存也要查点赞收藏",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				c.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{
+						1: {Biz: "article", BizId: 1, ReadCnt: 10},
+						2: {Biz: "article", BizId: 2, ReadCnt: 20},
+					}, nil)
+				// 数据库里面的计数比缓存旧，以缓存为准
+				d.EXPECT().GetInteractiveInfos(gomock.Any(), "article", []int64{1, 2}, int64(3)).
+					Return([]dao.UserInteractive{
+						{Interactive: dao.Interactive{Biz: "article", BizId: 1, ReadCnt: 9}, Liked: true},
+						{Interactive: dao.Interactive{Biz: "article", BizId: 2, ReadCnt: 19}, Collected: true},
+					}, nil)
+				buffer.EXPECT().Pending(gomock.Any(), "article", []int64{}).Return(map[int64]int64{}, nil)
+				c.EXPECT().SetInteractives(gomock.Any(), []domain.Interactive{}).Return(nil)
+				return d, c, buffer
+			},
+			uid: 3,
+			wantRes: map[int64]domain.Interactive{
+				1: {Biz: "article", BizId: 1, ReadCnt: 10, Liked: true},
+				2: {Biz: "article", BizId: 2, ReadCnt: 20, Collected: true},
+			},
+		},
+		{
+			name: "缓冲区查询失败不回写",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				c.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{
+						1: {Biz: "article", BizId: 1, ReadCnt: 10},
+					}, nil)
+				d.EXPECT().GetInteractiveInfos(gomock.Any(), "article", []int64{2}, int64(0)).
+					Return([]dao.UserInteractive{
+						{Interactive: dao.Interactive{Biz: "article", BizId: 2, ReadCnt: 20}},
+					}, nil)
+				buffer.EXPECT().Pending(gomock.Any(), "article", []int64{2}).Return(nil, errors.New("redis错误"))
+				d.EXPECT().GetReactionCnts(gomock.Any(), "article", []int64{2}).Return(nil, nil)
+				return d, c, buffer
+			},
+			wantRes: map[int64]domain.Interactive{
+				1: {Biz: "article", BizId: 1, ReadCnt: 10},
+				2: {Biz: "article", BizId: 2, ReadCnt: 20},
+			},
+		},
+		{
+			name: "数据库查询失败",
+			mock: func(ctrl *gomock.Controller) (dao.InteractiveDAO, cache.InteractiveCache, cache.ReadCntBuffer) {
+				d := daomocks.NewMockInteractiveDAO(ctrl)
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				c.EXPECT().GetInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(map[int64]domain.Interactive{}, nil)
+				d.EXPECT().GetInteractiveInfos(gomock.Any(), "article", []int64{1, 2}, int64(0)).
+					Return(nil, errors.New("mock db error"))
+				return d, c, buffer
+			},
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			d, c, buffer := tc.mock(ctrl)
+			repo := NewCachedInteractiveRepository(d, c, buffer, nil, nil, nil, logger.NewNopLogger())
+			res, err := repo.GetInteractives(context.Background(), "article", []int64{1, 2}, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestCachedInteractiveRepository_BatchIncrReadCnt(t *testing.T) {
+	bizs := []string{"article", "article", "article"}
+	bizIds := []int64{1, 1, 2}
+	uids := []int64{10, 10, 11}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (cache.InteractiveCache, cache.ReadCntBuffer, cache.ReaderCache)
+		// 第几次调用返回什么错误
+		wantErrs []error
+		// 计入实时热度的阅读
+		wantTrending []int64
+	}{
+		{
+			name: "重复阅读只计一次",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, cache.ReadCntBuffer, cache.ReaderCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				readers := cachemocks.NewMockReaderCache(ctrl)
+				readers.EXPECT().BatchRecord(gomock.Any(), bizs, bizIds, uids).Return([]bool{true, false, true}, nil)
+				buffer.EXPECT().AddBatch(gomock.Any(), []cache.ReadCntDelta{
+					{Biz: "article", BizId: 1, Cnt: 1},
+					{Biz: "article", BizId: 2, Cnt: 1},
+				}).Return(nil)
+				c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(), []string{"article", "article"},
+					[]int64{1, 2}, []int64{1, 1}).Return(nil)
+				return c, buffer, readers
+			},
+			wantErrs:     []error{nil},
+			wantTrending: []int64{1, 2},
+		},
+		{
+			name: "写缓冲区失败一次，删掉去重标记之后重试还能计入",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, cache.ReadCntBuffer, cache.ReaderCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				readers := cachemocks.NewMockReaderCache(ctrl)
+				deltas := []cache.ReadCntDelta{
+					{Biz: "article", BizId: 1, Cnt: 1},
+					{Biz: "article", BizId: 2, Cnt: 1},
+				}
+				gomock.InOrder(
+					readers.EXPECT().BatchRecord(gomock.Any(), bizs, bizIds, uids).Return([]bool{true, false, true}, nil),
+					buffer.EXPECT().AddBatch(gomock.Any(), deltas).Return(errors.New("redis错误")),
+					// 只删这次计入的
+					readers.EXPECT().Forget(gomock.Any(), []string{"article", "article"},
+						[]int64{1, 2}, []int64{10, 11}).Return(nil),
+					readers.EXPECT().BatchRecord(gomock.Any(), bizs, bizIds, uids).Return([]bool{true, false, true}, nil),
+					buffer.EXPECT().AddBatch(gomock.Any(), deltas).Return(nil),
+					c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(), []string{"article", "article"},
+						[]int64{1, 2}, []int64{1, 1}).Return(nil),
+				)
+				return c, buffer, readers
+			},
+			wantErrs: []error{errors.New("redis错误"), nil},
+			// 失败的那次不算
+			wantTrending: []int64{1, 2},
+		},
+		{
+			name: "缓冲区写成功之后更新缓存失败不重试",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, cache.ReadCntBuffer, cache.ReaderCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				readers := cachemocks.NewMockReaderCache(ctrl)
+				readers.EXPECT().BatchRecord(gomock.Any(), bizs, bizIds, uids).Return([]bool{true, false, true}, nil)
+				buffer.EXPECT().AddBatch(gomock.Any(), gomock.Any()).Return(nil)
+				c.EXPECT().BatchIncrReadCntIfPresent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("redis错误"))
+				return c, buffer, readers
+			},
+			wantErrs:     []error{nil},
+			wantTrending: []int64{1, 2},
+		},
+		{
+			name: "去重失败",
+			mock: func(ctrl *gomock.Controller) (cache.InteractiveCache, cache.ReadCntBuffer, cache.ReaderCache) {
+				c := cachemocks.NewMockInteractiveCache(ctrl)
+				buffer := cachemocks.NewMockReadCntBuffer(ctrl)
+				readers := cachemocks.NewMockReaderCache(ctrl)
+				readers.EXPECT().BatchRecord(gomock.Any(), bizs, bizIds, uids).Return(nil, errors.New("redis错误"))
+				return c, buffer, readers
+			},
+			wantErrs: []error{errors.New("redis错误")},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c, buffer, readers := tc.mock(ctrl)
+			trending := &readTrendingRepository{}
+			repo := NewCachedInteractiveRepository(nil, c, buffer, readers, nil, trending, logger.NewNopLogger())
+			for _, wantErr := range tc.wantErrs {
+				err := repo.BatchIncrReadCnt(context.Background(), bizs, bizIds, uids)
+				assert.Equal(t, wantErr, err)
+			}
+			assert.Equal(t, tc.wantTrending, trending.reads)
+		})
+	}
+}
+
+// readTrendingRepository 只记录计入热度的阅读
+type readTrendingRepository struct {
+	TrendingRepository
+	reads []int64
+}
+
+func (r *readTrendingRepository) IncrRead(ctx context.Context, biz string, bizIds []int64) error {
+	r.reads = append(r.reads, bizIds...)
+	return nil
+}
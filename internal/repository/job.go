@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository/dao"
+)
+
+var (
+	// ErrNoJob 没有到期的任务可以抢
+	ErrNoJob            = dao.ErrRecordNotFound
+	ErrJobNotFound      = dao.ErrRecordNotFound
+	ErrJobNotHold       = dao.ErrJobNotHold
+	ErrJobStatusChanged = dao.ErrJobStatusChanged
+)
+
+type JobRepository interface {
+	Upsert(ctx context.Context, j domain.Job) error
+	Preempt(ctx context.Context, owner string, timeout time.Duration) (domain.Job, error)
+	Heartbeat(ctx context.Context, id int64, owner string) error
+	Release(ctx context.Context, id int64, owner string, next time.Time) error
+	FindById(ctx context.Context, id int64) (domain.Job, error)
+	List(ctx context.Context) ([]domain.Job, error)
+	Pause(ctx context.Context, id int64) error
+	Resume(ctx context.Context, id int64, next time.Time) error
+	Trigger(ctx context.Context, id int64) error
+}
+
+type PreemptJobRepository struct {
+	dao dao.JobDAO
+}
+
+func NewPreemptJobRepository(dao dao.JobDAO) JobRepository {
+	return &PreemptJobRepository{
+		dao: dao,
+	}
+}
+
+func (p *PreemptJobRepository) Upsert(ctx context.Context, j domain.Job) error {
+	return p.dao.Upsert(ctx, p.toEntity(j))
+}
+
+func (p *PreemptJobRepository) Preempt(ctx context.Context, owner string, timeout time.Duration) (domain.Job, error) {
+	j, err := p.dao.Preempt(ctx, owner, timeout)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return p.toDomain(j), nil
+}
+
+func (p *PreemptJobRepository) Heartbeat(ctx context.Context, id int64, owner string) error {
+	return p.dao.Heartbeat(ctx, id, owner)
+}
+
+func (p *PreemptJobRepository) Release(ctx context.Context, id int64, owner string, next time.Time) error {
+	return p.dao.Release(ctx, id, owner, next.UnixMilli())
+}
+
+func (p *PreemptJobRepository) FindById(ctx context.Context, id int64) (domain.Job, error) {
+	j, err := p.dao.FindById(ctx, id)
+	if err != nil {
+		return domain.Job{}, err
+	}
+	return p.toDomain(j), nil
+}
+
+func (p *PreemptJobRepository) List(ctx context.Context) ([]domain.Job, error) {
+	jobs, err := p.dao.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map(jobs, func(idx int, src dao.Job) domain.Job {
+		return p.toDomain(src)
+	}), nil
+}
+
+func (p *PreemptJobRepository) Pause(ctx context.Context, id int64) error {
+	return p.dao.Pause(ctx, id)
+}
+
+func (p *PreemptJobRepository) Resume(ctx context.Context, id int64, next time.Time) error {
+	return p.dao.Resume(ctx, id, next.UnixMilli())
+}
+
+func (p *PreemptJobRepository) Trigger(ctx context.Context, id int64) error {
+	return p.dao.Trigger(ctx, id)
+}
+
+func (p *PreemptJobRepository) toEntity(j domain.Job) dao.Job {
+	return dao.Job{
+		Id:         j.Id,
+		Name:       j.Name,
+		Executor:   j.Executor,
+		Cfg:        j.Cfg,
+		Expression: j.Expression,
+		Status:     j.Status.ToUint8(),
+		NextTime:   j.NextTime,
+		Owner:      j.Owner,
+		Heartbeat:  j.Heartbeat,
+		Version:    j.Version,
+		Ctime:      j.Ctime,
+		Utime:      j.Utime,
+	}
+}
+
+func (p *PreemptJobRepository) toDomain(j dao.Job) domain.Job {
+	return domain.Job{
+		Id:         j.Id,
+		Name:       j.Name,
+		Executor:   j.Executor,
+		Cfg:        j.Cfg,
+		Expression: j.Expression,
+		Status:     domain.JobStatus(j.Status),
+		NextTime:   j.NextTime,
+		Owner:      j.Owner,
+		Heartbeat:  j.Heartbeat,
+		Version:    j.Version,
+		Ctime:      j.Ctime,
+		Utime:      j.Utime,
+	}
+}
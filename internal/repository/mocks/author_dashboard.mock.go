@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/author_dashboard.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/author_dashboard.go -package=repomocks -destination=./internal/repository/mocks/author_dashboard.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockAuthorDashboardRepository is a mock of AuthorDashboardRepository interface.
+type MockAuthorDashboardRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthorDashboardRepositoryMockRecorder
+}
+
+// MockAuthorDashboardRepositoryMockRecorder is the mock recorder for MockAuthorDashboardRepository.
+type MockAuthorDashboardRepositoryMockRecorder struct {
+	mock *MockAuthorDashboardRepository
+}
+
+// NewMockAuthorDashboardRepository creates a new mock instance.
+func NewMockAuthorDashboardRepository(ctrl *gomock.Controller) *MockAuthorDashboardRepository {
+	mock := &MockAuthorDashboardRepository{ctrl: ctrl}
+	mock.recorder = &MockAuthorDashboardRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthorDashboardRepository) EXPECT() *MockAuthorDashboardRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockAuthorDashboardRepository) Get(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, uid, days)
+	ret0, _ := ret[0].(domain.AuthorDashboard)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAuthorDashboardRepositoryMockRecorder) Get(ctx, uid, days any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAuthorDashboardRepository)(nil).Get), ctx, uid, days)
+}
+
+// GetBase mocks base method.
+func (m *MockAuthorDashboardRepository) GetBase(ctx context.Context, uid int64) (domain.AuthorStatsBase, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBase", ctx, uid)
+	ret0, _ := ret[0].(domain.AuthorStatsBase)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBase indicates an expected call of GetBase.
+func (mr *MockAuthorDashboardRepositoryMockRecorder) GetBase(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBase", reflect.TypeOf((*MockAuthorDashboardRepository)(nil).GetBase), ctx, uid)
+}
+
+// Set mocks base method.
+func (m *MockAuthorDashboardRepository) Set(ctx context.Context, dash domain.AuthorDashboard) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, dash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockAuthorDashboardRepositoryMockRecorder) Set(ctx, dash any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockAuthorDashboardRepository)(nil).Set), ctx, dash)
+}
+
+// SetBase mocks base method.
+func (m *MockAuthorDashboardRepository) SetBase(ctx context.Context, base domain.AuthorStatsBase) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBase", ctx, base)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBase indicates an expected call of SetBase.
+func (mr *MockAuthorDashboardRepositoryMockRecorder) SetBase(ctx, base any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBase", reflect.TypeOf((*MockAuthorDashboardRepository)(nil).SetBase), ctx, base)
+}
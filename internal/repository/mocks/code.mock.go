@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen.exe -source=./internal/repository/code.go -package=repomocks -destination=./internal/repository/mocks/code.mock.go
+//	mockgen -source=./internal/repository/code.go -package=repomocks -destination=./internal/repository/mocks/code.mock.go
 //
 // Package repomocks is a generated GoMock package.
 package repomocks
@@ -0,0 +1,185 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/feed.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/feed.go -package=repomocks -destination=./internal/repository/mocks/feed.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockFeedRepository is a mock of FeedRepository interface.
+type MockFeedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFeedRepositoryMockRecorder
+}
+
+// MockFeedRepositoryMockRecorder is the mock recorder for MockFeedRepository.
+type MockFeedRepositoryMockRecorder struct {
+	mock *MockFeedRepository
+}
+
+// NewMockFeedRepository creates a new mock instance.
+func NewMockFeedRepository(ctrl *gomock.Controller) *MockFeedRepository {
+	mock := &MockFeedRepository{ctrl: ctrl}
+	mock.recorder = &MockFeedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFeedRepository) EXPECT() *MockFeedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddBigAuthor mocks base method.
+func (m *MockFeedRepository) AddBigAuthor(ctx context.Context, author int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBigAuthor", ctx, author)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddBigAuthor indicates an expected call of AddBigAuthor.
+func (mr *MockFeedRepositoryMockRecorder) AddBigAuthor(ctx, author any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBigAuthor", reflect.TypeOf((*MockFeedRepository)(nil).AddBigAuthor), ctx, author)
+}
+
+// AddOutbox mocks base method.
+func (m *MockFeedRepository) AddOutbox(ctx context.Context, author int64, item domain.FeedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOutbox", ctx, author, item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOutbox indicates an expected call of AddOutbox.
+func (mr *MockFeedRepositoryMockRecorder) AddOutbox(ctx, author, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOutbox", reflect.TypeOf((*MockFeedRepository)(nil).AddOutbox), ctx, author, item)
+}
+
+// BigAuthors mocks base method.
+func (m *MockFeedRepository) BigAuthors(ctx context.Context) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BigAuthors", ctx)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BigAuthors indicates an expected call of BigAuthors.
+func (mr *MockFeedRepositoryMockRecorder) BigAuthors(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BigAuthors", reflect.TypeOf((*MockFeedRepository)(nil).BigAuthors), ctx)
+}
+
+// Inbox mocks base method.
+func (m *MockFeedRepository) Inbox(ctx context.Context, uid, cursor int64, limit int) ([]domain.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Inbox", ctx, uid, cursor, limit)
+	ret0, _ := ret[0].([]domain.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Inbox indicates an expected call of Inbox.
+func (mr *MockFeedRepositoryMockRecorder) Inbox(ctx, uid, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Inbox", reflect.TypeOf((*MockFeedRepository)(nil).Inbox), ctx, uid, cursor, limit)
+}
+
+// Outboxes mocks base method.
+func (m *MockFeedRepository) Outboxes(ctx context.Context, authors []int64, cursor int64, limit int) ([]domain.FeedItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Outboxes", ctx, authors, cursor, limit)
+	ret0, _ := ret[0].([]domain.FeedItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Outboxes indicates an expected call of Outboxes.
+func (mr *MockFeedRepositoryMockRecorder) Outboxes(ctx, authors, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Outboxes", reflect.TypeOf((*MockFeedRepository)(nil).Outboxes), ctx, authors, cursor, limit)
+}
+
+// Push mocks base method.
+func (m *MockFeedRepository) Push(ctx context.Context, uids []int64, item domain.FeedItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Push", ctx, uids, item)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Push indicates an expected call of Push.
+func (mr *MockFeedRepositoryMockRecorder) Push(ctx, uids, item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Push", reflect.TypeOf((*MockFeedRepository)(nil).Push), ctx, uids, item)
+}
+
+// Rebuild mocks base method.
+func (m *MockFeedRepository) Rebuild(ctx context.Context, uid int64, items []domain.FeedItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rebuild", ctx, uid, items)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rebuild indicates an expected call of Rebuild.
+func (mr *MockFeedRepositoryMockRecorder) Rebuild(ctx, uid, items any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rebuild", reflect.TypeOf((*MockFeedRepository)(nil).Rebuild), ctx, uid, items)
+}
+
+// Remove mocks base method.
+func (m *MockFeedRepository) Remove(ctx context.Context, uids, artIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", ctx, uids, artIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockFeedRepositoryMockRecorder) Remove(ctx, uids, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockFeedRepository)(nil).Remove), ctx, uids, artIds)
+}
+
+// RemoveOutbox mocks base method.
+func (m *MockFeedRepository) RemoveOutbox(ctx context.Context, author, artId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveOutbox", ctx, author, artId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveOutbox indicates an expected call of RemoveOutbox.
+func (mr *MockFeedRepositoryMockRecorder) RemoveOutbox(ctx, author, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOutbox", reflect.TypeOf((*MockFeedRepository)(nil).RemoveOutbox), ctx, author, artId)
+}
+
+// Touch mocks base method.
+func (m *MockFeedRepository) Touch(ctx context.Context, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockFeedRepositoryMockRecorder) Touch(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockFeedRepository)(nil).Touch), ctx, uid)
+}
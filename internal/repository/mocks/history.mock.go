@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/history.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/history.go -package=repomocks -destination=./internal/repository/mocks/history.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockHistoryRepository is a mock of HistoryRepository interface.
+type MockHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryRepositoryMockRecorder
+}
+
+// MockHistoryRepositoryMockRecorder is the mock recorder for MockHistoryRepository.
+type MockHistoryRepositoryMockRecorder struct {
+	mock *MockHistoryRepository
+}
+
+// NewMockHistoryRepository creates a new mock instance.
+func NewMockHistoryRepository(ctrl *gomock.Controller) *MockHistoryRepository {
+	mock := &MockHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockHistoryRepository) EXPECT() *MockHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockHistoryRepository) Delete(ctx context.Context, uid int64, artIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, uid, artIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockHistoryRepositoryMockRecorder) Delete(ctx, uid, artIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockHistoryRepository)(nil).Delete), ctx, uid, artIds)
+}
+
+// List mocks base method.
+func (m *MockHistoryRepository) List(ctx context.Context, uid int64, offset, limit int) ([]domain.ReadHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.ReadHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockHistoryRepositoryMockRecorder) List(ctx, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockHistoryRepository)(nil).List), ctx, uid, offset, limit)
+}
+
+// ListUnfinished mocks base method.
+func (m *MockHistoryRepository) ListUnfinished(ctx context.Context, uid int64, maxProgress uint8, since time.Time, limit int) ([]domain.ReadHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUnfinished", ctx, uid, maxProgress, since, limit)
+	ret0, _ := ret[0].([]domain.ReadHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUnfinished indicates an expected call of ListUnfinished.
+func (mr *MockHistoryRepositoryMockRecorder) ListUnfinished(ctx, uid, maxProgress, since, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUnfinished", reflect.TypeOf((*MockHistoryRepository)(nil).ListUnfinished), ctx, uid, maxProgress, since, limit)
+}
+
+// Paused mocks base method.
+func (m *MockHistoryRepository) Paused(ctx context.Context, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Paused", ctx, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Paused indicates an expected call of Paused.
+func (mr *MockHistoryRepositoryMockRecorder) Paused(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Paused", reflect.TypeOf((*MockHistoryRepository)(nil).Paused), ctx, uid)
+}
+
+// PausedIn mocks base method.
+func (m *MockHistoryRepository) PausedIn(ctx context.Context, uids []int64) (map[int64]struct{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PausedIn", ctx, uids)
+	ret0, _ := ret[0].(map[int64]struct{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PausedIn indicates an expected call of PausedIn.
+func (mr *MockHistoryRepositoryMockRecorder) PausedIn(ctx, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PausedIn", reflect.TypeOf((*MockHistoryRepository)(nil).PausedIn), ctx, uids)
+}
+
+// Record mocks base method.
+func (m *MockHistoryRepository) Record(ctx context.Context, hs []domain.ReadHistory) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Record", ctx, hs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Record indicates an expected call of Record.
+func (mr *MockHistoryRepositoryMockRecorder) Record(ctx, hs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Record", reflect.TypeOf((*MockHistoryRepository)(nil).Record), ctx, hs)
+}
+
+// SetPaused mocks base method.
+func (m *MockHistoryRepository) SetPaused(ctx context.Context, uid int64, paused bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPaused", ctx, uid, paused)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPaused indicates an expected call of SetPaused.
+func (mr *MockHistoryRepositoryMockRecorder) SetPaused(ctx, uid, paused any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPaused", reflect.TypeOf((*MockHistoryRepository)(nil).SetPaused), ctx, uid, paused)
+}
+
+// UpdateProgress mocks base method.
+func (m *MockHistoryRepository) UpdateProgress(ctx context.Context, uid, artId int64, progress uint8) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProgress", ctx, uid, artId, progress)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProgress indicates an expected call of UpdateProgress.
+func (mr *MockHistoryRepositoryMockRecorder) UpdateProgress(ctx, uid, artId, progress any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProgress", reflect.TypeOf((*MockHistoryRepository)(nil).UpdateProgress), ctx, uid, artId, progress)
+}
@@ -0,0 +1,350 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/interactive.go -package=repomocks -destination=./internal/repository/mocks/interactive.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveRepository is a mock of InteractiveRepository interface.
+type MockInteractiveRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveRepositoryMockRecorder
+}
+
+// MockInteractiveRepositoryMockRecorder is the mock recorder for MockInteractiveRepository.
+type MockInteractiveRepositoryMockRecorder struct {
+	mock *MockInteractiveRepository
+}
+
+// NewMockInteractiveRepository creates a new mock instance.
+func NewMockInteractiveRepository(ctrl *gomock.Controller) *MockInteractiveRepository {
+	mock := &MockInteractiveRepository{ctrl: ctrl}
+	mock.recorder = &MockInteractiveRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveRepository) EXPECT() *MockInteractiveRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddCollectionItem mocks base method.
+func (m *MockInteractiveRepository) AddCollectionItem(ctx context.Context, biz string, bizId, cid, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollectionItem", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddCollectionItem indicates an expected call of AddCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) AddCollectionItem(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).AddCollectionItem), ctx, biz, bizId, cid, uid)
+}
+
+// AddReaction mocks base method.
+func (m *MockInteractiveRepository) AddReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockInteractiveRepositoryMockRecorder) AddReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockInteractiveRepository)(nil).AddReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// BatchIncrReadCnt mocks base method.
+func (m *MockInteractiveRepository) BatchIncrReadCnt(ctx context.Context, bizs []string, bizIds, uids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchIncrReadCnt", ctx, bizs, bizIds, uids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BatchIncrReadCnt indicates an expected call of BatchIncrReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) BatchIncrReadCnt(ctx, bizs, bizIds, uids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchIncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).BatchIncrReadCnt), ctx, bizs, bizIds, uids)
+}
+
+// CollectRecords mocks base method.
+func (m *MockInteractiveRepository) CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool, offset, limit int) ([]domain.InteractiveRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectRecords", ctx, biz, uid, onlyPublic, offset, limit)
+	ret0, _ := ret[0].([]domain.InteractiveRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectRecords indicates an expected call of CollectRecords.
+func (mr *MockInteractiveRepositoryMockRecorder) CollectRecords(ctx, biz, uid, onlyPublic, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectRecords", reflect.TypeOf((*MockInteractiveRepository)(nil).CollectRecords), ctx, biz, uid, onlyPublic, offset, limit)
+}
+
+// Collected mocks base method.
+func (m *MockInteractiveRepository) Collected(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Collected", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Collected indicates an expected call of Collected.
+func (mr *MockInteractiveRepositoryMockRecorder) Collected(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Collected", reflect.TypeOf((*MockInteractiveRepository)(nil).Collected), ctx, biz, bizId, uid)
+}
+
+// DailyStats mocks base method.
+func (m *MockInteractiveRepository) DailyStats(ctx context.Context, biz string, bizIds []int64, startDay, endDay string) ([]domain.InteractiveStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DailyStats", ctx, biz, bizIds, startDay, endDay)
+	ret0, _ := ret[0].([]domain.InteractiveStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DailyStats indicates an expected call of DailyStats.
+func (mr *MockInteractiveRepositoryMockRecorder) DailyStats(ctx, biz, bizIds, startDay, endDay any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DailyStats", reflect.TypeOf((*MockInteractiveRepository)(nil).DailyStats), ctx, biz, bizIds, startDay, endDay)
+}
+
+// DeleteCollectionItem mocks base method.
+func (m *MockInteractiveRepository) DeleteCollectionItem(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCollectionItem", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCollectionItem indicates an expected call of DeleteCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteCollectionItem(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteCollectionItem), ctx, biz, bizId, cid, uid)
+}
+
+// DeleteReaction mocks base method.
+func (m *MockInteractiveRepository) DeleteReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteReaction indicates an expected call of DeleteReaction.
+func (mr *MockInteractiveRepositoryMockRecorder) DeleteReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteReaction", reflect.TypeOf((*MockInteractiveRepository)(nil).DeleteReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// FlushReadCnt mocks base method.
+func (m *MockInteractiveRepository) FlushReadCnt(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushReadCnt", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushReadCnt indicates an expected call of FlushReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) FlushReadCnt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).FlushReadCnt), ctx)
+}
+
+// FlushReaderCnt mocks base method.
+func (m *MockInteractiveRepository) FlushReaderCnt(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FlushReaderCnt", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FlushReaderCnt indicates an expected call of FlushReaderCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) FlushReaderCnt(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FlushReaderCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).FlushReaderCnt), ctx)
+}
+
+// GetInteractive mocks base method.
+func (m *MockInteractiveRepository) GetInteractive(ctx context.Context, biz string, bizId int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInteractive", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInteractive indicates an expected call of GetInteractive.
+func (mr *MockInteractiveRepositoryMockRecorder) GetInteractive(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInteractive", reflect.TypeOf((*MockInteractiveRepository)(nil).GetInteractive), ctx, biz, bizId)
+}
+
+// GetInteractives mocks base method.
+func (m *MockInteractiveRepository) GetInteractives(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetInteractives", ctx, biz, bizIds, uid)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetInteractives indicates an expected call of GetInteractives.
+func (mr *MockInteractiveRepositoryMockRecorder) GetInteractives(ctx, biz, bizIds, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetInteractives", reflect.TypeOf((*MockInteractiveRepository)(nil).GetInteractives), ctx, biz, bizIds, uid)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveRepository) IncrReadCnt(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) IncrReadCnt(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).IncrReadCnt), ctx, biz, bizId, uid)
+}
+
+// LikeRecords mocks base method.
+func (m *MockInteractiveRepository) LikeRecords(ctx context.Context, biz string, uid int64, offset, limit int) ([]domain.InteractiveRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikeRecords", ctx, biz, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.InteractiveRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LikeRecords indicates an expected call of LikeRecords.
+func (mr *MockInteractiveRepositoryMockRecorder) LikeRecords(ctx, biz, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeRecords", reflect.TypeOf((*MockInteractiveRepository)(nil).LikeRecords), ctx, biz, uid, offset, limit)
+}
+
+// Liked mocks base method.
+func (m *MockInteractiveRepository) Liked(ctx context.Context, biz string, bizId, uid int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Liked", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Liked indicates an expected call of Liked.
+func (mr *MockInteractiveRepositoryMockRecorder) Liked(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Liked", reflect.TypeOf((*MockInteractiveRepository)(nil).Liked), ctx, biz, bizId, uid)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveRepository) MoveCollectionItem(ctx context.Context, biz string, bizId, fromCid, toCid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", ctx, biz, bizId, fromCid, toCid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveRepositoryMockRecorder) MoveCollectionItem(ctx, biz, bizId, fromCid, toCid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveRepository)(nil).MoveCollectionItem), ctx, biz, bizId, fromCid, toCid, uid)
+}
+
+// Reactions mocks base method.
+func (m *MockInteractiveRepository) Reactions(ctx context.Context, biz string, bizId, uid int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reactions", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Reactions indicates an expected call of Reactions.
+func (mr *MockInteractiveRepositoryMockRecorder) Reactions(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reactions", reflect.TypeOf((*MockInteractiveRepository)(nil).Reactions), ctx, biz, bizId, uid)
+}
+
+// ReadCntDepth mocks base method.
+func (m *MockInteractiveRepository) ReadCntDepth(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadCntDepth", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadCntDepth indicates an expected call of ReadCntDepth.
+func (mr *MockInteractiveRepositoryMockRecorder) ReadCntDepth(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadCntDepth", reflect.TypeOf((*MockInteractiveRepository)(nil).ReadCntDepth), ctx)
+}
+
+// ReaderCnt mocks base method.
+func (m *MockInteractiveRepository) ReaderCnt(ctx context.Context, biz string, bizId int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReaderCnt", ctx, biz, bizId)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReaderCnt indicates an expected call of ReaderCnt.
+func (mr *MockInteractiveRepositoryMockRecorder) ReaderCnt(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReaderCnt", reflect.TypeOf((*MockInteractiveRepository)(nil).ReaderCnt), ctx, biz, bizId)
+}
+
+// SumInteractives mocks base method.
+func (m *MockInteractiveRepository) SumInteractives(ctx context.Context, biz string, bizIds []int64) (domain.InteractiveStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SumInteractives", ctx, biz, bizIds)
+	ret0, _ := ret[0].(domain.InteractiveStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SumInteractives indicates an expected call of SumInteractives.
+func (mr *MockInteractiveRepositoryMockRecorder) SumInteractives(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SumInteractives", reflect.TypeOf((*MockInteractiveRepository)(nil).SumInteractives), ctx, biz, bizIds)
+}
+
+// TopInteractives mocks base method.
+func (m *MockInteractiveRepository) TopInteractives(ctx context.Context, biz string, bizIds []int64, field string, limit int) ([]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopInteractives", ctx, biz, bizIds, field, limit)
+	ret0, _ := ret[0].([]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopInteractives indicates an expected call of TopInteractives.
+func (mr *MockInteractiveRepositoryMockRecorder) TopInteractives(ctx, biz, bizIds, field, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopInteractives", reflect.TypeOf((*MockInteractiveRepository)(nil).TopInteractives), ctx, biz, bizIds, field, limit)
+}
@@ -0,0 +1,127 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/notification.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/notification.go -package=repomocks -destination=./internal/repository/mocks/notification.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockNotificationRepository is a mock of NotificationRepository interface.
+type MockNotificationRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationRepositoryMockRecorder
+}
+
+// MockNotificationRepositoryMockRecorder is the mock recorder for MockNotificationRepository.
+type MockNotificationRepositoryMockRecorder struct {
+	mock *MockNotificationRepository
+}
+
+// NewMockNotificationRepository creates a new mock instance.
+func NewMockNotificationRepository(ctrl *gomock.Controller) *MockNotificationRepository {
+	mock := &MockNotificationRepository{ctrl: ctrl}
+	mock.recorder = &MockNotificationRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotificationRepository) EXPECT() *MockNotificationRepositoryMockRecorder {
+	return m.recorder
+}
+
+// List mocks base method.
+func (m *MockNotificationRepository) List(ctx context.Context, uid, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, uid, cursor, limit, unreadOnly)
+	ret0, _ := ret[0].([]domain.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockNotificationRepositoryMockRecorder) List(ctx, uid, cursor, limit, unreadOnly any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockNotificationRepository)(nil).List), ctx, uid, cursor, limit, unreadOnly)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotificationRepository) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", ctx, uid, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationRepositoryMockRecorder) MarkRead(ctx, uid, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotificationRepository)(nil).MarkRead), ctx, uid, ids)
+}
+
+// Notify mocks base method.
+func (m *MockNotificationRepository) Notify(ctx context.Context, n domain.Notification, actor int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Notify", ctx, n, actor)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Notify indicates an expected call of Notify.
+func (mr *MockNotificationRepositoryMockRecorder) Notify(ctx, n, actor any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Notify", reflect.TypeOf((*MockNotificationRepository)(nil).Notify), ctx, n, actor)
+}
+
+// OptOuts mocks base method.
+func (m *MockNotificationRepository) OptOuts(ctx context.Context, uid int64) ([]domain.NotificationType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OptOuts", ctx, uid)
+	ret0, _ := ret[0].([]domain.NotificationType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OptOuts indicates an expected call of OptOuts.
+func (mr *MockNotificationRepositoryMockRecorder) OptOuts(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OptOuts", reflect.TypeOf((*MockNotificationRepository)(nil).OptOuts), ctx, uid)
+}
+
+// SetOptOut mocks base method.
+func (m *MockNotificationRepository) SetOptOut(ctx context.Context, uid int64, typ domain.NotificationType, optOut bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOptOut", ctx, uid, typ, optOut)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOptOut indicates an expected call of SetOptOut.
+func (mr *MockNotificationRepositoryMockRecorder) SetOptOut(ctx, uid, typ, optOut any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOptOut", reflect.TypeOf((*MockNotificationRepository)(nil).SetOptOut), ctx, uid, typ, optOut)
+}
+
+// UnreadCnt mocks base method.
+func (m *MockNotificationRepository) UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnreadCnt", ctx, uid)
+	ret0, _ := ret[0].(map[domain.NotificationType]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnreadCnt indicates an expected call of UnreadCnt.
+func (mr *MockNotificationRepositoryMockRecorder) UnreadCnt(ctx, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnreadCnt", reflect.TypeOf((*MockNotificationRepository)(nil).UnreadCnt), ctx, uid)
+}
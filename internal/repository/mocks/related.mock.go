@@ -0,0 +1,142 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/related.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/related.go -package=repomocks -destination=./internal/repository/mocks/related.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRelatedRepository is a mock of RelatedRepository interface.
+type MockRelatedRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockRelatedRepositoryMockRecorder
+}
+
+// MockRelatedRepositoryMockRecorder is the mock recorder for MockRelatedRepository.
+type MockRelatedRepositoryMockRecorder struct {
+	mock *MockRelatedRepository
+}
+
+// NewMockRelatedRepository creates a new mock instance.
+func NewMockRelatedRepository(ctrl *gomock.Controller) *MockRelatedRepository {
+	mock := &MockRelatedRepository{ctrl: ctrl}
+	mock.recorder = &MockRelatedRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRelatedRepository) EXPECT() *MockRelatedRepositoryMockRecorder {
+	return m.recorder
+}
+
+// CoLiked mocks base method.
+func (m *MockRelatedRepository) CoLiked(ctx context.Context, artId int64, likers, limit int) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CoLiked", ctx, artId, likers, limit)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CoLiked indicates an expected call of CoLiked.
+func (mr *MockRelatedRepositoryMockRecorder) CoLiked(ctx, artId, likers, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CoLiked", reflect.TypeOf((*MockRelatedRepository)(nil).CoLiked), ctx, artId, likers, limit)
+}
+
+// GetRelated mocks base method.
+func (m *MockRelatedRepository) GetRelated(ctx context.Context, artId int64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRelated", ctx, artId)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRelated indicates an expected call of GetRelated.
+func (mr *MockRelatedRepositoryMockRecorder) GetRelated(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRelated", reflect.TypeOf((*MockRelatedRepository)(nil).GetRelated), ctx, artId)
+}
+
+// GetTags mocks base method.
+func (m *MockRelatedRepository) GetTags(ctx context.Context, artId int64) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTags", ctx, artId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTags indicates an expected call of GetTags.
+func (mr *MockRelatedRepositoryMockRecorder) GetTags(ctx, artId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTags", reflect.TypeOf((*MockRelatedRepository)(nil).GetTags), ctx, artId)
+}
+
+// RecentByAuthor mocks base method.
+func (m *MockRelatedRepository) RecentByAuthor(ctx context.Context, author, exclude int64, limit int) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecentByAuthor", ctx, author, exclude, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecentByAuthor indicates an expected call of RecentByAuthor.
+func (mr *MockRelatedRepositoryMockRecorder) RecentByAuthor(ctx, author, exclude, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecentByAuthor", reflect.TypeOf((*MockRelatedRepository)(nil).RecentByAuthor), ctx, author, exclude, limit)
+}
+
+// SetRelated mocks base method.
+func (m *MockRelatedRepository) SetRelated(ctx context.Context, artId int64, ids []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRelated", ctx, artId, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetRelated indicates an expected call of SetRelated.
+func (mr *MockRelatedRepositoryMockRecorder) SetRelated(ctx, artId, ids any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRelated", reflect.TypeOf((*MockRelatedRepository)(nil).SetRelated), ctx, artId, ids)
+}
+
+// SetTags mocks base method.
+func (m *MockRelatedRepository) SetTags(ctx context.Context, artId int64, tags []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTags", ctx, artId, tags)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTags indicates an expected call of SetTags.
+func (mr *MockRelatedRepositoryMockRecorder) SetTags(ctx, artId, tags any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTags", reflect.TypeOf((*MockRelatedRepository)(nil).SetTags), ctx, artId, tags)
+}
+
+// SharedTags mocks base method.
+func (m *MockRelatedRepository) SharedTags(ctx context.Context, tags []string, exclude int64, limit int) (map[int64]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SharedTags", ctx, tags, exclude, limit)
+	ret0, _ := ret[0].(map[int64]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SharedTags indicates an expected call of SharedTags.
+func (mr *MockRelatedRepositoryMockRecorder) SharedTags(ctx, tags, exclude, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SharedTags", reflect.TypeOf((*MockRelatedRepository)(nil).SharedTags), ctx, tags, exclude, limit)
+}
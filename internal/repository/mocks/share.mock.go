@@ -0,0 +1,113 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/share.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/share.go -package=repomocks -destination=./internal/repository/mocks/share.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockShareRepository is a mock of ShareRepository interface.
+type MockShareRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockShareRepositoryMockRecorder
+}
+
+// MockShareRepositoryMockRecorder is the mock recorder for MockShareRepository.
+type MockShareRepositoryMockRecorder struct {
+	mock *MockShareRepository
+}
+
+// NewMockShareRepository creates a new mock instance.
+func NewMockShareRepository(ctrl *gomock.Controller) *MockShareRepository {
+	mock := &MockShareRepository{ctrl: ctrl}
+	mock.recorder = &MockShareRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockShareRepository) EXPECT() *MockShareRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddShare mocks base method.
+func (m *MockShareRepository) AddShare(ctx context.Context, link domain.ShareLink, channel string) (domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddShare", ctx, link, channel)
+	ret0, _ := ret[0].(domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddShare indicates an expected call of AddShare.
+func (mr *MockShareRepositoryMockRecorder) AddShare(ctx, link, channel any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddShare", reflect.TypeOf((*MockShareRepository)(nil).AddShare), ctx, link, channel)
+}
+
+// FindByCode mocks base method.
+func (m *MockShareRepository) FindByCode(ctx context.Context, code string) (domain.ShareLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByCode", ctx, code)
+	ret0, _ := ret[0].(domain.ShareLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByCode indicates an expected call of FindByCode.
+func (mr *MockShareRepositoryMockRecorder) FindByCode(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCode", reflect.TypeOf((*MockShareRepository)(nil).FindByCode), ctx, code)
+}
+
+// GetStats mocks base method.
+func (m *MockShareRepository) GetStats(ctx context.Context, biz string, bizId int64) (domain.ShareStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStats", ctx, biz, bizId)
+	ret0, _ := ret[0].(domain.ShareStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStats indicates an expected call of GetStats.
+func (mr *MockShareRepositoryMockRecorder) GetStats(ctx, biz, bizId any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStats", reflect.TypeOf((*MockShareRepository)(nil).GetStats), ctx, biz, bizId)
+}
+
+// IncrClickCnt mocks base method.
+func (m *MockShareRepository) IncrClickCnt(ctx context.Context, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrClickCnt", ctx, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrClickCnt indicates an expected call of IncrClickCnt.
+func (mr *MockShareRepositoryMockRecorder) IncrClickCnt(ctx, code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrClickCnt", reflect.TypeOf((*MockShareRepository)(nil).IncrClickCnt), ctx, code)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockShareRepository) IncrReadCnt(ctx context.Context, code string, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, code, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockShareRepositoryMockRecorder) IncrReadCnt(ctx, code, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockShareRepository)(nil).IncrReadCnt), ctx, code, uid)
+}
@@ -0,0 +1,98 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/repository/trending.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/repository/trending.go -package=repomocks -destination=./internal/repository/mocks/trending.mock.go
+//
+// Package repomocks is a generated GoMock package.
+package repomocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTrendingRepository is a mock of TrendingRepository interface.
+type MockTrendingRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTrendingRepositoryMockRecorder
+}
+
+// MockTrendingRepositoryMockRecorder is the mock recorder for MockTrendingRepository.
+type MockTrendingRepositoryMockRecorder struct {
+	mock *MockTrendingRepository
+}
+
+// NewMockTrendingRepository creates a new mock instance.
+func NewMockTrendingRepository(ctrl *gomock.Controller) *MockTrendingRepository {
+	mock := &MockTrendingRepository{ctrl: ctrl}
+	mock.recorder = &MockTrendingRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTrendingRepository) EXPECT() *MockTrendingRepositoryMockRecorder {
+	return m.recorder
+}
+
+// IncrCollect mocks base method.
+func (m *MockTrendingRepository) IncrCollect(ctx context.Context, biz string, bizId int64, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrCollect", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrCollect indicates an expected call of IncrCollect.
+func (mr *MockTrendingRepositoryMockRecorder) IncrCollect(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrCollect", reflect.TypeOf((*MockTrendingRepository)(nil).IncrCollect), ctx, biz, bizId, delta)
+}
+
+// IncrLike mocks base method.
+func (m *MockTrendingRepository) IncrLike(ctx context.Context, biz string, bizId int64, delta int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrLike", ctx, biz, bizId, delta)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrLike indicates an expected call of IncrLike.
+func (mr *MockTrendingRepositoryMockRecorder) IncrLike(ctx, biz, bizId, delta any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrLike", reflect.TypeOf((*MockTrendingRepository)(nil).IncrLike), ctx, biz, bizId, delta)
+}
+
+// IncrRead mocks base method.
+func (m *MockTrendingRepository) IncrRead(ctx context.Context, biz string, bizIds []int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrRead", ctx, biz, bizIds)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrRead indicates an expected call of IncrRead.
+func (mr *MockTrendingRepositoryMockRecorder) IncrRead(ctx, biz, bizIds any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrRead", reflect.TypeOf((*MockTrendingRepository)(nil).IncrRead), ctx, biz, bizIds)
+}
+
+// Top mocks base method.
+func (m *MockTrendingRepository) Top(ctx context.Context, biz string, window time.Duration, limit int) ([]domain.TrendingItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Top", ctx, biz, window, limit)
+	ret0, _ := ret[0].([]domain.TrendingItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Top indicates an expected call of Top.
+func (mr *MockTrendingRepositoryMockRecorder) Top(ctx, biz, window, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Top", reflect.TypeOf((*MockTrendingRepository)(nil).Top), ctx, biz, window, limit)
+}
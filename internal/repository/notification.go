@@ -0,0 +1,119 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+	"webook/pkg/cachex"
+)
+
+type NotificationRepository interface {
+	// Notify actor 触发了一条通知，聚合到 n.Uid 同一个资源上同一种未读的通知里面
+	Notify(ctx context.Context, n domain.Notification, actor int64) error
+	List(ctx context.Context, uid int64, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error)
+	UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error)
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	OptOuts(ctx context.Context, uid int64) ([]domain.NotificationType, error)
+	SetOptOut(ctx context.Context, uid int64, typ domain.NotificationType, optOut bool) error
+}
+
+type CachedNotificationRepository struct {
+	dao   dao.NotificationDAO
+	cache cache.NotificationCache
+	aside *cachex.Aside
+}
+
+func NewCachedNotificationRepository(dao dao.NotificationDAO, cache cache.NotificationCache,
+	aside *cachex.Aside) NotificationRepository {
+	return &CachedNotificationRepository{
+		dao:   dao,
+		cache: cache,
+		aside: aside,
+	}
+}
+
+func (c *CachedNotificationRepository) Notify(ctx context.Context, n domain.Notification, actor int64) error {
+	changed, err := c.dao.Insert(ctx, dao.Notification{
+		Uid:   n.Uid,
+		Type:  string(n.Type),
+		Biz:   n.Biz,
+		BizId: n.BizId,
+	}, actor)
+	if err != nil || !changed {
+		return err
+	}
+	return c.cache.DelUnreadCnt(ctx, n.Uid)
+}
+
+func (c *CachedNotificationRepository) List(ctx context.Context, uid int64, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error) {
+	ns, err := c.dao.List(ctx, uid, cursor, limit, unreadOnly)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.Notification, domain.Notification](ns, func(idx int, src dao.Notification) domain.Notification {
+		return c.toDomain(src)
+	}), nil
+}
+
+func (c *CachedNotificationRepository) UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error) {
+	return cachex.Get(ctx, c.aside, cachex.Query[map[domain.NotificationType]int64]{
+		Key: fmt.Sprintf("notification:unread:%d", uid),
+		Get: func(ctx context.Context) (map[domain.NotificationType]int64, error) {
+			return c.cache.GetUnreadCnt(ctx, uid)
+		},
+		Load: func(ctx context.Context) (map[domain.NotificationType]int64, error) {
+			cnts, err := c.dao.UnreadCnt(ctx, uid)
+			if err != nil {
+				return nil, err
+			}
+			res := make(map[domain.NotificationType]int64, len(cnts))
+			for typ, cnt := range cnts {
+				res[domain.NotificationType(typ)] = cnt
+			}
+			return res, nil
+		},
+		Set: func(ctx context.Context, cnts map[domain.NotificationType]int64) error {
+			return c.cache.SetUnreadCnt(ctx, uid, cnts)
+		},
+	})
+}
+
+func (c *CachedNotificationRepository) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	err := c.dao.MarkRead(ctx, uid, ids)
+	if err != nil {
+		return err
+	}
+	return c.cache.DelUnreadCnt(ctx, uid)
+}
+
+func (c *CachedNotificationRepository) OptOuts(ctx context.Context, uid int64) ([]domain.NotificationType, error) {
+	types, err := c.dao.OptOuts(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[string, domain.NotificationType](types, func(idx int, src string) domain.NotificationType {
+		return domain.NotificationType(src)
+	}), nil
+}
+
+func (c *CachedNotificationRepository) SetOptOut(ctx context.Context, uid int64, typ domain.NotificationType, optOut bool) error {
+	return c.dao.SetOptOut(ctx, uid, string(typ), optOut)
+}
+
+func (c *CachedNotificationRepository) toDomain(n dao.Notification) domain.Notification {
+	return domain.Notification{
+		Id:        n.Id,
+		Uid:       n.Uid,
+		Type:      domain.NotificationType(n.Type),
+		Biz:       n.Biz,
+		BizId:     n.BizId,
+		LastActor: n.LastActor,
+		ActorCnt:  n.ActorCnt,
+		Read:      n.ReadId != 0,
+		Ctime:     n.Ctime,
+		Utime:     n.Utime,
+	}
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+)
+
+type RankingRepository interface {
+	ReplaceTopN(ctx context.Context, arts []domain.Article) error
+	GetTopN(ctx context.Context) ([]domain.Article, error)
+}
+
+// CachedRankingRepository 热榜只放在缓存里面，redis 一份，本地一份
+type CachedRankingRepository struct {
+	redis cache.RankingCache
+	local *cache.RankingLocalCache
+}
+
+func NewCachedRankingRepository(redis cache.RankingCache, local *cache.RankingLocalCache) RankingRepository {
+	return &CachedRankingRepository{
+		redis: redis,
+		local: local,
+	}
+}
+
+func (c *CachedRankingRepository) ReplaceTopN(ctx context.Context, arts []domain.Article) error {
+	// 本地的不会失败
+	_ = c.local.Set(ctx, arts)
+	return c.redis.Set(ctx, arts)
+}
+
+func (c *CachedRankingRepository) GetTopN(ctx context.Context) ([]domain.Article, error) {
+	arts, err := c.local.Get(ctx)
+	if err == nil {
+		return arts, nil
+	}
+	arts, err = c.redis.Get(ctx)
+	if err == nil {
+		_ = c.local.Set(ctx, arts)
+		return arts, nil
+	}
+	// redis 出问题就用本地过期的兜底
+	res, er := c.local.ForceGet(ctx)
+	if er != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+)
+
+var ErrRelatedNotFound = cache.ErrKeyNotExist
+
+// RelatedRepository 相关推荐的信号在数据库里面，算好的结果只放在 redis 里面
+type RelatedRepository interface {
+	SetTags(ctx context.Context, artId int64, tags []string) error
+	GetTags(ctx context.Context, artId int64) ([]string, error)
+	// SharedTags 和 tags 有共同标签的文章 -> 共同标签数
+	SharedTags(ctx context.Context, tags []string, exclude int64, limit int) (map[int64]int64, error)
+	// CoLiked 点赞了 artId 的用户还点赞了哪些文章 -> 人数
+	CoLiked(ctx context.Context, artId int64, likers int, limit int) (map[int64]int64, error)
+	RecentByAuthor(ctx context.Context, author int64, exclude int64, limit int) ([]int64, error)
+	SetRelated(ctx context.Context, artId int64, ids []int64) error
+	GetRelated(ctx context.Context, artId int64) ([]int64, error)
+}
+
+type CachedRelatedRepository struct {
+	dao   dao.RelatedDAO
+	cache cache.RelatedCache
+	biz   string
+}
+
+func NewCachedRelatedRepository(dao dao.RelatedDAO, cache cache.RelatedCache) RelatedRepository {
+	return &CachedRelatedRepository{
+		dao:   dao,
+		cache: cache,
+		biz:   "article",
+	}
+}
+
+func (c *CachedRelatedRepository) SetTags(ctx context.Context, artId int64, tags []string) error {
+	return c.dao.SetTags(ctx, artId, tags)
+}
+
+func (c *CachedRelatedRepository) GetTags(ctx context.Context, artId int64) ([]string, error) {
+	return c.dao.GetTags(ctx, artId)
+}
+
+func (c *CachedRelatedRepository) SharedTags(ctx context.Context, tags []string, exclude int64, limit int) (map[int64]int64, error) {
+	cnts, err := c.dao.FindByTags(ctx, tags, exclude, limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.toMap(cnts), nil
+}
+
+func (c *CachedRelatedRepository) CoLiked(ctx context.Context, artId int64, likers int, limit int) (map[int64]int64, error) {
+	cnts, err := c.dao.FindCoLiked(ctx, c.biz, artId, likers, limit)
+	if err != nil {
+		return nil, err
+	}
+	return c.toMap(cnts), nil
+}
+
+func (c *CachedRelatedRepository) RecentByAuthor(ctx context.Context, author int64, exclude int64, limit int) ([]int64, error) {
+	return c.dao.FindRecentByAuthor(ctx, author, exclude, limit)
+}
+
+func (c *CachedRelatedRepository) SetRelated(ctx context.Context, artId int64, ids []int64) error {
+	return c.cache.Set(ctx, artId, ids)
+}
+
+func (c *CachedRelatedRepository) GetRelated(ctx context.Context, artId int64) ([]int64, error) {
+	return c.cache.Get(ctx, artId)
+}
+
+func (c *CachedRelatedRepository) toMap(cnts []dao.RelatedCnt) map[int64]int64 {
+	res := make(map[int64]int64, len(cnts))
+	for _, cnt := range cnts {
+		res[cnt.BizId] = cnt.Cnt
+	}
+	return res
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+	"webook/internal/repository/dao"
+)
+
+var ErrShareLinkNotFound = dao.ErrRecordNotFound
+
+type ShareRepository interface {
+	// AddShare 记录一次分享，返回该用户对该资源的分享链接
+	AddShare(ctx context.Context, link domain.ShareLink, channel string) (domain.ShareLink, error)
+	FindByCode(ctx context.Context, code string) (domain.ShareLink, error)
+	IncrClickCnt(ctx context.Context, code string) error
+	IncrReadCnt(ctx context.Context, code string, uid int64) error
+	GetStats(ctx context.Context, biz string, bizId int64) (domain.ShareStats, error)
+}
+
+type CachedShareRepository struct {
+	dao       dao.ShareDAO
+	intrCache cache.InteractiveCache
+}
+
+func NewCachedShareRepository(dao dao.ShareDAO, intrCache cache.InteractiveCache) ShareRepository {
+	return &CachedShareRepository{
+		dao:       dao,
+		intrCache: intrCache,
+	}
+}
+
+func (c *CachedShareRepository) AddShare(ctx context.Context, link domain.ShareLink, channel string) (domain.ShareLink, error) {
+	res, err := c.dao.Insert(ctx, c.toEntity(link), channel)
+	if err != nil {
+		return domain.ShareLink{}, err
+	}
+	return c.toDomain(res), c.intrCache.IncrShareCntIfPresent(ctx, link.Biz, link.BizId)
+}
+
+func (c *CachedShareRepository) FindByCode(ctx context.Context, code string) (domain.ShareLink, error) {
+	link, err := c.dao.FindByCode(ctx, code)
+	if err != nil {
+		return domain.ShareLink{}, err
+	}
+	return c.toDomain(link), nil
+}
+
+func (c *CachedShareRepository) IncrClickCnt(ctx context.Context, code string) error {
+	return c.dao.IncrClickCnt(ctx, code)
+}
+
+func (c *CachedShareRepository) IncrReadCnt(ctx context.Context, code string, uid int64) error {
+	return c.dao.IncrReadCnt(ctx, code, uid)
+}
+
+func (c *CachedShareRepository) GetStats(ctx context.Context, biz string, bizId int64) (domain.ShareStats, error) {
+	sum, err := c.dao.SumByBiz(ctx, biz, bizId)
+	if err != nil {
+		return domain.ShareStats{}, err
+	}
+	channels, err := c.dao.FindChannels(ctx, biz, bizId)
+	if err != nil {
+		return domain.ShareStats{}, err
+	}
+	res := domain.ShareStats{
+		ClickCnt: sum.ClickCnt,
+		ReadCnt:  sum.ReadCnt,
+		Channels: slice.Map[dao.ShareChannel, domain.ShareChannel](channels,
+			func(idx int, src dao.ShareChannel) domain.ShareChannel {
+				return domain.ShareChannel{
+					Channel:  src.Channel,
+					ShareCnt: src.ShareCnt,
+				}
+			}),
+	}
+	for _, ch := range channels {
+		res.ShareCnt += ch.ShareCnt
+	}
+	return res, nil
+}
+
+func (c *CachedShareRepository) toEntity(link domain.ShareLink) dao.ShareLink {
+	return dao.ShareLink{
+		Id:    link.Id,
+		Code:  link.Code,
+		Uid:   link.Uid,
+		Biz:   link.Biz,
+		BizId: link.BizId,
+	}
+}
+
+func (c *CachedShareRepository) toDomain(link dao.ShareLink) domain.ShareLink {
+	return domain.ShareLink{
+		Id:       link.Id,
+		Code:     link.Code,
+		Uid:      link.Uid,
+		Biz:      link.Biz,
+		BizId:    link.BizId,
+		ClickCnt: link.ClickCnt,
+		ReadCnt:  link.ReadCnt,
+		Ctime:    link.Ctime,
+		Utime:    link.Utime,
+	}
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository/cache"
+)
+
+type TrendingRepository interface {
+	// IncrRead 一批阅读，同一个资源可以出现多次
+	IncrRead(ctx context.Context, biz string, bizIds []int64) error
+	// IncrLike delta 是 1 或者 -1，取消点赞的时候扣回去
+	IncrLike(ctx context.Context, biz string, bizId int64, delta int) error
+	IncrCollect(ctx context.Context, biz string, bizId int64, delta int) error
+	Top(ctx context.Context, biz string, window time.Duration, limit int) ([]domain.TrendingItem, error)
+}
+
+// TrendingWeights 每种互动加多少分
+type TrendingWeights struct {
+	Read    float64 `yaml:"read"`
+	Like    float64 `yaml:"like"`
+	Collect float64 `yaml:"collect"`
+}
+
+type CachedTrendingRepository struct {
+	cache   cache.TrendingCache
+	Weights TrendingWeights
+}
+
+func NewCachedTrendingRepository(cache cache.TrendingCache) *CachedTrendingRepository {
+	return &CachedTrendingRepository{
+		cache: cache,
+		Weights: TrendingWeights{
+			Read:    1,
+			Like:    5,
+			Collect: 10,
+		},
+	}
+}
+
+func (c *CachedTrendingRepository) IncrRead(ctx context.Context, biz string, bizIds []int64) error {
+	scores := make(map[int64]float64, len(bizIds))
+	for _, bizId := range bizIds {
+		scores[bizId] += c.Weights.Read
+	}
+	return c.cache.Incr(ctx, biz, scores)
+}
+
+func (c *CachedTrendingRepository) IncrLike(ctx context.Context, biz string, bizId int64, delta int) error {
+	return c.cache.Incr(ctx, biz, map[int64]float64{bizId: c.Weights.Like * float64(delta)})
+}
+
+func (c *CachedTrendingRepository) IncrCollect(ctx context.Context, biz string, bizId int64, delta int) error {
+	return c.cache.Incr(ctx, biz, map[int64]float64{bizId: c.Weights.Collect * float64(delta)})
+}
+
+func (c *CachedTrendingRepository) Top(ctx context.Context, biz string, window time.Duration, limit int) ([]domain.TrendingItem, error) {
+	return c.cache.Top(ctx, biz, window, limit)
+}
@@ -3,10 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
+	"github.com/ecodeclub/ekit/slice"
 	"time"
 	"webook/internal/domain"
 	"webook/internal/repository/cache"
 	"webook/internal/repository/dao"
+	"webook/pkg/cachex"
 )
 
 var (
@@ -22,17 +25,22 @@ type UserRepository interface {
 	FindById(ctx context.Context, uid int64) (domain.User, error)
 	FindByPhone(ctx context.Context, phone string) (domain.User, error)
 	FindByWechat(ctx context.Context, OpenId string) (domain.User, error)
+	UpdatePrivacy(ctx context.Context, uid int64, likesPublic bool) error
+	// FindByIds 批量查询，不存在的 id 直接忽略
+	FindByIds(ctx context.Context, ids []int64) ([]domain.User, error)
 }
 
 type CacheUserRepository struct {
 	dao   dao.UserDAO
 	cache cache.UserCache
+	aside *cachex.Aside
 }
 
-func NewCacheUserRepository(dao dao.UserDAO, cache cache.UserCache) UserRepository {
+func NewCacheUserRepository(dao dao.UserDAO, cache cache.UserCache, aside *cachex.Aside) UserRepository {
 	return &CacheUserRepository{
 		dao:   dao,
 		cache: cache,
+		aside: aside,
 	}
 }
 
@@ -46,7 +54,12 @@ func (repo *CacheUserRepository) FindByWechat(ctx context.Context, openId string
 }
 
 func (repo *CacheUserRepository) Create(ctx context.Context, u domain.User) error {
-	return repo.dao.Insert(ctx, repo.toEntity(u))
+	id, err := repo.dao.Insert(ctx, repo.toEntity(u))
+	if err != nil {
+		return err
+	}
+	// 注册之前可能被当成不存在记了空值
+	return repo.aside.Forget(ctx, repo.key(id))
 }
 
 func (repo *CacheUserRepository) FindByEmail(ctx context.Context, email string) (domain.User, error) {
@@ -72,6 +85,7 @@ func (repo *CacheUserRepository) toDomain(du dao.User) domain.User {
 			OpenId:  du.WetchatOpenId.String,
 			UnionId: du.WetchatUnionId.String,
 		},
+		LikesPublic: du.LikesPublic,
 	}
 }
 
@@ -100,20 +114,50 @@ func (repo *CacheUserRepository) toEntity(u domain.User) dao.User {
 	}
 }
 
-func (repo *CacheUserRepository) FindById(ctx context.Context, uid int64) (domain.User, error) {
-	u, err := repo.cache.Get(ctx, uid)
-	// 注意这里的处理方式
-	if err == nil {
-		return u, err
+func (repo *CacheUserRepository) UpdatePrivacy(ctx context.Context, uid int64, likesPublic bool) error {
+	err := repo.dao.UpdatePrivacy(ctx, uid, likesPublic)
+	if err != nil {
+		return err
 	}
+	// 缓存里面是整个用户，直接用数据库的最新数据覆盖
 	du, err := repo.dao.FindById(ctx, uid)
 	if err != nil {
-		return domain.User{}, err
+		return err
 	}
-	u = repo.toDomain(du)
-	// 忽略掉这里的错误
-	_ = repo.cache.Set(ctx, u)
-	return u, nil
+	return repo.cache.Set(ctx, repo.toDomain(du))
+}
+
+func (repo *CacheUserRepository) FindByIds(ctx context.Context, ids []int64) ([]domain.User, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	dus, err := repo.dao.FindByIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[dao.User, domain.User](dus, func(idx int, src dao.User) domain.User {
+		return repo.toDomain(src)
+	}), nil
+}
+
+// FindById 不存在的 uid 会记一个短时间的空值，新注册的用户 id 是新的，不受影响
+func (repo *CacheUserRepository) FindById(ctx context.Context, uid int64) (domain.User, error) {
+	return cachex.Get(ctx, repo.aside, cachex.Query[domain.User]{
+		Key: repo.key(uid),
+		Get: func(ctx context.Context) (domain.User, error) {
+			return repo.cache.Get(ctx, uid)
+		},
+		Load: func(ctx context.Context) (domain.User, error) {
+			du, err := repo.dao.FindById(ctx, uid)
+			if err != nil {
+				return domain.User{}, err
+			}
+			return repo.toDomain(du), nil
+		},
+		// 忽略掉回写的错误
+		Set:      repo.cache.Set,
+		NotFound: ErrUserNotFound,
+	})
 }
 
 func (repo *CacheUserRepository) FindByPhone(ctx context.Context, phone string) (domain.User, error) {
@@ -124,3 +168,7 @@ func (repo *CacheUserRepository) FindByPhone(ctx context.Context, phone string)
 	u := repo.toDomain(du)
 	return u, nil
 }
+
+func (repo *CacheUserRepository) key(uid int64) string {
+	return fmt.Sprintf("user:%d", uid)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"github.com/go-redis/redis/v8"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
@@ -11,8 +12,10 @@ import (
 	"webook/internal/domain"
 	"webook/internal/repository/cache"
 	cachemocks "webook/internal/repository/cache/mocks"
+	redismocks "webook/internal/repository/cache/rediscache"
 	"webook/internal/repository/dao"
 	daomocks "webook/internal/repository/dao/mocks"
+	"webook/pkg/cachex"
 )
 
 func TestCacheUserRepository_FindById(t *testing.T) {
@@ -23,6 +26,8 @@ func TestCacheUserRepository_FindById(t *testing.T) {
 		uid      int64
 		wantUser domain.User
 		wantErr  error
+		// 不存在的用户要记空值
+		wantNegative bool
 	}{
 		{
 			name: "查找成功，缓存未命中",
@@ -111,10 +116,11 @@ func TestCacheUserRepository_FindById(t *testing.T) {
 				d.EXPECT().FindById(gomock.Any(), uid).Return(dao.User{}, dao.ErrRecordNotFound)
 				return c, d
 			},
-			uid:      123,
-			ctx:      context.Background(),
-			wantUser: domain.User{},
-			wantErr:  dao.ErrRecordNotFound,
+			uid:          123,
+			ctx:          context.Background(),
+			wantUser:     domain.User{},
+			wantErr:      dao.ErrRecordNotFound,
+			wantNegative: true,
 		},
 		{
 			name: "回写缓存失败",
@@ -171,10 +177,55 @@ func TestCacheUserRepository_FindById(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			userCache, userDAO := tc.mock(ctrl)
-			repo := NewCacheUserRepository(userDAO, userCache)
+			cmd := redismocks.NewMockCmdable(ctrl)
+			cmd.EXPECT().Exists(gomock.Any(), "cache:nil:user:123").
+				Return(redis.NewIntResult(0, nil)).AnyTimes()
+			if tc.wantNegative {
+				cmd.EXPECT().Set(gomock.Any(), "cache:nil:user:123", "", gomock.Any()).
+					Return(redis.NewStatusResult("OK", nil))
+			}
+			repo := NewCacheUserRepository(userDAO, userCache, cachex.NewAside(cmd, time.Second))
 			user, err := repo.FindById(tc.ctx, tc.uid)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantUser, user)
 		})
 	}
 }
+
+func TestCacheUserRepository_Create(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (dao.UserDAO, redis.Cmdable)
+		wantErr error
+	}{
+		{
+			name: "创建成功，删掉空值",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, redis.Cmdable) {
+				d := daomocks.NewMockUserDAO(ctrl)
+				cmd := redismocks.NewMockCmdable(ctrl)
+				d.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(int64(123), nil)
+				cmd.EXPECT().Del(gomock.Any(), "cache:nil:user:123").Return(redis.NewIntResult(1, nil))
+				return d, cmd
+			},
+		},
+		{
+			name: "邮箱冲突",
+			mock: func(ctrl *gomock.Controller) (dao.UserDAO, redis.Cmdable) {
+				d := daomocks.NewMockUserDAO(ctrl)
+				d.EXPECT().Insert(gomock.Any(), gomock.Any()).Return(int64(0), dao.ErrDuplicateUser)
+				return d, redismocks.NewMockCmdable(ctrl)
+			},
+			wantErr: ErrDuplicateUser,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			userDAO, cmd := tc.mock(ctrl)
+			repo := NewCacheUserRepository(userDAO, cachemocks.NewMockUserCache(ctrl), cachex.NewAside(cmd, time.Second))
+			err := repo.Create(context.Background(), domain.User{Email: "123@qq.com"})
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
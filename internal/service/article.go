@@ -3,12 +3,18 @@ package service
 import (
 	"context"
 	"github.com/pkg/errors"
+	"time"
 	"webook/internal/domain"
 	"webook/internal/domain/events/article"
 	"webook/internal/repository"
 	"webook/pkg/logger"
 )
 
+// 发表、撤回之后分发关注流的超时时间
+const feedFanoutTimeout = 30 * time.Second
+
+var ErrArticleNotFound = repository.ErrArticleNotFound
+
 type ArticleService interface {
 	Save(ctx context.Context, article domain.Article) (int64, error)
 	Publish(ctx context.Context, article domain.Article) (int64, error)
@@ -17,11 +23,17 @@ type ArticleService interface {
 	GetByArtId(ctx context.Context, artId int64) (domain.Article, error)
 
 	GetPubByArtId(ctx context.Context, artId int64, uid int64) (domain.Article, error)
+	// GetPubByArtIds 批量获取线上库文章摘要，不发阅读事件
+	GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error)
+	// ListPub start 之后更新过的已发表文章，给热榜这种批量计算用，没有内容
+	// last 是上一批的最后一篇，第一批传零值
+	ListPub(ctx context.Context, start time.Time, last domain.Article, limit int) ([]domain.Article, error)
 }
 
 type articleService struct {
 	repo     repository.ArticleRepository
 	producer article.Producer // 生产事件
+	feedSvc  FeedService
 
 	// V1 专用
 	authorRepo repository.ArticleAuthorRepository
@@ -48,6 +60,14 @@ func (a *articleService) GetPubByArtId(ctx context.Context, artId int64, uid int
 	return res, err
 }
 
+func (a *articleService) GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error) {
+	return a.repo.GetPubByArtIds(ctx, artIds)
+}
+
+func (a *articleService) ListPub(ctx context.Context, start time.Time, last domain.Article, limit int) ([]domain.Article, error) {
+	return a.repo.ListPub(ctx, start, last, limit)
+}
+
 func (a *articleService) GetByArtId(ctx context.Context, artId int64) (domain.Article, error) {
 	return a.repo.GetByArtId(ctx, artId)
 }
@@ -57,7 +77,19 @@ func (a *articleService) GetByAuthor(ctx context.Context, limit, offset int, uid
 }
 
 func (a *articleService) Withdraw(ctx context.Context, artId int64, id int64) error {
-	return a.repo.SyncStatus(ctx, artId, id, domain.ArticleStatusPrivate)
+	err := a.repo.SyncStatus(ctx, artId, id, domain.ArticleStatusPrivate)
+	if err != nil {
+		return err
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), feedFanoutTimeout)
+		defer cancel()
+		er := a.feedSvc.Withdraw(ctx, id, artId)
+		if er != nil {
+			a.l.Error("从关注流撤回文章失败", logger.Int64("artId", artId), logger.Error(er))
+		}
+	}()
+	return nil
 }
 
 func (a *articleService) PublishV1(ctx context.Context, art domain.Article) (int64, error) {
@@ -92,7 +124,21 @@ func (a *articleService) PublishV1(ctx context.Context, art domain.Article) (int
 func (a *articleService) Publish(ctx context.Context, art domain.Article) (int64, error) {
 	art.Status = domain.ArticleStatusPublished
 	// 同步
-	return a.repo.Sync(ctx, art)
+	artId, err := a.repo.Sync(ctx, art)
+	if err != nil {
+		return 0, err
+	}
+	art.Id = artId
+	// 粉丝多的时候推送要一会儿，不让作者等
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), feedFanoutTimeout)
+		defer cancel()
+		er := a.feedSvc.Publish(ctx, art)
+		if er != nil {
+			a.l.Error("文章推送到关注流失败", logger.Int64("artId", artId), logger.Error(er))
+		}
+	}()
+	return artId, nil
 }
 
 func NewArticleServiceV1(authorRepo repository.ArticleAuthorRepository, readerRepo repository.ArticleReaderRepository, l logger.Logger) *articleService {
@@ -103,11 +149,13 @@ func NewArticleServiceV1(authorRepo repository.ArticleAuthorRepository, readerRe
 	}
 }
 
-func NewArticleService(repo repository.ArticleRepository, l logger.Logger) ArticleService {
+func NewArticleService(repo repository.ArticleRepository, producer article.Producer, feedSvc FeedService,
+	l logger.Logger) ArticleService {
 	return &articleService{
-		repo: repo,
-		//producer: producer,
-		l: l,
+		repo:     repo,
+		producer: producer,
+		feedSvc:  feedSvc,
+		l:        l,
 	}
 }
 
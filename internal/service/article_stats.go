@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+)
+
+var (
+	ErrStatsNoPermission = errors.New("只能查看自己文章的数据")
+	ErrInvalidStatsRange = errors.New("统计时间范围不合法")
+)
+
+// 一次最多查一年多一点，按天导出也就几百行
+const maxStatsDays = 366
+
+type ArticleStatsService interface {
+	// Stats 作者查看文章每天的阅读、点赞、收藏、评论增量，artId 为 0 的时候是作者所有文章的和。
+	// start 和 end 格式为 2006-01-02，两头都包含，按周、按月汇总的时候第一个周期可能从 start 之前开始
+	Stats(ctx context.Context, uid int64, artId int64, start string, end string,
+		granularity domain.StatsGranularity) ([]domain.InteractiveStat, error)
+}
+
+type articleStatsService struct {
+	intrRepo repository.InteractiveRepository
+	artRepo  repository.ArticleRepository
+}
+
+func NewArticleStatsService(intrRepo repository.InteractiveRepository,
+	artRepo repository.ArticleRepository) ArticleStatsService {
+	return &articleStatsService{
+		intrRepo: intrRepo,
+		artRepo:  artRepo,
+	}
+}
+
+func (s *articleStatsService) Stats(ctx context.Context, uid int64, artId int64, start string, end string,
+	granularity domain.StatsGranularity) ([]domain.InteractiveStat, error) {
+	startDay, err := time.Parse(time.DateOnly, start)
+	if err != nil {
+		return nil, ErrInvalidStatsRange
+	}
+	endDay, err := time.Parse(time.DateOnly, end)
+	if err != nil {
+		return nil, ErrInvalidStatsRange
+	}
+	if endDay.Before(startDay) || endDay.Sub(startDay) >= maxStatsDays*24*time.Hour {
+		return nil, ErrInvalidStatsRange
+	}
+	if !granularity.Valid() {
+		granularity = domain.StatsGranularityDay
+	}
+
+	var artIds []int64
+	if artId > 0 {
+		arts, err := s.artRepo.GetPubByArtIds(ctx, []int64{artId})
+		if err != nil {
+			return nil, err
+		}
+		if len(arts) == 0 || arts[0].Author.Id != uid {
+			return nil, ErrStatsNoPermission
+		}
+		artIds = []int64{artId}
+	} else {
+		artIds, err = s.artRepo.GetPubIdsByAuthor(ctx, uid)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	dailies, err := s.intrRepo.DailyStats(ctx, "article", artIds, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return rollupStats(dailies, startDay, endDay, granularity), nil
+}
+
+// rollupStats 补齐没有数据的日子，再按粒度汇总
+func rollupStats(dailies []domain.InteractiveStat, start, end time.Time,
+	granularity domain.StatsGranularity) []domain.InteractiveStat {
+	byDay := make(map[string]domain.InteractiveStat, len(dailies))
+	for _, d := range dailies {
+		byDay[d.Date] = d
+	}
+	var res []domain.InteractiveStat
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		period := periodStart(day, granularity).Format(time.DateOnly)
+		if len(res) == 0 || res[len(res)-1].Date != period {
+			res = append(res, domain.InteractiveStat{Date: period})
+		}
+		res[len(res)-1] = res[len(res)-1].Add(byDay[day.Format(time.DateOnly)])
+	}
+	return res
+}
+
+func periodStart(day time.Time, granularity domain.StatsGranularity) time.Time {
+	switch granularity {
+	case domain.StatsGranularityWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	case domain.StatsGranularityMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	default:
+		return day
+	}
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	repomocks "webook/internal/repository/mocks"
+)
+
+func TestRollupStats(t *testing.T) {
+	stat := func(day string, read int64, like int64) domain.InteractiveStat {
+		return domain.InteractiveStat{Date: day, ReadCnt: read, LikeCnt: like}
+	}
+	testCases := []struct {
+		name        string
+		dailies     []domain.InteractiveStat
+		start       string
+		end         string
+		granularity domain.StatsGranularity
+		want        []domain.InteractiveStat
+	}{
+		{
+			name:        "按天补齐没有数据的日子",
+			dailies:     []domain.InteractiveStat{stat("2024-03-02", 5, 0)},
+			start:       "2024-03-01",
+			end:         "2024-03-03",
+			granularity: domain.StatsGranularityDay,
+			want: []domain.InteractiveStat{
+				stat("2024-03-01", 0, 0),
+				stat("2024-03-02", 5, 0),
+				stat("2024-03-03", 0, 0),
+			},
+		},
+		{
+			name: "按周汇总，第一周从周一开始",
+			dailies: []domain.InteractiveStat{
+				stat("2024-03-01", 1, 0),
+				stat("2024-03-03", 2, 0),
+				stat("2024-03-04", 4, 0),
+				stat("2024-03-10", 0, 1),
+				stat("2024-03-12", 8, 0),
+			},
+			// 03-01 是周五
+			start:       "2024-03-01",
+			end:         "2024-03-12",
+			granularity: domain.StatsGranularityWeek,
+			want: []domain.InteractiveStat{
+				stat("2024-02-26", 3, 0),
+				stat("2024-03-04", 4, 1),
+				stat("2024-03-11", 8, 0),
+			},
+		},
+		{
+			name: "按月汇总，跨过闰年二月",
+			dailies: []domain.InteractiveStat{
+				stat("2024-01-31", 1, 0),
+				stat("2024-02-29", 2, 1),
+				stat("2024-03-02", 4, 0),
+			},
+			start:       "2024-01-30",
+			end:         "2024-03-02",
+			granularity: domain.StatsGranularityMonth,
+			want: []domain.InteractiveStat{
+				stat("2024-01-01", 1, 0),
+				stat("2024-02-01", 2, 1),
+				stat("2024-03-01", 4, 0),
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			start, _ := time.Parse(time.DateOnly, tc.start)
+			end, _ := time.Parse(time.DateOnly, tc.end)
+			assert.Equal(t, tc.want, rollupStats(tc.dailies, start, end, tc.granularity))
+		})
+	}
+}
+
+func TestArticleStatsService_Stats(t *testing.T) {
+	testCases := []struct {
+		name  string
+		mock  func(ctrl *gomock.Controller) (*repomocks.MockInteractiveRepository, *repomocks.MockArticleRepository)
+		artId int64
+		start string
+		end   string
+
+		want    []domain.InteractiveStat
+		wantErr error
+	}{
+		{
+			name: "所有文章按周汇总",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockInteractiveRepository, *repomocks.MockArticleRepository) {
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubIdsByAuthor(gomock.Any(), int64(1)).Return([]int64{1, 2}, nil)
+				intrRepo.EXPECT().DailyStats(gomock.Any(), "article", []int64{1, 2}, "2024-03-04", "2024-03-12").
+					Return([]domain.InteractiveStat{{Date: "2024-03-05", ReadCnt: 3}}, nil)
+				return intrRepo, artRepo
+			},
+			start: "2024-03-04",
+			end:   "2024-03-12",
+			want: []domain.InteractiveStat{
+				{Date: "2024-03-04", ReadCnt: 3},
+				{Date: "2024-03-11"},
+			},
+		},
+		{
+			name: "不是自己的文章",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockInteractiveRepository, *repomocks.MockArticleRepository) {
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtIds(gomock.Any(), []int64{5}).
+					Return([]domain.Article{{Id: 5, Author: domain.Author{Id: 2}}}, nil)
+				return repomocks.NewMockInteractiveRepository(ctrl), artRepo
+			},
+			artId:   5,
+			start:   "2024-03-04",
+			end:     "2024-03-12",
+			wantErr: ErrStatsNoPermission,
+		},
+		{
+			name: "结束早于开始",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockInteractiveRepository, *repomocks.MockArticleRepository) {
+				return repomocks.NewMockInteractiveRepository(ctrl), repomocks.NewMockArticleRepository(ctrl)
+			},
+			start:   "2024-03-12",
+			end:     "2024-03-04",
+			wantErr: ErrInvalidStatsRange,
+		},
+		{
+			name: "超过一年",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockInteractiveRepository, *repomocks.MockArticleRepository) {
+				return repomocks.NewMockInteractiveRepository(ctrl), repomocks.NewMockArticleRepository(ctrl)
+			},
+			start:   "2023-01-01",
+			end:     "2024-03-04",
+			wantErr: ErrInvalidStatsRange,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			intrRepo, artRepo := tc.mock(ctrl)
+			svc := NewArticleStatsService(intrRepo, artRepo)
+			res, err := svc.Stats(context.Background(), 1, tc.artId, tc.start, tc.end, domain.StatsGranularityWeek)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
@@ -2,26 +2,28 @@ package service
 
 import (
 	"context"
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 	"testing"
 	"webook/internal/domain"
 	"webook/internal/repository"
 	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
 )
 
 func Test_articleService_Publish(t *testing.T) {
 	testCases := []struct {
 		name    string
-		mock    func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleRepository)
+		mock    func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleReaderRepository)
 		art     domain.Article
 		wantId  int64
 		wantErr error
 	}{
 		{
 			name: "新建并发表成功",
-			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleRepository) {
-				authorRepo := repomocks.newMockArticleAuthorRepository(ctrl)
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
 				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
 					Title:   "新建发表",
 					Content: "新建发表",
@@ -29,7 +31,7 @@ func Test_articleService_Publish(t *testing.T) {
 						Id: 123,
 					},
 				}).Return(int64(1), nil)
-				readerRepo := repomocks.newMockArticleReaderRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
 				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      1,
 					Title:   "新建发表",
@@ -37,7 +39,7 @@ func Test_articleService_Publish(t *testing.T) {
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(int64(1), nil)
+				}).Return(nil)
 				return authorRepo, readerRepo
 			},
 			art: domain.Article{
@@ -51,8 +53,8 @@ func Test_articleService_Publish(t *testing.T) {
 		},
 		{
 			name: "修改并发表成功",
-			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleRepository) {
-				authorRepo := repomocks.newMockArticleAuthorRepository(ctrl)
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
 				authorRepo.EXPECT().Update(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "新建发表",
@@ -61,7 +63,7 @@ func Test_articleService_Publish(t *testing.T) {
 						Id: 123,
 					},
 				}).Return(nil)
-				readerRepo := repomocks.newMockArticleReaderRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
 				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      2,
 					Title:   "新建发表",
@@ -84,16 +86,16 @@ func Test_articleService_Publish(t *testing.T) {
 		},
 		{
 			name: "新建并发表失败",
-			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleRepository) {
-				authorRepo := repomocks.newMockArticleAuthorRepository(ctrl)
-				authorRepo.EXPECT().Update(gomock.Any(), domain.Article{
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
+				authorRepo.EXPECT().Create(gomock.Any(), domain.Article{
 					Title:   "新建发表",
 					Content: "新建发表",
 					Author: domain.Author{
 						Id: 123,
 					},
-				}).Return(nil)
-				readerRepo := repomocks.newMockArticleReaderRepository(ctrl)
+				}).Return(int64(3), nil)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
 				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      3,
 					Title:   "新建发表",
@@ -111,13 +113,12 @@ func Test_articleService_Publish(t *testing.T) {
 					Id: 123,
 				},
 			},
-			wantId:  3,
 			wantErr: errors.New("发表失败"),
 		},
 		{
 			name: "修改并发表失败",
-			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleRepository) {
-				authorRepo := repomocks.newMockArticleAuthorRepository(ctrl)
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
 				authorRepo.EXPECT().Update(gomock.Any(), domain.Article{
 					Id:      3,
 					Title:   "新建发表",
@@ -126,7 +127,7 @@ func Test_articleService_Publish(t *testing.T) {
 						Id: 123,
 					},
 				}).Return(nil)
-				readerRepo := repomocks.newMockArticleReaderRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
 				readerRepo.EXPECT().Save(gomock.Any(), domain.Article{
 					Id:      3,
 					Title:   "新建发表",
@@ -145,13 +146,12 @@ func Test_articleService_Publish(t *testing.T) {
 					Id: 123,
 				},
 			},
-			wantId:  3,
 			wantErr: errors.New("发表失败"),
 		},
 		{
 			name: "修改保存至制作库失败",
-			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleRepository) {
-				authorRepo := repomocks.newMockArticleAuthorRepository(ctrl)
+			mock: func(ctrl *gomock.Controller) (repository.ArticleAuthorRepository, repository.ArticleReaderRepository) {
+				authorRepo := repomocks.NewMockArticleAuthorRepository(ctrl)
 				authorRepo.EXPECT().Update(gomock.Any(), domain.Article{
 					Id:      3,
 					Title:   "新建发表",
@@ -160,7 +160,7 @@ func Test_articleService_Publish(t *testing.T) {
 						Id: 123,
 					},
 				}).Return(errors.New("保存至制作库失败"))
-				readerRepo := repomocks.newMockArticleReaderRepository(ctrl)
+				readerRepo := repomocks.NewMockArticleReaderRepository(ctrl)
 				return authorRepo, readerRepo
 			},
 			art: domain.Article{
@@ -171,7 +171,6 @@ func Test_articleService_Publish(t *testing.T) {
 					Id: 123,
 				},
 			},
-			wantId:  3,
 			wantErr: errors.New("保存至制作库失败"),
 		},
 	}
@@ -180,7 +179,7 @@ func Test_articleService_Publish(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			defer ctrl.Finish()
 			authorRepo, readerRepo := tc.mock(ctrl)
-			svc := NewArticleServiceV1(authorRepo, readerRepo)
+			svc := NewArticleServiceV1(authorRepo, readerRepo, logger.NewNopLogger())
 			artId, err := svc.PublishV1(context.Background(), tc.art)
 			assert.Equal(t, tc.wantErr, err)
 			assert.Equal(t, tc.wantId, artId)
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"github.com/ecodeclub/ekit/slice"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+const (
+	dashboardDefaultDays  = 7
+	dashboardMaxDays      = 90
+	dashboardTopN         = 5
+	dashboardCadenceWeeks = 12
+)
+
+type AuthorDashboardService interface {
+	// Dashboard 作者所有文章的数据看板，days 是环比的周期，默认 7 天
+	Dashboard(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error)
+}
+
+type authorDashboardService struct {
+	repo       repository.AuthorDashboardRepository
+	intrRepo   repository.InteractiveRepository
+	artRepo    repository.ArticleRepository
+	followRepo repository.FollowRepository
+	l          logger.Logger
+}
+
+func NewAuthorDashboardService(repo repository.AuthorDashboardRepository, intrRepo repository.InteractiveRepository,
+	artRepo repository.ArticleRepository, followRepo repository.FollowRepository, l logger.Logger) AuthorDashboardService {
+	return &authorDashboardService{
+		repo:       repo,
+		intrRepo:   intrRepo,
+		artRepo:    artRepo,
+		followRepo: followRepo,
+		l:          l,
+	}
+}
+
+func (s *authorDashboardService) Dashboard(ctx context.Context, uid int64, days int) (domain.AuthorDashboard, error) {
+	if days <= 0 {
+		days = dashboardDefaultDays
+	}
+	if days > dashboardMaxDays {
+		return domain.AuthorDashboard{}, ErrInvalidStatsRange
+	}
+	dash, err := s.repo.Get(ctx, uid, days)
+	if err == nil {
+		return dash, nil
+	}
+	dash, err = s.build(ctx, uid, days, time.Now())
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	if err = s.repo.Set(ctx, dash); err != nil {
+		s.l.Error("缓存作者看板失败", logger.Int64("uid", uid), logger.Error(err))
+	}
+	return dash, nil
+}
+
+// build 累计值 = 截止到昨天的 base + 今天的增量，base 每天只需要把新过去的几天加上去，
+// 不用每次把作者所有文章的计数加一遍
+func (s *authorDashboardService) build(ctx context.Context, uid int64, days int, now time.Time) (domain.AuthorDashboard, error) {
+	arts, err := s.artRepo.GetPubBriefsByAuthor(ctx, uid)
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+		return src.Id
+	})
+
+	today, _ := time.Parse(time.DateOnly, domain.StatsDay(now))
+	yesterday := today.AddDate(0, 0, -1)
+	curStart := today.AddDate(0, 0, 1-days)
+	prevStart := curStart.AddDate(0, 0, -days)
+
+	base, baseErr := s.repo.GetBase(ctx, uid)
+	var baseDay time.Time
+	if baseErr == nil {
+		baseDay, baseErr = time.Parse(time.DateOnly, base.Day)
+	}
+	// 一次把 base 缺的那几天和环比需要的那几天都查出来
+	start := prevStart
+	if baseErr == nil && baseDay.Before(start) {
+		start = baseDay.AddDate(0, 0, 1)
+	}
+	dailies, err := s.intrRepo.DailyStats(ctx, "article", ids,
+		start.Format(time.DateOnly), today.Format(time.DateOnly))
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	byDay := make(map[string]domain.InteractiveStat, len(dailies))
+	for _, d := range dailies {
+		byDay[d.Date] = d
+	}
+	sumDays := func(from, to time.Time) domain.InteractiveStat {
+		res := domain.InteractiveStat{Date: from.Format(time.DateOnly)}
+		for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+			res = res.Add(byDay[day.Format(time.DateOnly)])
+		}
+		return res
+	}
+	todayStat := byDay[today.Format(time.DateOnly)]
+
+	switch {
+	case baseErr != nil:
+		total, err := s.intrRepo.SumInteractives(ctx, "article", ids)
+		if err != nil {
+			return domain.AuthorDashboard{}, err
+		}
+		base = domain.AuthorStatsBase{
+			Uid:   uid,
+			Day:   yesterday.Format(time.DateOnly),
+			Total: total.Sub(todayStat),
+		}
+		s.saveBase(ctx, base)
+	case baseDay.Before(yesterday):
+		base.Total = base.Total.Add(sumDays(baseDay.AddDate(0, 0, 1), yesterday))
+		base.Day = yesterday.Format(time.DateOnly)
+		s.saveBase(ctx, base)
+	}
+
+	dash := domain.AuthorDashboard{
+		Uid:        uid,
+		Days:       days,
+		ArticleCnt: int64(len(arts)),
+		Total:      base.Total.Add(todayStat),
+		Current:    sumDays(curStart, today),
+		Previous:   sumDays(prevStart, curStart.AddDate(0, 0, -1)),
+		Cadence:    publishCadence(arts, today),
+		Utime:      now.UnixMilli(),
+	}
+	dash.Total.Date = today.Format(time.DateOnly)
+	dash.Delta = dash.Current.Sub(dash.Previous)
+
+	titles := make(map[int64]string, len(arts))
+	for _, art := range arts {
+		titles[art.Id] = art.Title
+	}
+	dash.TopByRead, err = s.top(ctx, ids, "read_cnt", titles)
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	dash.TopByLike, err = s.top(ctx, ids, "like_cnt", titles)
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	dash.Followers, err = s.followers(ctx, uid, prevStart, curStart, today)
+	if err != nil {
+		return domain.AuthorDashboard{}, err
+	}
+	return dash, nil
+}
+
+// followers 日期是统计时区里面的日期，要换成那个时区的零点再去查
+func (s *authorDashboardService) followers(ctx context.Context, uid int64,
+	prevStart, curStart, today time.Time) (domain.FollowerStats, error) {
+	midnight := func(day time.Time) time.Time {
+		res, _ := time.ParseInLocation(time.DateOnly, day.Format(time.DateOnly), domain.StatsLocation)
+		return res
+	}
+	stat, err := s.followRepo.GetStatistic(ctx, uid)
+	if err != nil {
+		return domain.FollowerStats{}, err
+	}
+	cur, err := s.followRepo.CountNewFollowers(ctx, uid, midnight(curStart), midnight(today.AddDate(0, 0, 1)))
+	if err != nil {
+		return domain.FollowerStats{}, err
+	}
+	prev, err := s.followRepo.CountNewFollowers(ctx, uid, midnight(prevStart), midnight(curStart))
+	if err != nil {
+		return domain.FollowerStats{}, err
+	}
+	return domain.FollowerStats{
+		Total:    stat.Followers,
+		Current:  cur,
+		Previous: prev,
+		Delta:    cur - prev,
+	}, nil
+}
+
+func (s *authorDashboardService) saveBase(ctx context.Context, base domain.AuthorStatsBase) {
+	if err := s.repo.SetBase(ctx, base); err != nil {
+		// 下次重新算，不影响这次的结果
+		s.l.Error("缓存作者累计数据失败", logger.Int64("uid", base.Uid), logger.Error(err))
+	}
+}
+
+func (s *authorDashboardService) top(ctx context.Context, ids []int64, field string,
+	titles map[int64]string) ([]domain.ArticleRank, error) {
+	intrs, err := s.intrRepo.TopInteractives(ctx, "article", ids, field, dashboardTopN)
+	if err != nil {
+		return nil, err
+	}
+	return slice.Map[domain.Interactive, domain.ArticleRank](intrs, func(idx int, src domain.Interactive) domain.ArticleRank {
+		return domain.ArticleRank{
+			Id:      src.BizId,
+			Title:   titles[src.BizId],
+			ReadCnt: src.ReadCnt,
+			LikeCnt: src.LikeCnt,
+		}
+	}), nil
+}
+
+// publishCadence 最近几周每周发表的篇数，按照首次发表时间算，没发表的周也返回
+func publishCadence(arts []domain.Article, today time.Time) []domain.PublishCadence {
+	thisWeek := periodStart(today, domain.StatsGranularityWeek)
+	first := thisWeek.AddDate(0, 0, -7*(dashboardCadenceWeeks-1))
+	res := make([]domain.PublishCadence, dashboardCadenceWeeks)
+	for i := range res {
+		res[i].Week = first.AddDate(0, 0, 7*i).Format(time.DateOnly)
+	}
+	for _, art := range arts {
+		day, _ := time.Parse(time.DateOnly, domain.StatsDay(time.UnixMilli(art.Ctime)))
+		idx := int(periodStart(day, domain.StatsGranularityWeek).Sub(first).Hours()) / (24 * 7)
+		if day.Before(first) || idx >= dashboardCadenceWeeks {
+			continue
+		}
+		res[idx].Cnt++
+	}
+	return res
+}
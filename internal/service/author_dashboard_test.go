@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+func TestAuthorDashboardService_build(t *testing.T) {
+	// 周四上午，周期两天：本期 03-13 ~ 03-14，上期 03-11 ~ 03-12
+	now := time.Date(2024, 3, 14, 10, 0, 0, 0, domain.StatsLocation)
+	midnight := func(day int) time.Time {
+		return time.Date(2024, 3, day, 0, 0, 0, 0, domain.StatsLocation)
+	}
+	arts := []domain.Article{
+		{Id: 1, Title: "第一篇", Ctime: midnight(4).UnixMilli()},
+		{Id: 2, Title: "第二篇", Ctime: midnight(12).UnixMilli()},
+	}
+	stat := func(day string, read int64, like int64) domain.InteractiveStat {
+		return domain.InteractiveStat{Date: day, ReadCnt: read, LikeCnt: like}
+	}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (*repomocks.MockAuthorDashboardRepository,
+			*repomocks.MockInteractiveRepository)
+
+		wantTotal    domain.InteractiveStat
+		wantCurrent  domain.InteractiveStat
+		wantPrevious domain.InteractiveStat
+		wantDelta    domain.InteractiveStat
+	}{
+		{
+			name: "没有 base，用累计值减掉今天的初始化",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockAuthorDashboardRepository,
+				*repomocks.MockInteractiveRepository) {
+				repo := repomocks.NewMockAuthorDashboardRepository(ctrl)
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetBase(gomock.Any(), int64(1)).Return(domain.AuthorStatsBase{}, errors.New("key不存在"))
+				intrRepo.EXPECT().DailyStats(gomock.Any(), "article", []int64{1, 2}, "2024-03-11", "2024-03-14").
+					Return([]domain.InteractiveStat{
+						stat("2024-03-11", 1, 0),
+						stat("2024-03-12", 2, 0),
+						stat("2024-03-13", 4, 0),
+						stat("2024-03-14", 8, 1),
+					}, nil)
+				intrRepo.EXPECT().SumInteractives(gomock.Any(), "article", []int64{1, 2}).
+					Return(stat("", 100, 10), nil)
+				repo.EXPECT().SetBase(gomock.Any(), domain.AuthorStatsBase{
+					Uid:   1,
+					Day:   "2024-03-13",
+					Total: stat("", 92, 9),
+				}).Return(nil)
+				return repo, intrRepo
+			},
+			wantTotal:    stat("2024-03-14", 100, 10),
+			wantCurrent:  stat("2024-03-13", 12, 1),
+			wantPrevious: stat("2024-03-11", 3, 0),
+			wantDelta:    stat("2024-03-13", 9, 1),
+		},
+		{
+			name: "base 落后几天，把中间没有数据的日子也滚过去",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockAuthorDashboardRepository,
+				*repomocks.MockInteractiveRepository) {
+				repo := repomocks.NewMockAuthorDashboardRepository(ctrl)
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetBase(gomock.Any(), int64(1)).Return(domain.AuthorStatsBase{
+					Uid:   1,
+					Day:   "2024-03-09",
+					Total: stat("", 50, 5),
+				}, nil)
+				// 03-11 和 03-13 没有数据
+				intrRepo.EXPECT().DailyStats(gomock.Any(), "article", []int64{1, 2}, "2024-03-10", "2024-03-14").
+					Return([]domain.InteractiveStat{
+						stat("2024-03-10", 1, 1),
+						stat("2024-03-12", 2, 0),
+						stat("2024-03-14", 8, 0),
+					}, nil)
+				repo.EXPECT().SetBase(gomock.Any(), domain.AuthorStatsBase{
+					Uid:   1,
+					Day:   "2024-03-13",
+					Total: stat("", 53, 6),
+				}).Return(nil)
+				return repo, intrRepo
+			},
+			wantTotal:    stat("2024-03-14", 61, 6),
+			wantCurrent:  stat("2024-03-13", 8, 0),
+			wantPrevious: stat("2024-03-11", 2, 0),
+			wantDelta:    stat("2024-03-13", 6, 0),
+		},
+		{
+			name: "base 是昨天的，不用更新",
+			mock: func(ctrl *gomock.Controller) (*repomocks.MockAuthorDashboardRepository,
+				*repomocks.MockInteractiveRepository) {
+				repo := repomocks.NewMockAuthorDashboardRepository(ctrl)
+				intrRepo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetBase(gomock.Any(), int64(1)).Return(domain.AuthorStatsBase{
+					Uid:   1,
+					Day:   "2024-03-13",
+					Total: stat("", 50, 5),
+				}, nil)
+				intrRepo.EXPECT().DailyStats(gomock.Any(), "article", []int64{1, 2}, "2024-03-11", "2024-03-14").
+					Return([]domain.InteractiveStat{
+						stat("2024-03-11", 6, 2),
+						stat("2024-03-12", 4, 0),
+						stat("2024-03-13", 1, 0),
+						stat("2024-03-14", 2, 0),
+					}, nil)
+				return repo, intrRepo
+			},
+			wantTotal:    stat("2024-03-14", 52, 5),
+			wantCurrent:  stat("2024-03-13", 3, 0),
+			wantPrevious: stat("2024-03-11", 10, 2),
+			// 比上期少
+			wantDelta: stat("2024-03-13", -7, -2),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, intrRepo := tc.mock(ctrl)
+			artRepo := repomocks.NewMockArticleRepository(ctrl)
+			artRepo.EXPECT().GetPubBriefsByAuthor(gomock.Any(), int64(1)).Return(arts, nil)
+			intrRepo.EXPECT().TopInteractives(gomock.Any(), "article", []int64{1, 2}, "read_cnt", dashboardTopN).
+				Return([]domain.Interactive{{BizId: 2, ReadCnt: 9}}, nil)
+			intrRepo.EXPECT().TopInteractives(gomock.Any(), "article", []int64{1, 2}, "like_cnt", dashboardTopN).
+				Return([]domain.Interactive{{BizId: 1, LikeCnt: 3}}, nil)
+			followRepo := repomocks.NewMockFollowRepository(ctrl)
+			followRepo.EXPECT().GetStatistic(gomock.Any(), int64(1)).
+				Return(domain.FollowStatistic{Uid: 1, Followers: 50}, nil)
+			followRepo.EXPECT().CountNewFollowers(gomock.Any(), int64(1), midnight(13), midnight(15)).Return(int64(5), nil)
+			followRepo.EXPECT().CountNewFollowers(gomock.Any(), int64(1), midnight(11), midnight(13)).Return(int64(3), nil)
+
+			svc := NewAuthorDashboardService(repo, intrRepo, artRepo, followRepo,
+				logger.NewNopLogger()).(*authorDashboardService)
+			dash, err := svc.build(context.Background(), 1, 2, now)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantTotal, dash.Total)
+			assert.Equal(t, tc.wantCurrent, dash.Current)
+			assert.Equal(t, tc.wantPrevious, dash.Previous)
+			assert.Equal(t, tc.wantDelta, dash.Delta)
+			assert.Equal(t, int64(2), dash.ArticleCnt)
+			assert.Equal(t, []domain.ArticleRank{{Id: 2, Title: "第二篇", ReadCnt: 9}}, dash.TopByRead)
+			assert.Equal(t, []domain.ArticleRank{{Id: 1, Title: "第一篇", LikeCnt: 3}}, dash.TopByLike)
+			assert.Equal(t, domain.FollowerStats{Total: 50, Current: 5, Previous: 3, Delta: 2}, dash.Followers)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"webook/internal/domain"
+	"webook/internal/repository"
+)
+
+var (
+	ErrCollectionNotFound     = repository.ErrCollectionNotFound
+	ErrCollectionNoPermission = repository.ErrCollectionNoPermission
+)
+
+type CollectionService interface {
+	Create(ctx context.Context, c domain.Collection) (int64, error)
+	// Update 重命名或者修改可见性
+	Update(ctx context.Context, c domain.Collection) error
+	Delete(ctx context.Context, id int64, uid int64) error
+	// List uid 的收藏夹，viewer 不是本人的时候只能看到公开的
+	List(ctx context.Context, uid int64, viewer int64) ([]domain.Collection, error)
+	// Items 收藏夹里面的收藏，带上文章摘要
+	// cid 为 0 是 uid 的默认收藏夹，默认收藏夹是私密的
+	Items(ctx context.Context, cid int64, uid int64, viewer int64, offset int, limit int) ([]domain.CollectionItem, error)
+}
+
+type collectionService struct {
+	repo    repository.CollectionRepository
+	artRepo repository.ArticleRepository
+}
+
+func NewCollectionService(repo repository.CollectionRepository, artRepo repository.ArticleRepository) CollectionService {
+	return &collectionService{
+		repo:    repo,
+		artRepo: artRepo,
+	}
+}
+
+func (c *collectionService) Create(ctx context.Context, col domain.Collection) (int64, error) {
+	return c.repo.Create(ctx, col)
+}
+
+func (c *collectionService) Update(ctx context.Context, col domain.Collection) error {
+	return c.repo.Update(ctx, col)
+}
+
+func (c *collectionService) Delete(ctx context.Context, id int64, uid int64) error {
+	return c.repo.Delete(ctx, id, uid)
+}
+
+func (c *collectionService) List(ctx context.Context, uid int64, viewer int64) ([]domain.Collection, error) {
+	return c.repo.FindByUid(ctx, uid, uid != viewer)
+}
+
+func (c *collectionService) Items(ctx context.Context, cid int64, uid int64, viewer int64,
+	offset int, limit int) ([]domain.CollectionItem, error) {
+	if cid > 0 {
+		col, err := c.repo.FindById(ctx, cid)
+		if err != nil {
+			return nil, err
+		}
+		uid = col.Uid
+		if col.Visibility != domain.CollectionVisibilityPublic && uid != viewer {
+			return nil, ErrCollectionNoPermission
+		}
+	} else if uid != viewer {
+		return nil, ErrCollectionNoPermission
+	}
+	items, err := c.repo.FindItems(ctx, cid, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	artIds := make([]int64, 0, len(items))
+	for _, item := range items {
+		if item.Biz == "article" {
+			artIds = append(artIds, item.BizId)
+		}
+	}
+	arts, err := c.artRepo.GetPubByArtIds(ctx, artIds)
+	if err != nil {
+		return nil, err
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		artMap[art.Id] = art
+	}
+	for i := range items {
+		if items[i].Biz == "article" {
+			// 找不到说明文章已经没了，前端展示已失效
+			items[i].Article = artMap[items[i].BizId]
+		}
+	}
+	return items, nil
+}
@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+var (
+	ErrCommentNotFound     = repository.ErrCommentNotFound
+	ErrCommentNoPermission = errors.New("没有权限删除该评论")
+	ErrCommentInvalidReply = errors.New("回复的评论不属于该资源")
+	ErrCommentInvalidBiz   = errors.New("评论的资源不存在")
+)
+
+type CommentService interface {
+	// Comment 发表评论 ParentComment 不为nil时为回复
+	Comment(ctx context.Context, c domain.Comment) (int64, error)
+	// Delete 评论者或者文章作者可以删除
+	Delete(ctx context.Context, id int64, uid int64) error
+	List(ctx context.Context, biz string, bizId int64, sort domain.CommentSort,
+		cursor domain.CommentCursor, limit int) ([]domain.Comment, error)
+	Replies(ctx context.Context, rid int64, lastId int64, limit int) ([]domain.Comment, error)
+	Like(ctx context.Context, id int64, uid int64) error
+	CancelLike(ctx context.Context, id int64, uid int64) error
+}
+
+type commentService struct {
+	repo     repository.CommentRepository
+	artRepo  repository.ArticleRepository
+	producer notification.Producer
+	l        logger.Logger
+}
+
+func NewCommentService(repo repository.CommentRepository, artRepo repository.ArticleRepository,
+	producer notification.Producer, l logger.Logger) CommentService {
+	return &commentService{
+		repo:     repo,
+		artRepo:  artRepo,
+		producer: producer,
+		l:        l,
+	}
+}
+
+func (c *commentService) Comment(ctx context.Context, cmt domain.Comment) (int64, error) {
+	err := c.checkBiz(ctx, cmt.Biz, cmt.BizId)
+	if err != nil {
+		return 0, err
+	}
+	// 评论通知资源的作者，回复通知被回复的人
+	evt := notification.Event{
+		Type:  string(domain.NotificationTypeComment),
+		Actor: cmt.Commentator.Id,
+		Biz:   cmt.Biz,
+		BizId: cmt.BizId,
+	}
+	if cmt.ParentComment != nil {
+		parent, err := c.repo.FindById(ctx, cmt.ParentComment.Id)
+		if err != nil {
+			return 0, err
+		}
+		if parent.Biz != cmt.Biz || parent.BizId != cmt.BizId {
+			return 0, ErrCommentInvalidReply
+		}
+		// 回复挂在根评论下面，只有两层
+		if parent.RootComment != nil {
+			cmt.RootComment = &domain.Comment{Id: parent.RootComment.Id}
+		} else {
+			cmt.RootComment = &domain.Comment{Id: parent.Id}
+		}
+		evt.Receiver = parent.Commentator.Id
+		evt.Biz = "comment"
+		evt.BizId = parent.Id
+	}
+	id, err := c.repo.CreateComment(ctx, cmt)
+	if err != nil {
+		return 0, err
+	}
+	produceNotification(c.producer, c.l, evt)
+	return id, nil
+}
+
+func (c *commentService) Delete(ctx context.Context, id int64, uid int64) error {
+	cmt, err := c.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	if cmt.Commentator.Id != uid {
+		ok, err := c.isBizAuthor(ctx, cmt.Biz, cmt.BizId, uid)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return ErrCommentNoPermission
+		}
+	}
+	return c.repo.DeleteComment(ctx, cmt)
+}
+
+// checkBiz 目前只能评论已经发表的文章
+func (c *commentService) checkBiz(ctx context.Context, biz string, bizId int64) error {
+	if biz != "article" {
+		return ErrCommentInvalidBiz
+	}
+	_, err := c.artRepo.GetPubByArtId(ctx, bizId)
+	if errors.Is(err, repository.ErrArticleNotFound) {
+		return ErrCommentInvalidBiz
+	}
+	return err
+}
+
+// isBizAuthor 目前只有文章有作者的概念
+func (c *commentService) isBizAuthor(ctx context.Context, biz string, bizId int64, uid int64) (bool, error) {
+	if biz != "article" {
+		return false, nil
+	}
+	art, err := c.artRepo.GetPubByArtId(ctx, bizId)
+	if err != nil {
+		return false, err
+	}
+	return art.Author.Id == uid, nil
+}
+
+func (c *commentService) List(ctx context.Context, biz string, bizId int64, sort domain.CommentSort,
+	cursor domain.CommentCursor, limit int) ([]domain.Comment, error) {
+	return c.repo.FindByBiz(ctx, biz, bizId, sort, cursor, limit)
+}
+
+func (c *commentService) Replies(ctx context.Context, rid int64, lastId int64, limit int) ([]domain.Comment, error) {
+	return c.repo.FindReplies(ctx, rid, lastId, limit)
+}
+
+func (c *commentService) Like(ctx context.Context, id int64, uid int64) error {
+	return c.repo.Like(ctx, id, uid)
+}
+
+func (c *commentService) CancelLike(ctx context.Context, id int64, uid int64) error {
+	return c.repo.CancelLike(ctx, id, uid)
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+func TestCommentService_Comment(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository)
+		cmt  domain.Comment
+
+		wantId  int64
+		wantEvt *notification.Event
+		wantErr error
+	}{
+		{
+			name: "评论文章，通知作者",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				repo.EXPECT().CreateComment(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Content: "评论", Commentator: domain.User{Id: 123},
+				}).Return(int64(10), nil)
+				return repo, artRepo
+			},
+			cmt: domain.Comment{
+				Biz: "article", BizId: 1, Content: "评论", Commentator: domain.User{Id: 123},
+			},
+			wantId: 10,
+			wantEvt: &notification.Event{
+				Type:  string(domain.NotificationTypeComment),
+				Actor: 123,
+				Biz:   "article",
+				BizId: 1,
+			},
+		},
+		{
+			name: "回复根评论，挂在根评论下面，通知被回复的人",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(domain.Comment{
+					Id: 5, Biz: "article", BizId: 1, Commentator: domain.User{Id: 456},
+				}, nil)
+				repo.EXPECT().CreateComment(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Content: "回复", Commentator: domain.User{Id: 123},
+					RootComment: &domain.Comment{Id: 5}, ParentComment: &domain.Comment{Id: 5},
+				}).Return(int64(11), nil)
+				return repo, artRepo
+			},
+			cmt: domain.Comment{
+				Biz: "article", BizId: 1, Content: "回复", Commentator: domain.User{Id: 123},
+				ParentComment: &domain.Comment{Id: 5},
+			},
+			wantId: 11,
+			wantEvt: &notification.Event{
+				Type:     string(domain.NotificationTypeComment),
+				Actor:    123,
+				Receiver: 456,
+				Biz:      "comment",
+				BizId:    5,
+			},
+		},
+		{
+			name: "回复一条回复，还是挂在根评论下面",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(7)).Return(domain.Comment{
+					Id: 7, Biz: "article", BizId: 1, Commentator: domain.User{Id: 789},
+					RootComment: &domain.Comment{Id: 5}, ParentComment: &domain.Comment{Id: 5},
+				}, nil)
+				repo.EXPECT().CreateComment(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Content: "回复", Commentator: domain.User{Id: 123},
+					RootComment: &domain.Comment{Id: 5}, ParentComment: &domain.Comment{Id: 7},
+				}).Return(int64(12), nil)
+				return repo, artRepo
+			},
+			cmt: domain.Comment{
+				Biz: "article", BizId: 1, Content: "回复", Commentator: domain.User{Id: 123},
+				ParentComment: &domain.Comment{Id: 7},
+			},
+			wantId: 12,
+			wantEvt: &notification.Event{
+				Type:     string(domain.NotificationTypeComment),
+				Actor:    123,
+				Receiver: 789,
+				Biz:      "comment",
+				BizId:    7,
+			},
+		},
+		{
+			name: "回复的评论不属于这篇文章",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).Return(domain.Article{Id: 1}, nil)
+				repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(domain.Comment{
+					Id: 5, Biz: "article", BizId: 2,
+				}, nil)
+				return repo, artRepo
+			},
+			cmt: domain.Comment{
+				Biz: "article", BizId: 1, Content: "回复", Commentator: domain.User{Id: 123},
+				ParentComment: &domain.Comment{Id: 5},
+			},
+			wantErr: ErrCommentInvalidReply,
+		},
+		{
+			name: "文章不存在",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(domain.Article{}, repository.ErrArticleNotFound)
+				return repo, artRepo
+			},
+			cmt: domain.Comment{
+				Biz: "article", BizId: 1, Content: "评论", Commentator: domain.User{Id: 123},
+			},
+			wantErr: ErrCommentInvalidBiz,
+		},
+		{
+			name: "不支持评论的资源",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				return repomocks.NewMockCommentRepository(ctrl), repomocks.NewMockArticleRepository(ctrl)
+			},
+			cmt: domain.Comment{
+				Biz: "user", BizId: 1, Content: "评论", Commentator: domain.User{Id: 123},
+			},
+			wantErr: ErrCommentInvalidBiz,
+		},
+		{
+			name: "查文章失败",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(domain.Article{}, errors.New("db error"))
+				return repo, artRepo
+			},
+			cmt: domain.Comment{
+				Biz: "article", BizId: 1, Content: "评论", Commentator: domain.User{Id: 123},
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			producer := newChanProducer()
+			svc := NewCommentService(repo, artRepo, producer, logger.NewNopLogger())
+			id, err := svc.Comment(context.Background(), tc.cmt)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantId, id)
+			producer.assertProduced(t, tc.wantEvt)
+		})
+	}
+}
+
+func TestCommentService_Delete(t *testing.T) {
+	cmt := domain.Comment{Id: 5, Biz: "article", BizId: 1, Commentator: domain.User{Id: 123}}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository)
+		uid  int64
+
+		wantErr error
+	}{
+		{
+			name: "评论者自己删除",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), cmt).Return(nil)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid: 123,
+		},
+		{
+			name: "文章作者删除",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 456}}, nil)
+				repo.EXPECT().DeleteComment(gomock.Any(), cmt).Return(nil)
+				return repo, artRepo
+			},
+			uid: 456,
+		},
+		{
+			name: "别人不能删除",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				artRepo := repomocks.NewMockArticleRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(cmt, nil)
+				artRepo.EXPECT().GetPubByArtId(gomock.Any(), int64(1)).
+					Return(domain.Article{Id: 1, Author: domain.Author{Id: 456}}, nil)
+				return repo, artRepo
+			},
+			uid:     789,
+			wantErr: ErrCommentNoPermission,
+		},
+		{
+			name: "评论不存在",
+			mock: func(ctrl *gomock.Controller) (repository.CommentRepository, repository.ArticleRepository) {
+				repo := repomocks.NewMockCommentRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(5)).Return(domain.Comment{}, repository.ErrCommentNotFound)
+				return repo, repomocks.NewMockArticleRepository(ctrl)
+			},
+			uid:     123,
+			wantErr: ErrCommentNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, artRepo := tc.mock(ctrl)
+			svc := NewCommentService(repo, artRepo, nil, logger.NewNopLogger())
+			err := svc.Delete(context.Background(), 5, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
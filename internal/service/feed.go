@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+// 重建收件箱的时候每个作者拉多少篇
+const feedRebuildPerAuthor = 20
+
+// FeedService 关注流，推拉结合
+// 粉丝不多的作者发表文章的时候推到活跃粉丝的收件箱；粉丝太多的作者只写自己的发件箱，读的时候再拉过来合并
+// 很久没有看关注流的用户不推，回来的时候从关注的作者的发件箱里面重建收件箱
+type FeedService interface {
+	// Publish 文章发表之后分发给粉丝
+	Publish(ctx context.Context, art domain.Article) error
+	// Withdraw 文章撤回之后从发件箱和粉丝的收件箱里面删掉
+	Withdraw(ctx context.Context, author int64, artId int64) error
+	// Feed cursor 是上一页返回的 NextCursor，为 0 的时候从最新的开始
+	Feed(ctx context.Context, uid int64, cursor int64, limit int) (domain.Feed, error)
+}
+
+type PushPullFeedService struct {
+	repo       repository.FeedRepository
+	followRepo repository.FollowRepository
+	artRepo    repository.ArticleRepository
+	l          logger.Logger
+
+	// PushThreshold 粉丝数超过这个的作者不推
+	PushThreshold int64
+	// BatchSize 一次推给多少个粉丝
+	BatchSize int
+	// RebuildFollowees 重建收件箱的时候最多看多少个关注的作者，按关注时间倒序
+	RebuildFollowees int
+	now              func() time.Time
+}
+
+func NewPushPullFeedService(repo repository.FeedRepository, followRepo repository.FollowRepository,
+	artRepo repository.ArticleRepository, l logger.Logger) *PushPullFeedService {
+	return &PushPullFeedService{
+		repo:             repo,
+		followRepo:       followRepo,
+		artRepo:          artRepo,
+		l:                l,
+		PushThreshold:    10000,
+		BatchSize:        500,
+		RebuildFollowees: 1000,
+		now:              time.Now,
+	}
+}
+
+func (f *PushPullFeedService) Publish(ctx context.Context, art domain.Article) error {
+	author := art.Author.Id
+	item := domain.FeedItem{ArtId: art.Id, Ctime: f.now().UnixMilli()}
+	err := f.repo.AddOutbox(ctx, author, item)
+	if err != nil {
+		return err
+	}
+	big, err := f.isBigAuthor(ctx, author)
+	if err != nil || big {
+		return err
+	}
+	return f.eachFollowers(ctx, author, func(uids []int64) error {
+		_, er := f.repo.Push(ctx, uids, item)
+		return er
+	})
+}
+
+func (f *PushPullFeedService) Withdraw(ctx context.Context, author int64, artId int64) error {
+	err := f.repo.RemoveOutbox(ctx, author, artId)
+	if err != nil {
+		return err
+	}
+	// 大 V 的文章没有推到收件箱里面，读的时候也会过滤掉撤回的文章
+	big, err := f.isBigAuthor(ctx, author)
+	if err != nil || big {
+		return err
+	}
+	return f.eachFollowers(ctx, author, func(uids []int64) error {
+		return f.repo.Remove(ctx, uids, []int64{artId})
+	})
+}
+
+func (f *PushPullFeedService) isBigAuthor(ctx context.Context, author int64) (bool, error) {
+	stat, err := f.followRepo.GetStatistic(ctx, author)
+	if err != nil {
+		return false, err
+	}
+	if stat.Followers <= f.PushThreshold {
+		return false, nil
+	}
+	return true, f.repo.AddBigAuthor(ctx, author)
+}
+
+// eachFollowers 分批遍历作者的粉丝
+func (f *PushPullFeedService) eachFollowers(ctx context.Context, author int64, fn func(uids []int64) error) error {
+	var cursor int64
+	for {
+		rs, err := f.followRepo.Followers(ctx, author, cursor, f.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(rs) == 0 {
+			return nil
+		}
+		uids := make([]int64, 0, len(rs))
+		for _, r := range rs {
+			uids = append(uids, r.Follower)
+		}
+		if err = fn(uids); err != nil {
+			return err
+		}
+		if len(rs) < f.BatchSize {
+			return nil
+		}
+		cursor = rs[len(rs)-1].Id
+	}
+}
+
+func (f *PushPullFeedService) Feed(ctx context.Context, uid int64, cursor int64, limit int) (domain.Feed, error) {
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	active, err := f.repo.Touch(ctx, uid)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	if !active {
+		if err = f.rebuild(ctx, uid); err != nil {
+			return domain.Feed{}, err
+		}
+	}
+	items, err := f.repo.Inbox(ctx, uid, cursor, limit)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	pulled, err := f.pull(ctx, uid, cursor, limit)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	items = f.merge(append(items, pulled...), limit)
+
+	var res domain.Feed
+	if len(items) == limit {
+		res.NextCursor = items[len(items)-1].Ctime
+	}
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ArtId)
+	}
+	arts, err := f.artRepo.GetPubByArtIds(ctx, ids)
+	if err != nil {
+		return domain.Feed{}, err
+	}
+	found := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		if art.Status == domain.ArticleStatusPublished {
+			found[art.Id] = art
+		}
+	}
+	// 撤回、删除了的顺便从收件箱里面清掉，这一页会少几篇
+	var stale []int64
+	res.Articles = make([]domain.Article, 0, len(ids))
+	for _, id := range ids {
+		art, ok := found[id]
+		if !ok {
+			stale = append(stale, id)
+			continue
+		}
+		res.Articles = append(res.Articles, art)
+	}
+	if len(stale) > 0 {
+		if er := f.repo.Remove(ctx, []int64{uid}, stale); er != nil {
+			f.l.Error("清理收件箱失败", logger.Int64("uid", uid), logger.Error(er))
+		}
+	}
+	return res, nil
+}
+
+// pull 关注了的大 V 的发件箱
+func (f *PushPullFeedService) pull(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FeedItem, error) {
+	bigs, err := f.repo.BigAuthors(ctx)
+	if err != nil || len(bigs) == 0 {
+		return nil, err
+	}
+	followed, err := f.followRepo.FollowedIn(ctx, uid, bigs)
+	if err != nil {
+		return nil, err
+	}
+	return f.repo.Outboxes(ctx, followed, cursor, limit)
+}
+
+// rebuild 从最近关注的作者的发件箱里面拉
+func (f *PushPullFeedService) rebuild(ctx context.Context, uid int64) error {
+	var (
+		cursor int64
+		items  []domain.FeedItem
+	)
+	for cnt := 0; cnt < f.RebuildFollowees; {
+		rs, err := f.followRepo.Followees(ctx, uid, cursor, f.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(rs) == 0 {
+			break
+		}
+		authors := make([]int64, 0, len(rs))
+		for _, r := range rs {
+			authors = append(authors, r.Followee)
+		}
+		res, err := f.repo.Outboxes(ctx, authors, 0, feedRebuildPerAuthor)
+		if err != nil {
+			return err
+		}
+		items = append(items, res...)
+		cnt += len(rs)
+		if len(rs) < f.BatchSize {
+			break
+		}
+		cursor = rs[len(rs)-1].Id
+	}
+	return f.repo.Rebuild(ctx, uid, items)
+}
+
+// merge 去重之后按发表时间倒序取前 limit 个，收件箱和大 V 的发件箱可能有同一篇
+func (f *PushPullFeedService) merge(items []domain.FeedItem, limit int) []domain.FeedItem {
+	seen := make(map[int64]struct{}, len(items))
+	res := make([]domain.FeedItem, 0, len(items))
+	for _, item := range items {
+		if _, ok := seen[item.ArtId]; ok {
+			continue
+		}
+		seen[item.ArtId] = struct{}{}
+		res = append(res, item)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Ctime != res[j].Ctime {
+			return res[i].Ctime > res[j].Ctime
+		}
+		return res[i].ArtId > res[j].ArtId
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return res
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+type feedArticleRepository struct {
+	repository.ArticleRepository
+	arts map[int64]domain.Article
+}
+
+func (f *feedArticleRepository) GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(artIds))
+	for _, id := range artIds {
+		if art, ok := f.arts[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func TestPushPullFeedService_Publish(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	item := domain.FeedItem{ArtId: 10, Ctime: now.UnixMilli()}
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository)
+	}{
+		{
+			name: "分批推给粉丝",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().AddOutbox(gomock.Any(), int64(1), item).Return(nil)
+				followRepo.EXPECT().GetStatistic(gomock.Any(), int64(1)).
+					Return(domain.FollowStatistic{Uid: 1, Followers: 3}, nil)
+				followRepo.EXPECT().Followers(gomock.Any(), int64(1), int64(0), 2).Return([]domain.FollowRelation{
+					{Id: 9, Follower: 5},
+					{Id: 8, Follower: 4},
+				}, nil)
+				repo.EXPECT().Push(gomock.Any(), []int64{5, 4}, item).Return(1, nil)
+				followRepo.EXPECT().Followers(gomock.Any(), int64(1), int64(8), 2).Return([]domain.FollowRelation{
+					{Id: 3, Follower: 2},
+				}, nil)
+				repo.EXPECT().Push(gomock.Any(), []int64{2}, item).Return(1, nil)
+				return repo, followRepo
+			},
+		},
+		{
+			name: "大V只写发件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().AddOutbox(gomock.Any(), int64(1), item).Return(nil)
+				followRepo.EXPECT().GetStatistic(gomock.Any(), int64(1)).
+					Return(domain.FollowStatistic{Uid: 1, Followers: 100}, nil)
+				repo.EXPECT().AddBigAuthor(gomock.Any(), int64(1)).Return(nil)
+				return repo, followRepo
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, followRepo := tc.mock(ctrl)
+			svc := NewPushPullFeedService(repo, followRepo, nil, logger.NewNopLogger())
+			svc.PushThreshold = 10
+			svc.BatchSize = 2
+			svc.now = func() time.Time {
+				return now
+			}
+			err := svc.Publish(context.Background(), domain.Article{Id: 10, Author: domain.Author{Id: 1}})
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPushPullFeedService_Feed(t *testing.T) {
+	arts := map[int64]domain.Article{
+		1: {Id: 1, Status: domain.ArticleStatusPublished},
+		2: {Id: 2, Status: domain.ArticleStatusPrivate},
+		3: {Id: 3, Status: domain.ArticleStatusPublished},
+		4: {Id: 4, Status: domain.ArticleStatusPublished},
+	}
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository)
+		wantFeed domain.Feed
+	}{
+		{
+			name: "合并大V的发件箱，清掉撤回的",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Touch(gomock.Any(), int64(7)).Return(true, nil)
+				repo.EXPECT().Inbox(gomock.Any(), int64(7), int64(0), 3).Return([]domain.FeedItem{
+					{ArtId: 3, Ctime: 300},
+					{ArtId: 2, Ctime: 200},
+					{ArtId: 1, Ctime: 100},
+				}, nil)
+				repo.EXPECT().BigAuthors(gomock.Any()).Return([]int64{20, 21}, nil)
+				followRepo.EXPECT().FollowedIn(gomock.Any(), int64(7), []int64{20, 21}).Return([]int64{20}, nil)
+				repo.EXPECT().Outboxes(gomock.Any(), []int64{20}, int64(0), 3).Return([]domain.FeedItem{
+					{ArtId: 4, Ctime: 250},
+					{ArtId: 3, Ctime: 300},
+				}, nil)
+				repo.EXPECT().Remove(gomock.Any(), []int64{7}, []int64{2}).Return(nil)
+				return repo, followRepo
+			},
+			wantFeed: domain.Feed{
+				Articles:   []domain.Article{arts[3], arts[4]},
+				NextCursor: 200,
+			},
+		},
+		{
+			name: "不活跃的用户先重建收件箱",
+			mock: func(ctrl *gomock.Controller) (repository.FeedRepository, repository.FollowRepository) {
+				repo := repomocks.NewMockFeedRepository(ctrl)
+				followRepo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Touch(gomock.Any(), int64(7)).Return(false, nil)
+				followRepo.EXPECT().Followees(gomock.Any(), int64(7), int64(0), 500).Return([]domain.FollowRelation{
+					{Id: 5, Follower: 7, Followee: 30},
+				}, nil)
+				items := []domain.FeedItem{{ArtId: 1, Ctime: 100}}
+				repo.EXPECT().Outboxes(gomock.Any(), []int64{30}, int64(0), feedRebuildPerAuthor).Return(items, nil)
+				repo.EXPECT().Rebuild(gomock.Any(), int64(7), items).Return(nil)
+				repo.EXPECT().Inbox(gomock.Any(), int64(7), int64(0), 3).Return(items, nil)
+				repo.EXPECT().BigAuthors(gomock.Any()).Return(nil, nil)
+				return repo, followRepo
+			},
+			wantFeed: domain.Feed{
+				Articles: []domain.Article{arts[1]},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			repo, followRepo := tc.mock(ctrl)
+			svc := NewPushPullFeedService(repo, followRepo, &feedArticleRepository{arts: arts}, logger.NewNopLogger())
+			feed, err := svc.Feed(context.Background(), 7, 0, 3)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantFeed, feed)
+		})
+	}
+}
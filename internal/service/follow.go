@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+var ErrFollowSelf = errors.New("不能关注自己")
+
+type FollowService interface {
+	Follow(ctx context.Context, follower int64, followee int64) error
+	CancelFollow(ctx context.Context, follower int64, followee int64) error
+	// Followers uid 的粉丝，Mutual 表示 uid 也关注了对方
+	Followers(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FollowRelation, error)
+	// Followees uid 关注的人，Mutual 表示对方也关注了 uid
+	Followees(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FollowRelation, error)
+	// Followed follower 是否关注了 followee
+	Followed(ctx context.Context, follower int64, followee int64) (bool, error)
+	// Statistic viewer 看 uid 的时候的关注信息，viewer 为 0 表示没有登录
+	Statistic(ctx context.Context, viewer int64, uid int64) (domain.FollowStatistic, error)
+}
+
+type followService struct {
+	repo     repository.FollowRepository
+	producer notification.Producer
+	l        logger.Logger
+}
+
+func NewFollowService(repo repository.FollowRepository, producer notification.Producer, l logger.Logger) FollowService {
+	return &followService{
+		repo:     repo,
+		producer: producer,
+		l:        l,
+	}
+}
+
+func (f *followService) Follow(ctx context.Context, follower int64, followee int64) error {
+	if follower == followee {
+		return ErrFollowSelf
+	}
+	changed, err := f.repo.Follow(ctx, follower, followee)
+	// 重复关注不再通知
+	if err != nil || !changed {
+		return err
+	}
+	produceNotification(f.producer, f.l, notification.Event{
+		Type:     string(domain.NotificationTypeFollow),
+		Actor:    follower,
+		Receiver: followee,
+		Biz:      "user",
+		BizId:    followee,
+	})
+	return nil
+}
+
+func (f *followService) CancelFollow(ctx context.Context, follower int64, followee int64) error {
+	return f.repo.CancelFollow(ctx, follower, followee)
+}
+
+func (f *followService) Followers(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	rs, err := f.repo.Followers(ctx, uid, cursor, limit)
+	if err != nil || len(rs) == 0 {
+		return rs, err
+	}
+	ids := make([]int64, 0, len(rs))
+	for _, r := range rs {
+		ids = append(ids, r.Follower)
+	}
+	mutual, err := f.repo.FollowedIn(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	f.fillMutual(rs, mutual, func(r domain.FollowRelation) int64 {
+		return r.Follower
+	})
+	return rs, nil
+}
+
+func (f *followService) Followees(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FollowRelation, error) {
+	rs, err := f.repo.Followees(ctx, uid, cursor, limit)
+	if err != nil || len(rs) == 0 {
+		return rs, err
+	}
+	ids := make([]int64, 0, len(rs))
+	for _, r := range rs {
+		ids = append(ids, r.Followee)
+	}
+	mutual, err := f.repo.FollowersIn(ctx, uid, ids)
+	if err != nil {
+		return nil, err
+	}
+	f.fillMutual(rs, mutual, func(r domain.FollowRelation) int64 {
+		return r.Followee
+	})
+	return rs, nil
+}
+
+func (f *followService) fillMutual(rs []domain.FollowRelation, mutual []int64, other func(r domain.FollowRelation) int64) {
+	m := make(map[int64]struct{}, len(mutual))
+	for _, id := range mutual {
+		m[id] = struct{}{}
+	}
+	for i := range rs {
+		_, rs[i].Mutual = m[other(rs[i])]
+	}
+}
+
+func (f *followService) Followed(ctx context.Context, follower int64, followee int64) (bool, error) {
+	if follower == followee {
+		return false, nil
+	}
+	ids, err := f.repo.FollowedIn(ctx, follower, []int64{followee})
+	return len(ids) > 0, err
+}
+
+func (f *followService) Statistic(ctx context.Context, viewer int64, uid int64) (domain.FollowStatistic, error) {
+	s, err := f.repo.GetStatistic(ctx, uid)
+	if err != nil {
+		return domain.FollowStatistic{}, err
+	}
+	if viewer == 0 || viewer == uid {
+		return s, nil
+	}
+	s.Followed, err = f.Followed(ctx, viewer, uid)
+	if err != nil {
+		return domain.FollowStatistic{}, err
+	}
+	s.FollowedBy, err = f.Followed(ctx, uid, viewer)
+	if err != nil {
+		return domain.FollowStatistic{}, err
+	}
+	return s, nil
+}
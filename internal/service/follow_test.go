@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+func TestFollowService_Follow(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.FollowRepository
+		followee int64
+
+		wantEvt *notification.Event
+		wantErr error
+	}{
+		{
+			name: "第一次关注通知对方",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Follow(gomock.Any(), int64(1), int64(2)).Return(true, nil)
+				return repo
+			},
+			followee: 2,
+			wantEvt: &notification.Event{
+				Type:     string(domain.NotificationTypeFollow),
+				Actor:    1,
+				Receiver: 2,
+				Biz:      "user",
+				BizId:    2,
+			},
+		},
+		{
+			name: "重复关注不通知",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Follow(gomock.Any(), int64(1), int64(2)).Return(false, nil)
+				return repo
+			},
+			followee: 2,
+		},
+		{
+			name: "关注自己",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				return repomocks.NewMockFollowRepository(ctrl)
+			},
+			followee: 1,
+			wantErr:  ErrFollowSelf,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			producer := newChanProducer()
+			svc := NewFollowService(tc.mock(ctrl), producer, logger.NewNopLogger())
+			err := svc.Follow(context.Background(), 1, tc.followee)
+			assert.Equal(t, tc.wantErr, err)
+			producer.assertProduced(t, tc.wantEvt)
+		})
+	}
+}
+
+func TestFollowService_Followers(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.FollowRepository
+		wantRes []domain.FollowRelation
+		wantErr error
+	}{
+		{
+			name: "标记互相关注",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Followers(gomock.Any(), int64(1), int64(0), 2).Return([]domain.FollowRelation{
+					{Id: 5, Follower: 3, Followee: 1},
+					{Id: 4, Follower: 2, Followee: 1},
+				}, nil)
+				repo.EXPECT().FollowedIn(gomock.Any(), int64(1), []int64{3, 2}).Return([]int64{2}, nil)
+				return repo
+			},
+			wantRes: []domain.FollowRelation{
+				{Id: 5, Follower: 3, Followee: 1},
+				{Id: 4, Follower: 2, Followee: 1, Mutual: true},
+			},
+		},
+		{
+			name: "没有粉丝",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Followers(gomock.Any(), int64(1), int64(0), 2).Return(nil, nil)
+				return repo
+			},
+		},
+		{
+			name: "查互相关注失败",
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().Followers(gomock.Any(), int64(1), int64(0), 2).Return([]domain.FollowRelation{
+					{Id: 5, Follower: 3, Followee: 1},
+				}, nil)
+				repo.EXPECT().FollowedIn(gomock.Any(), int64(1), []int64{3}).Return(nil, errors.New("db error"))
+				return repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewFollowService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			res, err := svc.Followers(context.Background(), 1, 0, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
+
+func TestFollowService_Statistic(t *testing.T) {
+	stat := domain.FollowStatistic{Uid: 2, Followers: 10, Followees: 3}
+	testCases := []struct {
+		name    string
+		viewer  int64
+		mock    func(ctrl *gomock.Controller) repository.FollowRepository
+		wantRes domain.FollowStatistic
+	}{
+		{
+			name:   "没有登录",
+			viewer: 0,
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().GetStatistic(gomock.Any(), int64(2)).Return(stat, nil)
+				return repo
+			},
+			wantRes: stat,
+		},
+		{
+			name:   "互相关注",
+			viewer: 1,
+			mock: func(ctrl *gomock.Controller) repository.FollowRepository {
+				repo := repomocks.NewMockFollowRepository(ctrl)
+				repo.EXPECT().GetStatistic(gomock.Any(), int64(2)).Return(stat, nil)
+				repo.EXPECT().FollowedIn(gomock.Any(), int64(1), []int64{2}).Return([]int64{2}, nil)
+				repo.EXPECT().FollowedIn(gomock.Any(), int64(2), []int64{1}).Return([]int64{1}, nil)
+				return repo
+			},
+			wantRes: domain.FollowStatistic{Uid: 2, Followers: 10, Followees: 3, Followed: true, FollowedBy: true},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewFollowService(tc.mock(ctrl), nil, logger.NewNopLogger())
+			res, err := svc.Statistic(context.Background(), tc.viewer, 2)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+)
+
+var ErrInvalidProgress = errors.New("阅读进度不合法")
+
+// HistoryService 阅读历史和继续阅读
+// 打开文章的阅读事件异步记录历史，滚动进度由客户端单独上报
+type HistoryService interface {
+	// ReportProgress 暂停了阅读历史的时候直接忽略
+	ReportProgress(ctx context.Context, uid int64, artId int64, progress uint8) error
+	// List 按最近阅读时间倒序，已经撤回的文章 Article 是空的，用户还能删掉
+	List(ctx context.Context, uid int64, offset int, limit int) ([]domain.ReadHistory, error)
+	// Continue 最近读了一部分没有读完的文章，已经撤回的不返回
+	Continue(ctx context.Context, uid int64, limit int) ([]domain.ReadHistory, error)
+	// Delete artIds 为空的时候清空
+	Delete(ctx context.Context, uid int64, artIds []int64) error
+	Paused(ctx context.Context, uid int64) (bool, error)
+	SetPaused(ctx context.Context, uid int64, paused bool) error
+}
+
+type DBHistoryService struct {
+	repo    repository.HistoryRepository
+	artRepo repository.ArticleRepository
+
+	// FinishedProgress 进度到了这里就算读完了，文章最后的评论区一般不会滚到底
+	FinishedProgress uint8
+	// ContinueWindow 继续阅读只看这么久之内读过的
+	ContinueWindow time.Duration
+	now            func() time.Time
+}
+
+func NewDBHistoryService(repo repository.HistoryRepository, artRepo repository.ArticleRepository) *DBHistoryService {
+	return &DBHistoryService{
+		repo:             repo,
+		artRepo:          artRepo,
+		FinishedProgress: 95,
+		ContinueWindow:   30 * 24 * time.Hour,
+		now:              time.Now,
+	}
+}
+
+func (d *DBHistoryService) ReportProgress(ctx context.Context, uid int64, artId int64, progress uint8) error {
+	if progress > 100 {
+		return ErrInvalidProgress
+	}
+	paused, err := d.repo.Paused(ctx, uid)
+	if err != nil || paused {
+		return err
+	}
+	// 不能给不存在的文章记进度
+	_, err = d.artRepo.GetPubByArtId(ctx, artId)
+	if err != nil {
+		return err
+	}
+	return d.repo.UpdateProgress(ctx, uid, artId, progress)
+}
+
+func (d *DBHistoryService) List(ctx context.Context, uid int64, offset int, limit int) ([]domain.ReadHistory, error) {
+	hs, err := d.repo.List(ctx, uid, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+	return d.fillArticles(ctx, hs, false)
+}
+
+func (d *DBHistoryService) Continue(ctx context.Context, uid int64, limit int) ([]domain.ReadHistory, error) {
+	since := d.now().Add(-d.ContinueWindow)
+	hs, err := d.repo.ListUnfinished(ctx, uid, d.FinishedProgress, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	return d.fillArticles(ctx, hs, true)
+}
+
+// fillArticles dropMissing 为 true 的时候去掉已经撤回的
+func (d *DBHistoryService) fillArticles(ctx context.Context, hs []domain.ReadHistory, dropMissing bool) ([]domain.ReadHistory, error) {
+	if len(hs) == 0 {
+		return hs, nil
+	}
+	ids := make([]int64, 0, len(hs))
+	for _, h := range hs {
+		ids = append(ids, h.ArtId)
+	}
+	arts, err := d.artRepo.GetPubByArtIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		if art.Status == domain.ArticleStatusPublished {
+			found[art.Id] = art
+		}
+	}
+	res := make([]domain.ReadHistory, 0, len(hs))
+	for _, h := range hs {
+		art, ok := found[h.ArtId]
+		if !ok && dropMissing {
+			continue
+		}
+		h.Article = art
+		res = append(res, h)
+	}
+	return res, nil
+}
+
+func (d *DBHistoryService) Delete(ctx context.Context, uid int64, artIds []int64) error {
+	return d.repo.Delete(ctx, uid, artIds)
+}
+
+func (d *DBHistoryService) Paused(ctx context.Context, uid int64) (bool, error) {
+	return d.repo.Paused(ctx, uid)
+}
+
+func (d *DBHistoryService) SetPaused(ctx context.Context, uid int64, paused bool) error {
+	return d.repo.SetPaused(ctx, uid, paused)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+)
+
+type historyArticleRepository struct {
+	repository.ArticleRepository
+	arts map[int64]domain.Article
+}
+
+func (h *historyArticleRepository) GetPubByArtId(ctx context.Context, artId int64) (domain.Article, error) {
+	art, ok := h.arts[artId]
+	if !ok {
+		return domain.Article{}, repository.ErrArticleNotFound
+	}
+	return art, nil
+}
+
+func (h *historyArticleRepository) GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(artIds))
+	for _, id := range artIds {
+		if art, ok := h.arts[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func TestDBHistoryService(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	arts := map[int64]domain.Article{
+		1: {Id: 1, Title: "a", Status: domain.ArticleStatusPublished},
+		2: {Id: 2, Title: "b", Status: domain.ArticleStatusPrivate},
+	}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.HistoryRepository
+		call    func(svc HistoryService) ([]domain.ReadHistory, error)
+		want    []domain.ReadHistory
+		wantErr error
+	}{
+		{
+			name: "上报进度",
+			mock: func(ctrl *gomock.Controller) repository.HistoryRepository {
+				repo := repomocks.NewMockHistoryRepository(ctrl)
+				repo.EXPECT().Paused(gomock.Any(), int64(7)).Return(false, nil)
+				repo.EXPECT().UpdateProgress(gomock.Any(), int64(7), int64(1), uint8(40)).Return(nil)
+				return repo
+			},
+			call: func(svc HistoryService) ([]domain.ReadHistory, error) {
+				return nil, svc.ReportProgress(context.Background(), 7, 1, 40)
+			},
+		},
+		{
+			name: "暂停了不记进度",
+			mock: func(ctrl *gomock.Controller) repository.HistoryRepository {
+				repo := repomocks.NewMockHistoryRepository(ctrl)
+				repo.EXPECT().Paused(gomock.Any(), int64(7)).Return(true, nil)
+				return repo
+			},
+			call: func(svc HistoryService) ([]domain.ReadHistory, error) {
+				return nil, svc.ReportProgress(context.Background(), 7, 1, 40)
+			},
+		},
+		{
+			name: "进度超过 100",
+			mock: func(ctrl *gomock.Controller) repository.HistoryRepository {
+				return repomocks.NewMockHistoryRepository(ctrl)
+			},
+			call: func(svc HistoryService) ([]domain.ReadHistory, error) {
+				return nil, svc.ReportProgress(context.Background(), 7, 1, 101)
+			},
+			wantErr: ErrInvalidProgress,
+		},
+		{
+			name: "文章不存在",
+			mock: func(ctrl *gomock.Controller) repository.HistoryRepository {
+				repo := repomocks.NewMockHistoryRepository(ctrl)
+				repo.EXPECT().Paused(gomock.Any(), int64(7)).Return(false, nil)
+				return repo
+			},
+			call: func(svc HistoryService) ([]domain.ReadHistory, error) {
+				return nil, svc.ReportProgress(context.Background(), 7, 3, 40)
+			},
+			wantErr: ErrArticleNotFound,
+		},
+		{
+			name: "历史里面撤回的文章留着",
+			mock: func(ctrl *gomock.Controller) repository.HistoryRepository {
+				repo := repomocks.NewMockHistoryRepository(ctrl)
+				repo.EXPECT().List(gomock.Any(), int64(7), 0, 20).Return([]domain.ReadHistory{
+					{Uid: 7, ArtId: 2, Progress: 10},
+					{Uid: 7, ArtId: 1, Progress: 100},
+				}, nil)
+				return repo
+			},
+			call: func(svc HistoryService) ([]domain.ReadHistory, error) {
+				return svc.List(context.Background(), 7, 0, 20)
+			},
+			want: []domain.ReadHistory{
+				{Uid: 7, ArtId: 2, Progress: 10},
+				{Uid: 7, ArtId: 1, Progress: 100, Article: arts[1]},
+			},
+		},
+		{
+			name: "继续阅读不要撤回的",
+			mock: func(ctrl *gomock.Controller) repository.HistoryRepository {
+				repo := repomocks.NewMockHistoryRepository(ctrl)
+				repo.EXPECT().ListUnfinished(gomock.Any(), int64(7), uint8(95), now.Add(-30*24*time.Hour), 10).
+					Return([]domain.ReadHistory{
+						{Uid: 7, ArtId: 2, Progress: 10},
+						{Uid: 7, ArtId: 1, Progress: 60},
+					}, nil)
+				return repo
+			},
+			call: func(svc HistoryService) ([]domain.ReadHistory, error) {
+				return svc.Continue(context.Background(), 7, 10)
+			},
+			want: []domain.ReadHistory{
+				{Uid: 7, ArtId: 1, Progress: 60, Article: arts[1]},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewDBHistoryService(tc.mock(ctrl), &historyArticleRepository{arts: arts})
+			svc.now = func() time.Time {
+				return now
+			}
+			res, err := tc.call(svc)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
@@ -2,22 +2,42 @@ package service
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"golang.org/x/sync/errgroup"
 	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
 	"webook/internal/repository"
+	"webook/pkg/logger"
 )
 
+// ErrInvalidReaction 不在配置里面的表情
+var ErrInvalidReaction = errors.New("不支持的表情")
+
 type InteractiveService interface {
-	IncrReadCnt(ctx context.Context, biz string, bizId int64) error
+	// IncrReadCnt uid 用来去重和统计独立读者
+	IncrReadCnt(ctx context.Context, biz string, bizId int64, uid int64) error
 	Like(ctx context.Context, biz string, bizId int64, uid int64) error
 	CancelLike(ctx context.Context, biz string, bizId int64, uid int64) error
+	// React 点表情，Like 等价于 React 默认表情 domain.ReactionLike
+	React(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
+	CancelReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error
 	AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	CancelCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error
+	MoveCollectionItem(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error
 	GetIntrByArtId(ctx context.Context, biz string, bizId int64, uid int64) (domain.Interactive, error)
+	// GetIntrByArtIds 列表页批量获取互动信息，uid 为 0 的时候不查是否点赞、收藏
+	GetIntrByArtIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error)
+	// LikeRecords 用户的点赞记录
+	LikeRecords(ctx context.Context, biz string, uid int64, offset int, limit int) ([]domain.InteractiveRecord, error)
+	// CollectRecords 用户的收藏记录，onlyPublic 为 true 的时候只返回公开收藏夹里面的
+	CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool, offset int, limit int) ([]domain.InteractiveRecord, error)
 }
 
 type interactiveService struct {
-	repo repository.InteractiveRepository
+	repo      repository.InteractiveRepository
+	reactions domain.ReactionSet
+	producer  notification.Producer
+	l         logger.Logger
 }
 
 // GetIntrByArtId 获取文章的互动信息,包括是否点赞,是否收藏
@@ -29,45 +49,137 @@ func (i *interactiveService) GetIntrByArtId(ctx context.Context, biz string, biz
 
 	var eg = errgroup.Group{}
 	eg.Go(func() error {
-		intr.Liked, err = i.repo.Liked(ctx, biz, bizId, uid)
-		if err != nil {
-			return err
+		liked, er := i.repo.Liked(ctx, biz, bizId, uid)
+		if er != nil {
+			return er
+		}
+		intr.Liked = liked
+		return nil
+	})
+	eg.Go(func() error {
+		collected, er := i.repo.Collected(ctx, biz, bizId, uid)
+		if er != nil {
+			return er
+		}
+		intr.Collected = collected
+		return nil
+	})
+	eg.Go(func() error {
+		rs, er := i.repo.Reactions(ctx, biz, bizId, uid)
+		if er != nil {
+			return er
 		}
+		intr.Reactions = rs
 		return nil
 	})
 	eg.Go(func() error {
-		intr.Collected, err = i.repo.Collected(ctx, biz, bizId, uid)
-		if err != nil {
-			return err
+		// 持久化的独立读者数是定时同步的，详情页用 redis 里面实时的
+		cnt, er := i.repo.ReaderCnt(ctx, biz, bizId)
+		if er != nil {
+			return er
+		}
+		if cnt > intr.ReaderCnt {
+			intr.ReaderCnt = cnt
 		}
 		return nil
 	})
-	if err := eg.Wait(); err != nil {
-		// 日志
-		fmt.Println(err)
+	// 计数已经拿到了，用户自己的状态查不到也返回
+	err = eg.Wait()
+	if err != nil {
+		i.l.Error("查询用户互动状态失败", logger.String("biz", biz), logger.Int64("bizId", bizId),
+			logger.Int64("uid", uid), logger.Error(err))
+	}
+	// 配置了的表情都返回，没人点过的是 0
+	cnts := make(map[string]int64, len(i.reactions))
+	for _, r := range i.reactions {
+		cnts[r] = intr.ReactionCnts[r]
 	}
+	intr.ReactionCnts = cnts
 	return intr, nil
 }
 
+func (i *interactiveService) GetIntrByArtIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	return i.repo.GetInteractives(ctx, biz, bizIds, uid)
+}
+
+func (i *interactiveService) LikeRecords(ctx context.Context, biz string, uid int64, offset int, limit int) ([]domain.InteractiveRecord, error) {
+	return i.repo.LikeRecords(ctx, biz, uid, offset, limit)
+}
+
+func (i *interactiveService) CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool,
+	offset int, limit int) ([]domain.InteractiveRecord, error) {
+	return i.repo.CollectRecords(ctx, biz, uid, onlyPublic, offset, limit)
+}
+
 // AddCollectionItem 新增收集项
 func (i *interactiveService) AddCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
-	return i.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	changed, err := i.repo.AddCollectionItem(ctx, biz, bizId, cid, uid)
+	// 重复收藏、已经收藏在别的收藏夹里面的不再通知
+	if err != nil || !changed {
+		return err
+	}
+	produceNotification(i.producer, i.l, notification.Event{
+		Type:  string(domain.NotificationTypeCollect),
+		Actor: uid,
+		Biz:   biz,
+		BizId: bizId,
+	})
+	return nil
+}
+
+// CancelCollectionItem 从收藏夹中取消收藏
+func (i *interactiveService) CancelCollectionItem(ctx context.Context, biz string, bizId int64, cid int64, uid int64) error {
+	return i.repo.DeleteCollectionItem(ctx, biz, bizId, cid, uid)
+}
+
+// MoveCollectionItem 把收藏移动到另一个收藏夹
+func (i *interactiveService) MoveCollectionItem(ctx context.Context, biz string, bizId int64, fromCid int64, toCid int64, uid int64) error {
+	return i.repo.MoveCollectionItem(ctx, biz, bizId, fromCid, toCid, uid)
 }
 
 func (i *interactiveService) CancelLike(ctx context.Context, biz string, bizId int64, uid int64) error {
-	return i.repo.DecrLike(ctx, biz, bizId, uid)
+	return i.CancelReaction(ctx, biz, bizId, uid, domain.ReactionLike)
 }
 
 func (i *interactiveService) Like(ctx context.Context, biz string, bizId int64, uid int64) error {
-	return i.repo.IncrLike(ctx, biz, bizId, uid)
+	return i.React(ctx, biz, bizId, uid, domain.ReactionLike)
+}
+
+func (i *interactiveService) React(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	if !i.reactions.Contains(reaction) {
+		return ErrInvalidReaction
+	}
+	changed, err := i.repo.AddReaction(ctx, biz, bizId, uid, reaction)
+	// 重复点赞不再通知
+	if err != nil || !changed || reaction != domain.ReactionLike {
+		return err
+	}
+	produceNotification(i.producer, i.l, notification.Event{
+		Type:  string(domain.NotificationTypeLike),
+		Actor: uid,
+		Biz:   biz,
+		BizId: bizId,
+	})
+	return nil
+}
+
+func (i *interactiveService) CancelReaction(ctx context.Context, biz string, bizId int64, uid int64, reaction string) error {
+	if !i.reactions.Contains(reaction) {
+		return ErrInvalidReaction
+	}
+	return i.repo.DeleteReaction(ctx, biz, bizId, uid, reaction)
 }
 
-func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64) error {
-	return i.repo.IncrReadCnt(ctx, biz, bizId)
+func (i *interactiveService) IncrReadCnt(ctx context.Context, biz string, bizId int64, uid int64) error {
+	return i.repo.IncrReadCnt(ctx, biz, bizId, uid)
 }
 
-func NewInteractiveService(repo repository.InteractiveRepository) InteractiveService {
+func NewInteractiveService(repo repository.InteractiveRepository, reactions domain.ReactionSet,
+	producer notification.Producer, l logger.Logger) InteractiveService {
 	return &interactiveService{
-		repo: repo,
+		repo:      repo,
+		reactions: reactions,
+		producer:  producer,
+		l:         l,
 	}
 }
@@ -0,0 +1,240 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+// chanProducer 通知是异步发的，发出来的事件放到 channel 里面
+type chanProducer struct {
+	events chan notification.Event
+}
+
+func newChanProducer() *chanProducer {
+	return &chanProducer{events: make(chan notification.Event, 1)}
+}
+
+func (c *chanProducer) ProduceEvent(event notification.Event) error {
+	c.events <- event
+	return nil
+}
+
+// assertProduced wantEvt 为 nil 的时候要求没有发通知
+func (c *chanProducer) assertProduced(t *testing.T, wantEvt *notification.Event) {
+	select {
+	case evt := <-c.events:
+		if assert.NotNil(t, wantEvt, "不应该发通知") {
+			assert.Equal(t, *wantEvt, evt)
+		}
+	case <-time.After(100 * time.Millisecond):
+		assert.Nil(t, wantEvt, "没有发通知")
+	}
+}
+
+func TestInteractiveService_React(t *testing.T) {
+	testCases := []struct {
+		name     string
+		mock     func(ctrl *gomock.Controller) repository.InteractiveRepository
+		reaction string
+
+		wantEvt *notification.Event
+		wantErr error
+	}{
+		{
+			name: "第一次点赞通知作者",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddReaction(gomock.Any(), "article", int64(1), int64(123), domain.ReactionLike).
+					Return(true, nil)
+				return repo
+			},
+			reaction: domain.ReactionLike,
+			wantEvt: &notification.Event{
+				Type:  string(domain.NotificationTypeLike),
+				Actor: 123,
+				Biz:   "article",
+				BizId: 1,
+			},
+		},
+		{
+			name: "重复点赞不通知",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddReaction(gomock.Any(), "article", int64(1), int64(123), domain.ReactionLike).
+					Return(false, nil)
+				return repo
+			},
+			reaction: domain.ReactionLike,
+		},
+		{
+			name: "其他表情不通知",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddReaction(gomock.Any(), "article", int64(1), int64(123), "funny").
+					Return(true, nil)
+				return repo
+			},
+			reaction: "funny",
+		},
+		{
+			name: "点赞失败",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddReaction(gomock.Any(), "article", int64(1), int64(123), domain.ReactionLike).
+					Return(false, errors.New("db error"))
+				return repo
+			},
+			reaction: domain.ReactionLike,
+			wantErr:  errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			producer := newChanProducer()
+			svc := NewInteractiveService(tc.mock(ctrl), domain.ReactionSet{domain.ReactionLike, "funny"},
+				producer, logger.NewNopLogger())
+			err := svc.React(context.Background(), "article", 1, 123, tc.reaction)
+			assert.Equal(t, tc.wantErr, err)
+			producer.assertProduced(t, tc.wantEvt)
+		})
+	}
+}
+
+func TestInteractiveService_AddCollectionItem(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+
+		wantEvt *notification.Event
+		wantErr error
+	}{
+		{
+			name: "第一次收藏通知作者",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(123)).
+					Return(true, nil)
+				return repo
+			},
+			wantEvt: &notification.Event{
+				Type:  string(domain.NotificationTypeCollect),
+				Actor: 123,
+				Biz:   "article",
+				BizId: 1,
+			},
+		},
+		{
+			name: "已经收藏过了不通知",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(123)).
+					Return(false, nil)
+				return repo
+			},
+		},
+		{
+			name: "收藏失败",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().AddCollectionItem(gomock.Any(), "article", int64(1), int64(2), int64(123)).
+					Return(false, repository.ErrCollectionNoPermission)
+				return repo
+			},
+			wantErr: ErrCollectionNoPermission,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			producer := newChanProducer()
+			svc := NewInteractiveService(tc.mock(ctrl), domain.ReactionSet{domain.ReactionLike},
+				producer, logger.NewNopLogger())
+			err := svc.AddCollectionItem(context.Background(), "article", 1, 2, 123)
+			assert.Equal(t, tc.wantErr, err)
+			producer.assertProduced(t, tc.wantEvt)
+		})
+	}
+}
+
+func TestInteractiveService_GetIntrByArtId(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) repository.InteractiveRepository
+
+		wantRes domain.Interactive
+		wantErr error
+	}{
+		{
+			name: "查询成功",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetInteractive(gomock.Any(), "article", int64(1)).Return(domain.Interactive{
+					BizId: 1, ReadCnt: 10, LikeCnt: 2, ReaderCnt: 3,
+					ReactionCnts: map[string]int64{domain.ReactionLike: 2},
+				}, nil)
+				repo.EXPECT().Liked(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				repo.EXPECT().Collected(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				repo.EXPECT().Reactions(gomock.Any(), "article", int64(1), int64(123)).
+					Return([]string{domain.ReactionLike}, nil)
+				repo.EXPECT().ReaderCnt(gomock.Any(), "article", int64(1)).Return(int64(5), nil)
+				return repo
+			},
+			wantRes: domain.Interactive{
+				BizId: 1, ReadCnt: 10, LikeCnt: 2, ReaderCnt: 5,
+				Liked: true, Collected: true, Reactions: []string{domain.ReactionLike},
+				ReactionCnts: map[string]int64{domain.ReactionLike: 2, "funny": 0},
+			},
+		},
+		{
+			name: "用户状态查不到也返回计数",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetInteractive(gomock.Any(), "article", int64(1)).Return(domain.Interactive{
+					BizId: 1, ReadCnt: 10,
+				}, nil)
+				repo.EXPECT().Liked(gomock.Any(), "article", int64(1), int64(123)).Return(false, errors.New("db error"))
+				repo.EXPECT().Collected(gomock.Any(), "article", int64(1), int64(123)).Return(true, nil)
+				repo.EXPECT().Reactions(gomock.Any(), "article", int64(1), int64(123)).Return(nil, nil)
+				repo.EXPECT().ReaderCnt(gomock.Any(), "article", int64(1)).Return(int64(0), nil)
+				return repo
+			},
+			wantRes: domain.Interactive{
+				BizId: 1, ReadCnt: 10, Collected: true,
+				ReactionCnts: map[string]int64{domain.ReactionLike: 0, "funny": 0},
+			},
+		},
+		{
+			name: "查计数失败",
+			mock: func(ctrl *gomock.Controller) repository.InteractiveRepository {
+				repo := repomocks.NewMockInteractiveRepository(ctrl)
+				repo.EXPECT().GetInteractive(gomock.Any(), "article", int64(1)).
+					Return(domain.Interactive{}, errors.New("db error"))
+				return repo
+			},
+			wantErr: errors.New("db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewInteractiveService(tc.mock(ctrl), domain.ReactionSet{domain.ReactionLike, "funny"},
+				nil, logger.NewNopLogger())
+			res, err := svc.GetIntrByArtId(context.Background(), "article", 1, 123)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantRes, res)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"os"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+)
+
+var (
+	ErrNoJob                = repository.ErrNoJob
+	ErrJobNotFound          = repository.ErrJobNotFound
+	ErrJobNotHold           = repository.ErrJobNotHold
+	ErrJobStatusChanged     = repository.ErrJobStatusChanged
+	ErrInvalidJobExpression = errors.New("cron 表达式不合法")
+)
+
+// JobService 数据库里面的定时任务，实例之间通过抢占来分配
+type JobService interface {
+	// Register 注册任务，新任务从表达式的下一个时间点开始执行，已有的任务只更新定义
+	Register(ctx context.Context, j domain.Job) error
+	// Preempt 抢一个到期的任务，没有的时候返回 ErrNoJob
+	Preempt(ctx context.Context) (domain.Job, error)
+	// Heartbeat 执行期间要定时续约，返回 ErrJobNotHold 说明任务已经不归自己了，要停下来
+	Heartbeat(ctx context.Context, j domain.Job) error
+	// Release 执行完释放任务，按表达式算下一次执行时间
+	Release(ctx context.Context, j domain.Job) error
+
+	List(ctx context.Context) ([]domain.Job, error)
+	Pause(ctx context.Context, id int64) error
+	Resume(ctx context.Context, id int64) error
+	// Trigger 让任务尽快执行一次
+	Trigger(ctx context.Context, id int64) error
+}
+
+type PreemptJobService struct {
+	repo repository.JobRepository
+	// owner 当前实例的标识
+	owner string
+	// Timeout 心跳超过这么久没有更新，就认为执行的实例已经挂了，任务可以被别人抢走
+	Timeout time.Duration
+	now     func() time.Time
+}
+
+func NewPreemptJobService(repo repository.JobRepository) *PreemptJobService {
+	host, _ := os.Hostname()
+	return &PreemptJobService{
+		repo:    repo,
+		owner:   fmt.Sprintf("%s-%s", host, uuid.New().String()),
+		Timeout: time.Minute,
+		now:     time.Now,
+	}
+}
+
+func (p *PreemptJobService) Register(ctx context.Context, j domain.Job) error {
+	next := j.Next(p.now())
+	if next.IsZero() {
+		return ErrInvalidJobExpression
+	}
+	j.Status = domain.JobStatusWaiting
+	j.NextTime = next.UnixMilli()
+	return p.repo.Upsert(ctx, j)
+}
+
+func (p *PreemptJobService) Preempt(ctx context.Context) (domain.Job, error) {
+	return p.repo.Preempt(ctx, p.owner, p.Timeout)
+}
+
+func (p *PreemptJobService) Heartbeat(ctx context.Context, j domain.Job) error {
+	return p.repo.Heartbeat(ctx, j.Id, p.owner)
+}
+
+func (p *PreemptJobService) Release(ctx context.Context, j domain.Job) error {
+	return p.repo.Release(ctx, j.Id, p.owner, j.Next(p.now()))
+}
+
+func (p *PreemptJobService) List(ctx context.Context) ([]domain.Job, error) {
+	return p.repo.List(ctx)
+}
+
+func (p *PreemptJobService) Pause(ctx context.Context, id int64) error {
+	_, err := p.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.repo.Pause(ctx, id)
+}
+
+func (p *PreemptJobService) Resume(ctx context.Context, id int64) error {
+	j, err := p.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.repo.Resume(ctx, id, j.Next(p.now()))
+}
+
+func (p *PreemptJobService) Trigger(ctx context.Context, id int64) error {
+	_, err := p.repo.FindById(ctx, id)
+	if err != nil {
+		return err
+	}
+	return p.repo.Trigger(ctx, id)
+}
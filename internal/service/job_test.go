@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+)
+
+func TestPreemptJobService(t *testing.T) {
+	now := time.Date(2024, 3, 1, 10, 30, 0, 0, time.Local)
+	hourly := domain.Job{Id: 1, Name: "ranking", Executor: "local", Expression: "0 * * * *"}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.JobRepository
+		call    func(svc JobService) error
+		wantErr error
+	}{
+		{
+			name: "注册任务，从下一个整点开始执行",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				j := hourly
+				j.Status = domain.JobStatusWaiting
+				j.NextTime = time.Date(2024, 3, 1, 11, 0, 0, 0, time.Local).UnixMilli()
+				repo.EXPECT().Upsert(gomock.Any(), j).Return(nil)
+				return repo
+			},
+			call: func(svc JobService) error {
+				return svc.Register(context.Background(), hourly)
+			},
+		},
+		{
+			name: "表达式不合法",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				return repomocks.NewMockJobRepository(ctrl)
+			},
+			call: func(svc JobService) error {
+				return svc.Register(context.Background(), domain.Job{Name: "bad", Expression: "every hour"})
+			},
+			wantErr: ErrInvalidJobExpression,
+		},
+		{
+			name: "释放的时候按表达式算下一次",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().Release(gomock.Any(), int64(1), "owner",
+					time.Date(2024, 3, 1, 11, 0, 0, 0, time.Local)).Return(nil)
+				return repo
+			},
+			call: func(svc JobService) error {
+				return svc.Release(context.Background(), hourly)
+			},
+		},
+		{
+			name: "恢复暂停的任务",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(1)).Return(hourly, nil)
+				repo.EXPECT().Resume(gomock.Any(), int64(1),
+					time.Date(2024, 3, 1, 11, 0, 0, 0, time.Local)).Return(nil)
+				return repo
+			},
+			call: func(svc JobService) error {
+				return svc.Resume(context.Background(), 1)
+			},
+		},
+		{
+			name: "触发不存在的任务",
+			mock: func(ctrl *gomock.Controller) repository.JobRepository {
+				repo := repomocks.NewMockJobRepository(ctrl)
+				repo.EXPECT().FindById(gomock.Any(), int64(2)).Return(domain.Job{}, repository.ErrJobNotFound)
+				return repo
+			},
+			call: func(svc JobService) error {
+				return svc.Trigger(context.Background(), 2)
+			},
+			wantErr: ErrJobNotFound,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewPreemptJobService(tc.mock(ctrl))
+			svc.owner = "owner"
+			svc.now = func() time.Time {
+				return now
+			}
+			err := tc.call(svc)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
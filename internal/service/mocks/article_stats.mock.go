@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/article_stats.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/article_stats.go -package=svcmocks -destination=./internal/service/mocks/article_stats.mock.go
+//
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockArticleStatsService is a mock of ArticleStatsService interface.
+type MockArticleStatsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockArticleStatsServiceMockRecorder
+}
+
+// MockArticleStatsServiceMockRecorder is the mock recorder for MockArticleStatsService.
+type MockArticleStatsServiceMockRecorder struct {
+	mock *MockArticleStatsService
+}
+
+// NewMockArticleStatsService creates a new mock instance.
+func NewMockArticleStatsService(ctrl *gomock.Controller) *MockArticleStatsService {
+	mock := &MockArticleStatsService{ctrl: ctrl}
+	mock.recorder = &MockArticleStatsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockArticleStatsService) EXPECT() *MockArticleStatsServiceMockRecorder {
+	return m.recorder
+}
+
+// Stats mocks base method.
+func (m *MockArticleStatsService) Stats(ctx context.Context, uid, artId int64, start, end string, granularity domain.StatsGranularity) ([]domain.InteractiveStat, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats", ctx, uid, artId, start, end, granularity)
+	ret0, _ := ret[0].([]domain.InteractiveStat)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockArticleStatsServiceMockRecorder) Stats(ctx, uid, artId, start, end, granularity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockArticleStatsService)(nil).Stats), ctx, uid, artId, start, end, granularity)
+}
@@ -3,7 +3,7 @@
 //
 // Generated by this command:
 //
-//	mockgen.exe -source=./internal/service/code.go -package=svcmocks -destination=./internal/service/mocks/code.mock.go
+//	mockgen -source=./internal/service/code.go -package=svcmocks -destination=./internal/service/mocks/code.mock.go
 //
 // Package svcmocks is a generated GoMock package.
 package svcmocks
@@ -0,0 +1,212 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./internal/service/interactive.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./internal/service/interactive.go -package=svcmocks -destination=./internal/service/mocks/interactive.mock.go
+//
+// Package svcmocks is a generated GoMock package.
+package svcmocks
+
+import (
+	context "context"
+	reflect "reflect"
+	domain "webook/internal/domain"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockInteractiveService is a mock of InteractiveService interface.
+type MockInteractiveService struct {
+	ctrl     *gomock.Controller
+	recorder *MockInteractiveServiceMockRecorder
+}
+
+// MockInteractiveServiceMockRecorder is the mock recorder for MockInteractiveService.
+type MockInteractiveServiceMockRecorder struct {
+	mock *MockInteractiveService
+}
+
+// NewMockInteractiveService creates a new mock instance.
+func NewMockInteractiveService(ctrl *gomock.Controller) *MockInteractiveService {
+	mock := &MockInteractiveService{ctrl: ctrl}
+	mock.recorder = &MockInteractiveServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInteractiveService) EXPECT() *MockInteractiveServiceMockRecorder {
+	return m.recorder
+}
+
+// AddCollectionItem mocks base method.
+func (m *MockInteractiveService) AddCollectionItem(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddCollectionItem", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddCollectionItem indicates an expected call of AddCollectionItem.
+func (mr *MockInteractiveServiceMockRecorder) AddCollectionItem(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddCollectionItem", reflect.TypeOf((*MockInteractiveService)(nil).AddCollectionItem), ctx, biz, bizId, cid, uid)
+}
+
+// CancelCollectionItem mocks base method.
+func (m *MockInteractiveService) CancelCollectionItem(ctx context.Context, biz string, bizId, cid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelCollectionItem", ctx, biz, bizId, cid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelCollectionItem indicates an expected call of CancelCollectionItem.
+func (mr *MockInteractiveServiceMockRecorder) CancelCollectionItem(ctx, biz, bizId, cid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelCollectionItem", reflect.TypeOf((*MockInteractiveService)(nil).CancelCollectionItem), ctx, biz, bizId, cid, uid)
+}
+
+// CancelLike mocks base method.
+func (m *MockInteractiveService) CancelLike(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelLike", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelLike indicates an expected call of CancelLike.
+func (mr *MockInteractiveServiceMockRecorder) CancelLike(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLike", reflect.TypeOf((*MockInteractiveService)(nil).CancelLike), ctx, biz, bizId, uid)
+}
+
+// CancelReaction mocks base method.
+func (m *MockInteractiveService) CancelReaction(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelReaction", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelReaction indicates an expected call of CancelReaction.
+func (mr *MockInteractiveServiceMockRecorder) CancelReaction(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelReaction", reflect.TypeOf((*MockInteractiveService)(nil).CancelReaction), ctx, biz, bizId, uid, reaction)
+}
+
+// CollectRecords mocks base method.
+func (m *MockInteractiveService) CollectRecords(ctx context.Context, biz string, uid int64, onlyPublic bool, offset, limit int) ([]domain.InteractiveRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CollectRecords", ctx, biz, uid, onlyPublic, offset, limit)
+	ret0, _ := ret[0].([]domain.InteractiveRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CollectRecords indicates an expected call of CollectRecords.
+func (mr *MockInteractiveServiceMockRecorder) CollectRecords(ctx, biz, uid, onlyPublic, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectRecords", reflect.TypeOf((*MockInteractiveService)(nil).CollectRecords), ctx, biz, uid, onlyPublic, offset, limit)
+}
+
+// GetIntrByArtId mocks base method.
+func (m *MockInteractiveService) GetIntrByArtId(ctx context.Context, biz string, bizId, uid int64) (domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntrByArtId", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIntrByArtId indicates an expected call of GetIntrByArtId.
+func (mr *MockInteractiveServiceMockRecorder) GetIntrByArtId(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntrByArtId", reflect.TypeOf((*MockInteractiveService)(nil).GetIntrByArtId), ctx, biz, bizId, uid)
+}
+
+// GetIntrByArtIds mocks base method.
+func (m *MockInteractiveService) GetIntrByArtIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIntrByArtIds", ctx, biz, bizIds, uid)
+	ret0, _ := ret[0].(map[int64]domain.Interactive)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIntrByArtIds indicates an expected call of GetIntrByArtIds.
+func (mr *MockInteractiveServiceMockRecorder) GetIntrByArtIds(ctx, biz, bizIds, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIntrByArtIds", reflect.TypeOf((*MockInteractiveService)(nil).GetIntrByArtIds), ctx, biz, bizIds, uid)
+}
+
+// IncrReadCnt mocks base method.
+func (m *MockInteractiveService) IncrReadCnt(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrReadCnt", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IncrReadCnt indicates an expected call of IncrReadCnt.
+func (mr *MockInteractiveServiceMockRecorder) IncrReadCnt(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrReadCnt", reflect.TypeOf((*MockInteractiveService)(nil).IncrReadCnt), ctx, biz, bizId, uid)
+}
+
+// Like mocks base method.
+func (m *MockInteractiveService) Like(ctx context.Context, biz string, bizId, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Like", ctx, biz, bizId, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Like indicates an expected call of Like.
+func (mr *MockInteractiveServiceMockRecorder) Like(ctx, biz, bizId, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Like", reflect.TypeOf((*MockInteractiveService)(nil).Like), ctx, biz, bizId, uid)
+}
+
+// LikeRecords mocks base method.
+func (m *MockInteractiveService) LikeRecords(ctx context.Context, biz string, uid int64, offset, limit int) ([]domain.InteractiveRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LikeRecords", ctx, biz, uid, offset, limit)
+	ret0, _ := ret[0].([]domain.InteractiveRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LikeRecords indicates an expected call of LikeRecords.
+func (mr *MockInteractiveServiceMockRecorder) LikeRecords(ctx, biz, uid, offset, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LikeRecords", reflect.TypeOf((*MockInteractiveService)(nil).LikeRecords), ctx, biz, uid, offset, limit)
+}
+
+// MoveCollectionItem mocks base method.
+func (m *MockInteractiveService) MoveCollectionItem(ctx context.Context, biz string, bizId, fromCid, toCid, uid int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveCollectionItem", ctx, biz, bizId, fromCid, toCid, uid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveCollectionItem indicates an expected call of MoveCollectionItem.
+func (mr *MockInteractiveServiceMockRecorder) MoveCollectionItem(ctx, biz, bizId, fromCid, toCid, uid any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveCollectionItem", reflect.TypeOf((*MockInteractiveService)(nil).MoveCollectionItem), ctx, biz, bizId, fromCid, toCid, uid)
+}
+
+// React mocks base method.
+func (m *MockInteractiveService) React(ctx context.Context, biz string, bizId, uid int64, reaction string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "React", ctx, biz, bizId, uid, reaction)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// React indicates an expected call of React.
+func (mr *MockInteractiveServiceMockRecorder) React(ctx, biz, bizId, uid, reaction any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "React", reflect.TypeOf((*MockInteractiveService)(nil).React), ctx, biz, bizId, uid, reaction)
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+var ErrInvalidNotificationType = errors.New("不支持的通知类型")
+
+type NotificationService interface {
+	// List cursor 是上一页最后一条的 id
+	List(ctx context.Context, uid int64, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error)
+	UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error)
+	// MarkRead ids 为空的时候全部标记成已读
+	MarkRead(ctx context.Context, uid int64, ids []int64) error
+	// Settings 每种通知是否打开
+	Settings(ctx context.Context, uid int64) (map[domain.NotificationType]bool, error)
+	SetEnabled(ctx context.Context, uid int64, typ domain.NotificationType, enabled bool) error
+}
+
+type notificationService struct {
+	repo repository.NotificationRepository
+}
+
+func NewNotificationService(repo repository.NotificationRepository) NotificationService {
+	return &notificationService{
+		repo: repo,
+	}
+}
+
+func (n *notificationService) List(ctx context.Context, uid int64, cursor int64, limit int, unreadOnly bool) ([]domain.Notification, error) {
+	return n.repo.List(ctx, uid, cursor, limit, unreadOnly)
+}
+
+func (n *notificationService) UnreadCnt(ctx context.Context, uid int64) (map[domain.NotificationType]int64, error) {
+	return n.repo.UnreadCnt(ctx, uid)
+}
+
+func (n *notificationService) MarkRead(ctx context.Context, uid int64, ids []int64) error {
+	return n.repo.MarkRead(ctx, uid, ids)
+}
+
+func (n *notificationService) Settings(ctx context.Context, uid int64) (map[domain.NotificationType]bool, error) {
+	optOuts, err := n.repo.OptOuts(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+	res := make(map[domain.NotificationType]bool, len(domain.NotificationTypes))
+	for _, typ := range domain.NotificationTypes {
+		res[typ] = true
+	}
+	for _, typ := range optOuts {
+		res[typ] = false
+	}
+	return res, nil
+}
+
+func (n *notificationService) SetEnabled(ctx context.Context, uid int64, typ domain.NotificationType, enabled bool) error {
+	if !typ.Valid() {
+		return ErrInvalidNotificationType
+	}
+	return n.repo.SetOptOut(ctx, uid, typ, !enabled)
+}
+
+// produceNotification 异步发通知事件，失败了只记日志，不影响点赞、评论这些操作本身
+func produceNotification(p notification.Producer, l logger.Logger, evt notification.Event) {
+	go func() {
+		err := p.ProduceEvent(evt)
+		if err != nil {
+			l.Error("发送通知事件失败", logger.String("type", evt.Type),
+				logger.Int64("actor", evt.Actor), logger.Int64("bizId", evt.BizId), logger.Error(err))
+		}
+	}()
+}
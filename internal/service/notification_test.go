@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	repomocks "webook/internal/repository/mocks"
+)
+
+func TestNotificationService_Settings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockNotificationRepository(ctrl)
+	repo.EXPECT().OptOuts(gomock.Any(), int64(1)).Return([]domain.NotificationType{domain.NotificationTypeLike}, nil)
+	settings, err := NewNotificationService(repo).Settings(context.Background(), 1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[domain.NotificationType]bool{
+		domain.NotificationTypeLike:    false,
+		domain.NotificationTypeCollect: true,
+		domain.NotificationTypeComment: true,
+		domain.NotificationTypeFollow:  true,
+	}, settings)
+}
+
+func TestNotificationService_SetEnabled(t *testing.T) {
+	testCases := []struct {
+		name    string
+		typ     domain.NotificationType
+		enabled bool
+		mock    func(ctrl *gomock.Controller) *repomocks.MockNotificationRepository
+		wantErr error
+	}{
+		{
+			name:    "关掉点赞通知",
+			typ:     domain.NotificationTypeLike,
+			enabled: false,
+			mock: func(ctrl *gomock.Controller) *repomocks.MockNotificationRepository {
+				repo := repomocks.NewMockNotificationRepository(ctrl)
+				repo.EXPECT().SetOptOut(gomock.Any(), int64(1), domain.NotificationTypeLike, true).Return(nil)
+				return repo
+			},
+		},
+		{
+			name: "不支持的类型",
+			typ:  "share",
+			mock: func(ctrl *gomock.Controller) *repomocks.MockNotificationRepository {
+				return repomocks.NewMockNotificationRepository(ctrl)
+			},
+			wantErr: ErrInvalidNotificationType,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			err := NewNotificationService(tc.mock(ctrl)).SetEnabled(context.Background(), 1, tc.typ, tc.enabled)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -2,26 +2,145 @@ package service
 
 import (
 	"context"
+	"github.com/ecodeclub/ekit/queue"
+	"github.com/ecodeclub/ekit/slice"
+	"math"
+	"time"
 	"webook/internal/domain"
+	"webook/internal/repository"
 )
 
 type RankingService interface {
-	TopN(ctx context.Context) error // 前一百
+	// TopN 重新计算热榜并保存
+	TopN(ctx context.Context) error
+	// GetTopN 读计算好的热榜
+	GetTopN(ctx context.Context) ([]domain.Article, error)
 }
 
+// RankingScore 热度 = (点赞数 * LikeWeight + 阅读数 * ReadWeight) / (发表了多少小时 + 2) ^ Gravity
+// Gravity 越大，旧文章掉得越快
+type RankingScore struct {
+	LikeWeight float64 `yaml:"likeWeight"`
+	ReadWeight float64 `yaml:"readWeight"`
+	Gravity    float64 `yaml:"gravity"`
+}
+
+func (s RankingScore) Of(likeCnt int64, readCnt int64, age time.Duration) float64 {
+	hours := math.Max(age.Hours(), 0)
+	return (float64(likeCnt)*s.LikeWeight + float64(readCnt)*s.ReadWeight) /
+		math.Pow(hours+2, s.Gravity)
+}
+
+// BatchRankingService 分批把最近的文章和它们的互动数据捞出来，算热度，留下前 N 个
 type BatchRankingService struct {
+	artSvc  ArticleService
+	intrSvc InteractiveService
+	repo    repository.RankingRepository
+	biz     string
+
+	// BatchSize 一批查多少篇文章
+	BatchSize int
+	// N 热榜的长度
+	N int
+	// Window 只看这么久之内更新过的文章，更老的热度也不会高
+	Window time.Duration
+	Score  RankingScore
+	now    func() time.Time
+}
+
+func NewBatchRankingService(artSvc ArticleService, intrSvc InteractiveService,
+	repo repository.RankingRepository) *BatchRankingService {
+	return &BatchRankingService{
+		artSvc:    artSvc,
+		intrSvc:   intrSvc,
+		repo:      repo,
+		biz:       "article",
+		BatchSize: 100,
+		N:         100,
+		Window:    7 * 24 * time.Hour,
+		Score: RankingScore{
+			LikeWeight: 1,
+			ReadWeight: 0.1,
+			Gravity:    1.5,
+		},
+		now: time.Now,
+	}
 }
 
-func (b BatchRankingService) TopN(ctx context.Context) error {
+func (b *BatchRankingService) TopN(ctx context.Context) error {
 	arts, err := b.topN(ctx)
 	if err != nil {
 		return err
 	}
-	//TODO implement me
-	panic(arts)
 	// 最终是放在缓存里面的
+	return b.repo.ReplaceTopN(ctx, arts)
 }
 
-func (b BatchRankingService) topN(ctx context.Context) ([]domain.Article, error) {
+func (b *BatchRankingService) GetTopN(ctx context.Context) ([]domain.Article, error) {
+	return b.repo.GetTopN(ctx)
+}
 
+func (b *BatchRankingService) topN(ctx context.Context) ([]domain.Article, error) {
+	type scored struct {
+		art   domain.Article
+		score float64
+	}
+	now := b.now()
+	start := now.Add(-b.Window)
+	// 小顶堆，堆顶是目前前 N 里面热度最低的
+	q := queue.NewConcurrentPriorityQueue[scored](b.N, func(src scored, dst scored) int {
+		switch {
+		case src.score < dst.score:
+			return -1
+		case src.score > dst.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+	var last domain.Article
+	for {
+		arts, err := b.artSvc.ListPub(ctx, start, last, b.BatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if len(arts) == 0 {
+			break
+		}
+		ids := slice.Map[domain.Article, int64](arts, func(idx int, src domain.Article) int64 {
+			return src.Id
+		})
+		intrs, err := b.intrSvc.GetIntrByArtIds(ctx, b.biz, ids, 0)
+		if err != nil {
+			return nil, err
+		}
+		for _, art := range arts {
+			intr := intrs[art.Id]
+			cur := scored{
+				art: art,
+				// 按第一次发表的时间算，重新编辑不会让老文章变新
+				score: b.Score.Of(intr.LikeCnt, intr.ReadCnt, now.Sub(time.UnixMilli(art.Ctime))),
+			}
+			if q.Len() < b.N {
+				_ = q.Enqueue(cur)
+				continue
+			}
+			min, _ := q.Peek()
+			if cur.score > min.score {
+				_, _ = q.Dequeue()
+				_ = q.Enqueue(cur)
+			}
+		}
+		if len(arts) < b.BatchSize {
+			break
+		}
+		last = arts[len(arts)-1]
+	}
+	// 出队是从低到高，倒着放
+	res := make([]domain.Article, q.Len())
+	for i := len(res) - 1; i >= 0; i-- {
+		val, _ := q.Dequeue()
+		res[i] = val.art
+	}
+	return res, nil
 }
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	repomocks "webook/internal/repository/mocks"
+)
+
+// svcmocks 引用了 service，这里用不了，只实现用到的方法
+type rankingArticleService struct {
+	ArticleService
+	arts []domain.Article
+}
+
+// ListPub arts 已经按 (utime, id) 倒序排好
+func (r *rankingArticleService) ListPub(ctx context.Context, start time.Time, last domain.Article, limit int) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, limit)
+	for _, art := range r.arts {
+		if len(res) == limit {
+			break
+		}
+		if last.Id > 0 && !(art.Utime < last.Utime || art.Utime == last.Utime && art.Id < last.Id) {
+			continue
+		}
+		res = append(res, art)
+	}
+	return res, nil
+}
+
+type rankingInteractiveService struct {
+	InteractiveService
+	intrs map[int64]domain.Interactive
+}
+
+func (r *rankingInteractiveService) GetIntrByArtIds(ctx context.Context, biz string, bizIds []int64, uid int64) (map[int64]domain.Interactive, error) {
+	res := make(map[int64]domain.Interactive, len(bizIds))
+	for _, id := range bizIds {
+		res[id] = r.intrs[id]
+	}
+	return res, nil
+}
+
+func TestBatchRankingService_TopN(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	hoursAgo := func(h int) int64 {
+		return now.Add(-time.Duration(h) * time.Hour).UnixMilli()
+	}
+	arts := []domain.Article{
+		// 两天前发表，刚刚重新编辑过
+		{Id: 6, Ctime: hoursAgo(48), Utime: hoursAgo(1)},
+		{Id: 1, Ctime: hoursAgo(1), Utime: hoursAgo(1)},
+		{Id: 2, Ctime: hoursAgo(2), Utime: hoursAgo(2)},
+		{Id: 3, Ctime: hoursAgo(3), Utime: hoursAgo(3)},
+		{Id: 4, Ctime: hoursAgo(4), Utime: hoursAgo(4)},
+		{Id: 5, Ctime: hoursAgo(48), Utime: hoursAgo(48)},
+	}
+	intrs := map[int64]domain.Interactive{
+		1: {LikeCnt: 1},
+		2: {LikeCnt: 10},
+		3: {LikeCnt: 5, ReadCnt: 100},
+		// 点赞最多，但是太老了，比不过只有一个赞的新文章
+		5: {LikeCnt: 50},
+		// 热度按发表时间算，编辑过也一样老
+		6: {LikeCnt: 50},
+	}
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	repo := repomocks.NewMockRankingRepository(ctrl)
+	repo.EXPECT().ReplaceTopN(gomock.Any(), []domain.Article{
+		{Id: 3, Ctime: hoursAgo(3), Utime: hoursAgo(3)},
+		{Id: 2, Ctime: hoursAgo(2), Utime: hoursAgo(2)},
+		{Id: 1, Ctime: hoursAgo(1), Utime: hoursAgo(1)},
+	}).Return(nil)
+	svc := NewBatchRankingService(&rankingArticleService{arts: arts},
+		&rankingInteractiveService{intrs: intrs}, repo)
+	svc.BatchSize = 2
+	svc.N = 3
+	svc.now = func() time.Time {
+		return now
+	}
+	err := svc.TopN(context.Background())
+	require.NoError(t, err)
+}
+
+func TestRankingScore_Of(t *testing.T) {
+	s := RankingScore{LikeWeight: 1, ReadWeight: 0.1, Gravity: 1.5}
+	// 同样的互动，越新越热
+	assert.Greater(t, s.Of(10, 100, time.Hour), s.Of(10, 100, 10*time.Hour))
+	// 同样的时间，点赞比阅读重要
+	assert.Greater(t, s.Of(10, 0, time.Hour), s.Of(0, 10, time.Hour))
+}
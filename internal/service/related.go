@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+)
+
+var (
+	ErrTagsNoPermission = errors.New("只有作者可以修改标签")
+	ErrInvalidTags      = errors.New("标签不合法")
+)
+
+const (
+	maxArticleTags = 5
+	maxTagLength   = 32
+)
+
+// RelatedService 详情页下面的相关文章
+// 共同标签、一起被点赞、同一个作者最近的文章三种信号加权，定时算好放在 redis 里面，没有的用热榜补
+type RelatedService interface {
+	// Compute 重新计算最近更新过的文章的相关推荐
+	Compute(ctx context.Context) error
+	// Related 已发表的相关文章，不包括 artId 自己
+	Related(ctx context.Context, artId int64, limit int) ([]domain.Article, error)
+	// SetTags 作者修改文章标签，最多 5 个，重复的只算一个
+	SetTags(ctx context.Context, artId int64, uid int64, tags []string) error
+	GetTags(ctx context.Context, artId int64) ([]string, error)
+}
+
+// RelatedScore 分数 = 共同标签数 * TagWeight + 一起点赞的人数 * CoLikeWeight + 同一个作者 AuthorWeight
+type RelatedScore struct {
+	TagWeight    float64 `yaml:"tagWeight"`
+	CoLikeWeight float64 `yaml:"coLikeWeight"`
+	AuthorWeight float64 `yaml:"authorWeight"`
+}
+
+type PrecomputedRelatedService struct {
+	repo    repository.RelatedRepository
+	artRepo repository.ArticleRepository
+	rankSvc RankingService
+	l       logger.Logger
+
+	// BatchSize 一批算多少篇文章
+	BatchSize int
+	// Window 只算这么久之内更新过的文章，更老的缓存过期以后用热榜兜底
+	Window time.Duration
+	// N 每篇文章保存多少篇相关文章
+	N int
+	// Candidates 每种信号最多取多少篇候选
+	Candidates int
+	// Likers 只看最近这么多个点赞的用户
+	Likers int
+	Score  RelatedScore
+	now    func() time.Time
+}
+
+func NewPrecomputedRelatedService(repo repository.RelatedRepository, artRepo repository.ArticleRepository,
+	rankSvc RankingService, l logger.Logger) *PrecomputedRelatedService {
+	return &PrecomputedRelatedService{
+		repo:       repo,
+		artRepo:    artRepo,
+		rankSvc:    rankSvc,
+		l:          l,
+		BatchSize:  100,
+		Window:     7 * 24 * time.Hour,
+		N:          10,
+		Candidates: 50,
+		Likers:     200,
+		Score: RelatedScore{
+			TagWeight:    3,
+			CoLikeWeight: 1,
+			AuthorWeight: 2,
+		},
+		now: time.Now,
+	}
+}
+
+func (p *PrecomputedRelatedService) Compute(ctx context.Context) error {
+	start := p.now().Add(-p.Window)
+	var last domain.Article
+	for {
+		arts, err := p.artRepo.ListPub(ctx, start, last, p.BatchSize)
+		if err != nil {
+			return err
+		}
+		for _, art := range arts {
+			ids, err := p.compute(ctx, art)
+			if err != nil {
+				return err
+			}
+			err = p.repo.SetRelated(ctx, art.Id, ids)
+			if err != nil {
+				return err
+			}
+		}
+		if len(arts) < p.BatchSize {
+			return nil
+		}
+		last = arts[len(arts)-1]
+	}
+}
+
+func (p *PrecomputedRelatedService) compute(ctx context.Context, art domain.Article) ([]int64, error) {
+	scores := make(map[int64]float64, p.Candidates)
+	tags, err := p.repo.GetTags(ctx, art.Id)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := p.repo.SharedTags(ctx, tags, art.Id, p.Candidates)
+	if err != nil {
+		return nil, err
+	}
+	for id, cnt := range shared {
+		scores[id] += float64(cnt) * p.Score.TagWeight
+	}
+	coLiked, err := p.repo.CoLiked(ctx, art.Id, p.Likers, p.Candidates)
+	if err != nil {
+		return nil, err
+	}
+	for id, cnt := range coLiked {
+		scores[id] += float64(cnt) * p.Score.CoLikeWeight
+	}
+	recent, err := p.repo.RecentByAuthor(ctx, art.Author.Id, art.Id, p.Candidates)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range recent {
+		scores[id] += p.Score.AuthorWeight
+	}
+
+	ids := make([]int64, 0, len(scores))
+	for id := range scores {
+		ids = append(ids, id)
+	}
+	// 分数一样的新文章在前面
+	sort.Slice(ids, func(i, j int) bool {
+		if scores[ids[i]] != scores[ids[j]] {
+			return scores[ids[i]] > scores[ids[j]]
+		}
+		return ids[i] > ids[j]
+	})
+	if len(ids) > p.N {
+		ids = ids[:p.N]
+	}
+	return ids, nil
+}
+
+func (p *PrecomputedRelatedService) Related(ctx context.Context, artId int64, limit int) ([]domain.Article, error) {
+	if limit <= 0 || limit > p.N {
+		limit = p.N
+	}
+	ids, err := p.repo.GetRelated(ctx, artId)
+	if err != nil && !errors.Is(err, repository.ErrRelatedNotFound) {
+		return nil, err
+	}
+	res := make([]domain.Article, 0, limit)
+	if len(ids) > 0 {
+		arts, err := p.artRepo.GetPubByArtIds(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		found := make(map[int64]domain.Article, len(arts))
+		for _, art := range arts {
+			// 算好以后撤回了的不要
+			if art.Status == domain.ArticleStatusPublished {
+				found[art.Id] = art
+			}
+		}
+		for _, id := range ids {
+			if art, ok := found[id]; ok {
+				res = append(res, art)
+				if len(res) == limit {
+					return res, nil
+				}
+			}
+		}
+	}
+	// 新文章还没有算过，或者相关的太少，用热榜补
+	hot, err := p.rankSvc.GetTopN(ctx)
+	if err != nil {
+		// 热榜只是补位，已经有相关文章了就先返回这些
+		if len(res) > 0 {
+			p.l.Error("查询热榜补相关文章失败", logger.Int64("artId", artId), logger.Error(err))
+			return res, nil
+		}
+		return nil, err
+	}
+	seen := make(map[int64]struct{}, len(res)+1)
+	seen[artId] = struct{}{}
+	for _, art := range res {
+		seen[art.Id] = struct{}{}
+	}
+	for _, art := range hot {
+		if len(res) == limit {
+			break
+		}
+		if _, ok := seen[art.Id]; ok {
+			continue
+		}
+		seen[art.Id] = struct{}{}
+		res = append(res, art)
+	}
+	return res, nil
+}
+
+func (p *PrecomputedRelatedService) SetTags(ctx context.Context, artId int64, uid int64, tags []string) error {
+	res := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || utf8.RuneCountInString(tag) > maxTagLength {
+			return ErrInvalidTags
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		res = append(res, tag)
+	}
+	if len(res) > maxArticleTags {
+		return ErrInvalidTags
+	}
+	art, err := p.artRepo.GetByArtId(ctx, artId)
+	if err != nil {
+		return err
+	}
+	if art.Author.Id != uid {
+		return ErrTagsNoPermission
+	}
+	return p.repo.SetTags(ctx, artId, res)
+}
+
+func (p *PrecomputedRelatedService) GetTags(ctx context.Context, artId int64) ([]string, error) {
+	return p.repo.GetTags(ctx, artId)
+}
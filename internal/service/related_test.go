@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+	"webook/pkg/logger"
+)
+
+type relatedArticleRepository struct {
+	repository.ArticleRepository
+	arts map[int64]domain.Article
+}
+
+func (r *relatedArticleRepository) ListPub(ctx context.Context, start time.Time, last domain.Article, limit int) ([]domain.Article, error) {
+	if last.Id > 0 {
+		return nil, nil
+	}
+	return []domain.Article{r.arts[1]}, nil
+}
+
+func (r *relatedArticleRepository) GetByArtId(ctx context.Context, artId int64) (domain.Article, error) {
+	art, ok := r.arts[artId]
+	if !ok {
+		return domain.Article{}, repository.ErrArticleNotFound
+	}
+	return art, nil
+}
+
+func (r *relatedArticleRepository) GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(artIds))
+	for _, id := range artIds {
+		if art, ok := r.arts[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+type relatedRankingService struct {
+	RankingService
+	arts []domain.Article
+	err  error
+}
+
+func (r *relatedRankingService) GetTopN(ctx context.Context) ([]domain.Article, error) {
+	return r.arts, r.err
+}
+
+func TestPrecomputedRelatedService(t *testing.T) {
+	published := func(id int64, author int64) domain.Article {
+		return domain.Article{Id: id, Author: domain.Author{Id: author}, Status: domain.ArticleStatusPublished}
+	}
+	arts := map[int64]domain.Article{
+		1: published(1, 10),
+		2: published(2, 10),
+		3: {Id: 3, Author: domain.Author{Id: 20}, Status: domain.ArticleStatusPrivate},
+		5: published(5, 10),
+		6: published(6, 30),
+	}
+	hot := []domain.Article{arts[1], arts[6], arts[2]}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.RelatedRepository
+		rankErr error
+		call    func(svc RelatedService) ([]domain.Article, error)
+		want    []domain.Article
+		wantErr error
+	}{
+		{
+			name: "三种信号加权，保留前 N 个",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				repo := repomocks.NewMockRelatedRepository(ctrl)
+				repo.EXPECT().GetTags(gomock.Any(), int64(1)).Return([]string{"go"}, nil)
+				repo.EXPECT().SharedTags(gomock.Any(), []string{"go"}, int64(1), 50).
+					Return(map[int64]int64{2: 2, 3: 1}, nil)
+				repo.EXPECT().CoLiked(gomock.Any(), int64(1), 200, 50).
+					Return(map[int64]int64{3: 4, 4: 1}, nil)
+				repo.EXPECT().RecentByAuthor(gomock.Any(), int64(10), int64(1), 50).
+					Return([]int64{5, 2}, nil)
+				// 2: 2*3+2, 3: 1*3+4, 5: 2, 4: 1
+				repo.EXPECT().SetRelated(gomock.Any(), int64(1), []int64{2, 3, 5}).Return(nil)
+				return repo
+			},
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return nil, svc.Compute(context.Background())
+			},
+		},
+		{
+			name: "撤回了的去掉，不够的用热榜补",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				repo := repomocks.NewMockRelatedRepository(ctrl)
+				repo.EXPECT().GetRelated(gomock.Any(), int64(1)).Return([]int64{2, 3, 5}, nil)
+				return repo
+			},
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return svc.Related(context.Background(), 1, 3)
+			},
+			want: []domain.Article{arts[2], arts[5], arts[6]},
+		},
+		{
+			name: "没有算过，直接用热榜",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				repo := repomocks.NewMockRelatedRepository(ctrl)
+				repo.EXPECT().GetRelated(gomock.Any(), int64(1)).Return(nil, repository.ErrRelatedNotFound)
+				return repo
+			},
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return svc.Related(context.Background(), 1, 3)
+			},
+			want: []domain.Article{arts[6], arts[2]},
+		},
+		{
+			name: "热榜查询失败，返回已有的相关文章",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				repo := repomocks.NewMockRelatedRepository(ctrl)
+				repo.EXPECT().GetRelated(gomock.Any(), int64(1)).Return([]int64{2, 3, 5}, nil)
+				return repo
+			},
+			rankErr: errors.New("redis error"),
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return svc.Related(context.Background(), 1, 3)
+			},
+			want: []domain.Article{arts[2], arts[5]},
+		},
+		{
+			name: "没有算过，热榜也查询失败",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				repo := repomocks.NewMockRelatedRepository(ctrl)
+				repo.EXPECT().GetRelated(gomock.Any(), int64(1)).Return(nil, repository.ErrRelatedNotFound)
+				return repo
+			},
+			rankErr: errors.New("redis error"),
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return svc.Related(context.Background(), 1, 3)
+			},
+			wantErr: errors.New("redis error"),
+		},
+		{
+			name: "修改标签，去掉空格和重复的",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				repo := repomocks.NewMockRelatedRepository(ctrl)
+				repo.EXPECT().SetTags(gomock.Any(), int64(1), []string{"go", "redis"}).Return(nil)
+				return repo
+			},
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return nil, svc.SetTags(context.Background(), 1, 10, []string{" Go", "redis", "go "})
+			},
+		},
+		{
+			name: "不是作者不能修改标签",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				return repomocks.NewMockRelatedRepository(ctrl)
+			},
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return nil, svc.SetTags(context.Background(), 1, 20, []string{"go"})
+			},
+			wantErr: ErrTagsNoPermission,
+		},
+		{
+			name: "标签太多",
+			mock: func(ctrl *gomock.Controller) repository.RelatedRepository {
+				return repomocks.NewMockRelatedRepository(ctrl)
+			},
+			call: func(svc RelatedService) ([]domain.Article, error) {
+				return nil, svc.SetTags(context.Background(), 1, 10, []string{"a", "b", "c", "d", "e", "f"})
+			},
+			wantErr: ErrInvalidTags,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewPrecomputedRelatedService(tc.mock(ctrl), &relatedArticleRepository{arts: arts},
+				&relatedRankingService{arts: hot, err: tc.rankErr}, logger.NewNopLogger())
+			svc.N = 3
+			res, err := tc.call(svc)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, res)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/bwmarrin/snowflake"
+	"webook/internal/domain"
+	"webook/internal/repository"
+)
+
+var (
+	ErrShareLinkNotFound = repository.ErrShareLinkNotFound
+	ErrShareNoPermission = errors.New("只有作者可以查看分享数据")
+	ErrShareLinkMismatch = errors.New("分享链接和文章不匹配")
+)
+
+type ShareService interface {
+	// Share 分享一次，返回归因用的分享链接
+	Share(ctx context.Context, biz string, bizId int64, uid int64, channel string) (domain.ShareLink, error)
+	// Click 通过分享链接点进来
+	Click(ctx context.Context, code string) (domain.ShareLink, error)
+	// Read 通过分享链接带来的一次阅读，分享者自己读不算，同一个用户只算一次
+	Read(ctx context.Context, code string, artId int64, uid int64) error
+	// ArticleStats 作者查看自己文章的分享数据
+	ArticleStats(ctx context.Context, artId int64, uid int64) (domain.ShareStats, error)
+}
+
+type shareService struct {
+	repo    repository.ShareRepository
+	artRepo repository.ArticleRepository
+	node    *snowflake.Node
+}
+
+func NewShareService(repo repository.ShareRepository, artRepo repository.ArticleRepository,
+	node *snowflake.Node) ShareService {
+	return &shareService{
+		repo:    repo,
+		artRepo: artRepo,
+		node:    node,
+	}
+}
+
+func (s *shareService) Share(ctx context.Context, biz string, bizId int64, uid int64, channel string) (domain.ShareLink, error) {
+	return s.repo.AddShare(ctx, domain.ShareLink{
+		// 已经有链接的话会沿用旧的 code
+		Code:  s.node.Generate().Base58(),
+		Uid:   uid,
+		Biz:   biz,
+		BizId: bizId,
+	}, domain.ValidShareChannel(channel))
+}
+
+func (s *shareService) Click(ctx context.Context, code string) (domain.ShareLink, error) {
+	link, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return domain.ShareLink{}, err
+	}
+	return link, s.repo.IncrClickCnt(ctx, code)
+}
+
+func (s *shareService) Read(ctx context.Context, code string, artId int64, uid int64) error {
+	link, err := s.repo.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	// 拿别的文章的链接来刷阅读数
+	if link.Biz != "article" || link.BizId != artId {
+		return ErrShareLinkMismatch
+	}
+	if link.Uid == uid {
+		return nil
+	}
+	return s.repo.IncrReadCnt(ctx, code, uid)
+}
+
+func (s *shareService) ArticleStats(ctx context.Context, artId int64, uid int64) (domain.ShareStats, error) {
+	art, err := s.artRepo.GetPubByArtId(ctx, artId)
+	if err != nil {
+		return domain.ShareStats{}, err
+	}
+	if art.Author.Id != uid {
+		return domain.ShareStats{}, ErrShareNoPermission
+	}
+	return s.repo.GetStats(ctx, "article", artId)
+}
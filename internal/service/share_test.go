@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+)
+
+func TestShareService_Read(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) repository.ShareRepository
+		artId   int64
+		uid     int64
+		wantErr error
+	}{
+		{
+			name: "别人通过分享链接阅读",
+			mock: func(ctrl *gomock.Controller) repository.ShareRepository {
+				repo := repomocks.NewMockShareRepository(ctrl)
+				repo.EXPECT().FindByCode(gomock.Any(), "abc").
+					Return(domain.ShareLink{Code: "abc", Uid: 1, Biz: "article", BizId: 10}, nil)
+				repo.EXPECT().IncrReadCnt(gomock.Any(), "abc", int64(2)).Return(nil)
+				return repo
+			},
+			artId: 10,
+			uid:   2,
+		},
+		{
+			name: "分享者自己读不算",
+			mock: func(ctrl *gomock.Controller) repository.ShareRepository {
+				repo := repomocks.NewMockShareRepository(ctrl)
+				repo.EXPECT().FindByCode(gomock.Any(), "abc").
+					Return(domain.ShareLink{Code: "abc", Uid: 1, Biz: "article", BizId: 10}, nil)
+				return repo
+			},
+			artId: 10,
+			uid:   1,
+		},
+		{
+			name: "链接不是这篇文章的",
+			mock: func(ctrl *gomock.Controller) repository.ShareRepository {
+				repo := repomocks.NewMockShareRepository(ctrl)
+				repo.EXPECT().FindByCode(gomock.Any(), "abc").
+					Return(domain.ShareLink{Code: "abc", Uid: 1, Biz: "article", BizId: 11}, nil)
+				return repo
+			},
+			artId:   10,
+			uid:     2,
+			wantErr: ErrShareLinkMismatch,
+		},
+		{
+			name: "链接不是文章的",
+			mock: func(ctrl *gomock.Controller) repository.ShareRepository {
+				repo := repomocks.NewMockShareRepository(ctrl)
+				repo.EXPECT().FindByCode(gomock.Any(), "abc").
+					Return(domain.ShareLink{Code: "abc", Uid: 1, Biz: "comment", BizId: 10}, nil)
+				return repo
+			},
+			artId:   10,
+			uid:     2,
+			wantErr: ErrShareLinkMismatch,
+		},
+		{
+			name: "链接不存在",
+			mock: func(ctrl *gomock.Controller) repository.ShareRepository {
+				repo := repomocks.NewMockShareRepository(ctrl)
+				repo.EXPECT().FindByCode(gomock.Any(), "abc").
+					Return(domain.ShareLink{}, repository.ErrShareLinkNotFound)
+				return repo
+			},
+			artId:   10,
+			uid:     2,
+			wantErr: ErrShareLinkNotFound,
+		},
+		{
+			name: "记录阅读失败",
+			mock: func(ctrl *gomock.Controller) repository.ShareRepository {
+				repo := repomocks.NewMockShareRepository(ctrl)
+				repo.EXPECT().FindByCode(gomock.Any(), "abc").
+					Return(domain.ShareLink{Code: "abc", Uid: 1, Biz: "article", BizId: 10}, nil)
+				repo.EXPECT().IncrReadCnt(gomock.Any(), "abc", int64(2)).Return(errors.New("mock db error"))
+				return repo
+			},
+			artId:   10,
+			uid:     2,
+			wantErr: errors.New("mock db error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewShareService(tc.mock(ctrl), nil, nil)
+			err := svc.Read(context.Background(), "abc", tc.artId, tc.uid)
+			assert.Equal(t, tc.wantErr, err)
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+)
+
+var ErrInvalidTrendingWindow = errors.New("不支持的时间窗口")
+
+// 能查的窗口，最大的不能超过时间桶的保留时间
+var trendingWindows = map[time.Duration]struct{}{
+	time.Hour:      {},
+	24 * time.Hour: {},
+}
+
+// TrendingService 实时热度，和 RankingService 定时算的热榜不一样，阅读、点赞、收藏之后马上就能体现
+type TrendingService interface {
+	// TopArticles 最近 window 里面热度最高的已发表文章，按热度从高到低
+	TopArticles(ctx context.Context, window time.Duration, limit int) ([]domain.Article, error)
+}
+
+type trendingService struct {
+	repo   repository.TrendingRepository
+	artSvc ArticleService
+}
+
+func NewTrendingService(repo repository.TrendingRepository, artSvc ArticleService) TrendingService {
+	return &trendingService{
+		repo:   repo,
+		artSvc: artSvc,
+	}
+}
+
+func (t *trendingService) TopArticles(ctx context.Context, window time.Duration, limit int) ([]domain.Article, error) {
+	if _, ok := trendingWindows[window]; !ok {
+		return nil, ErrInvalidTrendingWindow
+	}
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	// 有的文章已经撤回了，多取一些
+	items, err := t.repo.Top(ctx, "article", window, limit*2)
+	if err != nil || len(items) == 0 {
+		return nil, err
+	}
+	ids := make([]int64, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.BizId)
+	}
+	arts, err := t.artSvc.GetPubByArtIds(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[int64]domain.Article, len(arts))
+	for _, art := range arts {
+		if art.Status == domain.ArticleStatusPublished {
+			found[art.Id] = art
+		}
+	}
+	res := make([]domain.Article, 0, limit)
+	for _, id := range ids {
+		art, ok := found[id]
+		if !ok {
+			continue
+		}
+		res = append(res, art)
+		if len(res) == limit {
+			break
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/repository"
+	repomocks "webook/internal/repository/mocks"
+)
+
+type trendingArticleService struct {
+	ArticleService
+	arts map[int64]domain.Article
+}
+
+func (t *trendingArticleService) GetPubByArtIds(ctx context.Context, artIds []int64) ([]domain.Article, error) {
+	res := make([]domain.Article, 0, len(artIds))
+	for _, id := range artIds {
+		if art, ok := t.arts[id]; ok {
+			res = append(res, art)
+		}
+	}
+	return res, nil
+}
+
+func TestTrendingService_TopArticles(t *testing.T) {
+	arts := map[int64]domain.Article{
+		1: {Id: 1, Status: domain.ArticleStatusPublished},
+		2: {Id: 2, Status: domain.ArticleStatusPrivate},
+		3: {Id: 3, Status: domain.ArticleStatusPublished},
+	}
+	testCases := []struct {
+		name     string
+		window   time.Duration
+		mock     func(ctrl *gomock.Controller) repository.TrendingRepository
+		wantArts []domain.Article
+		wantErr  error
+	}{
+		{
+			name:   "按热度排序，去掉撤回的",
+			window: time.Hour,
+			mock: func(ctrl *gomock.Controller) repository.TrendingRepository {
+				repo := repomocks.NewMockTrendingRepository(ctrl)
+				repo.EXPECT().Top(gomock.Any(), "article", time.Hour, 4).Return([]domain.TrendingItem{
+					{BizId: 3, Score: 20},
+					{BizId: 2, Score: 10},
+					{BizId: 4, Score: 8},
+					{BizId: 1, Score: 5},
+				}, nil)
+				return repo
+			},
+			wantArts: []domain.Article{arts[3], arts[1]},
+		},
+		{
+			name:   "不支持的窗口",
+			window: 2 * time.Hour,
+			mock: func(ctrl *gomock.Controller) repository.TrendingRepository {
+				return repomocks.NewMockTrendingRepository(ctrl)
+			},
+			wantErr: ErrInvalidTrendingWindow,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			svc := NewTrendingService(tc.mock(ctrl), &trendingArticleService{arts: arts})
+			res, err := svc.TopArticles(context.Background(), tc.window, 2)
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantArts, res)
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 var (
 	ErrDuplicateUser = repository.ErrDuplicateUser
+	ErrUserNotFound  = repository.ErrUserNotFound
 	// todo 含糊
 	ErrInvalidUserOrPassword = errors.New("用户或者密码不正确")
 )
@@ -22,6 +23,9 @@ type UserService interface {
 	FindOrCreate(ctx context.Context, phone string) (domain.User, error)
 	FindById(ctx context.Context, id int64) (domain.User, error)
 	FindOrCreateByWechat(ctx context.Context, wechatInfo domain.WechatInfo) (domain.User, error)
+	// UpdatePrivacy 修改点赞记录是否公开
+	UpdatePrivacy(ctx context.Context, uid int64, likesPublic bool) error
+	FindByIds(ctx context.Context, ids []int64) ([]domain.User, error)
 }
 
 type userService struct {
@@ -110,6 +114,14 @@ func (svc *userService) FindOrCreate(ctx context.Context, phone string) (domain.
 	return svc.repo.FindByPhone(ctx, phone)
 }
 
+func (svc *userService) UpdatePrivacy(ctx context.Context, uid int64, likesPublic bool) error {
+	return svc.repo.UpdatePrivacy(ctx, uid, likesPublic)
+}
+
+func (svc *userService) FindByIds(ctx context.Context, ids []int64) ([]domain.User, error) {
+	return svc.repo.FindByIds(ctx, ids)
+}
+
 func (svc *userService) FindById(ctx context.Context, id int64) (domain.User, error) {
 	return svc.repo.FindById(ctx, id)
 }
@@ -0,0 +1,74 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/prometheus/client_golang/prometheus"
+	"webook/pkg/logger"
+	"webook/pkg/ratelimit"
+)
+
+// 限流的动作
+const (
+	actionLike    = "like"
+	actionCollect = "collect"
+	actionComment = "comment"
+)
+
+// ActionLimiter 点赞、收藏、评论这类写操作的限流
+// 同一个用户所有资源共用一个限额，同一个用户同一个资源再单独一个更小的限额，防止脚本反复切换
+type ActionLimiter struct {
+	user    ratelimit.Limiter
+	item    ratelimit.Limiter
+	l       logger.Logger
+	limited *prometheus.CounterVec
+}
+
+func NewActionLimiter(user ratelimit.Limiter, item ratelimit.Limiter, l logger.Logger) *ActionLimiter {
+	limited := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "webook",
+		Subsystem: "action",
+		Name:      "limited_total",
+		Help:      "被限流的操作次数，scope 是 user 或者 item",
+	}, []string{"action", "scope"})
+	// 集成测试里面会创建多次，已经注册过的直接复用
+	err := prometheus.Register(limited)
+	if err != nil {
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			panic(err)
+		}
+		limited = are.ExistingCollector.(*prometheus.CounterVec)
+	}
+	return &ActionLimiter{
+		user:    user,
+		item:    item,
+		l:       l,
+		limited: limited,
+	}
+}
+
+// Limited 返回 true 表示操作太频繁了
+// redis 出问题的时候放行，限流只是保护，不能因为它影响正常使用
+func (a *ActionLimiter) Limited(ctx context.Context, action string, uid int64, biz string, bizId int64) bool {
+	limited, err := a.user.Limit(ctx, fmt.Sprintf("limit:action:%s:%d", action, uid))
+	if err != nil {
+		a.l.Error("限流失败", logger.String("action", action), logger.Int64("uid", uid), logger.Error(err))
+		return false
+	}
+	if limited {
+		a.limited.WithLabelValues(action, "user").Inc()
+		return true
+	}
+	limited, err = a.item.Limit(ctx, fmt.Sprintf("limit:action:%s:%d:%s:%d", action, uid, biz, bizId))
+	if err != nil {
+		a.l.Error("限流失败", logger.String("action", action), logger.Int64("uid", uid),
+			logger.String("biz", biz), logger.Int64("bizId", bizId), logger.Error(err))
+		return false
+	}
+	if limited {
+		a.limited.WithLabelValues(action, "item").Inc()
+	}
+	return limited
+}
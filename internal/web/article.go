@@ -2,6 +2,8 @@ package web
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/ecodeclub/ekit/slice"
 	"github.com/gin-gonic/gin"
 	"golang.org/x/sync/errgroup"
@@ -16,18 +18,35 @@ import (
 )
 
 type ArticleHandler struct {
-	svc     service.ArticleService
-	intrSvc service.InteractiveService
-	l       logger.Logger
-	biz     string
+	svc        service.ArticleService
+	intrSvc    service.InteractiveService
+	shareSvc   service.ShareService
+	userSvc    service.UserService
+	rankSvc    service.RankingService
+	trendSvc   service.TrendingService
+	followSvc  service.FollowService
+	relatedSvc service.RelatedService
+	limiter    *ActionLimiter
+	l          logger.Logger
+	biz        string
 }
 
-func NewArticleHandler(svc service.ArticleService, l logger.Logger, intrSvc service.InteractiveService) *ArticleHandler {
+func NewArticleHandler(svc service.ArticleService, l logger.Logger, intrSvc service.InteractiveService,
+	shareSvc service.ShareService, userSvc service.UserService, rankSvc service.RankingService,
+	trendSvc service.TrendingService, followSvc service.FollowService, relatedSvc service.RelatedService,
+	limiter *ActionLimiter) *ArticleHandler {
 	return &ArticleHandler{
-		svc:     svc,
-		l:       l,
-		intrSvc: intrSvc,
-		biz:     "article",
+		svc:        svc,
+		rankSvc:    rankSvc,
+		trendSvc:   trendSvc,
+		followSvc:  followSvc,
+		relatedSvc: relatedSvc,
+		limiter:    limiter,
+		l:          l,
+		intrSvc:    intrSvc,
+		shareSvc:   shareSvc,
+		userSvc:    userSvc,
+		biz:        "article",
 	}
 }
 
@@ -36,16 +55,31 @@ func (a *ArticleHandler) RegisterRouter(server *gin.Engine) {
 	g.POST("/edit", a.Edit)
 	g.POST("/publish", a.Publish)
 	g.POST("/withdraw", a.Withdraw)
+	g.POST("/tags", a.SetTags)
 
 	// 创作者接口
 	g.POST("/list", a.List)
 	g.GET("/detail:id", a.Detail)
+	g.GET("/share/stats:id", a.ShareStats)
 
 	// 读者接口
 	pub := g.Group("/pub")
 	pub.GET("/detail:id", a.PubDetail)
+	pub.GET("/hot", a.Hot)
+	pub.GET("/trending", a.Trending)
+	pub.GET("/related:id", a.Related)
 	pub.GET("/like", a.Like)
+	pub.POST("/reaction", a.Reaction)
 	pub.POST("/collection", a.Collection)
+	pub.POST("/collection/cancel", a.CancelCollection)
+	pub.POST("/collection/move", a.MoveCollection)
+	pub.POST("/share", a.Share)
+	// 点赞和收藏记录
+	pub.POST("/likes", a.LikeRecords)
+	pub.POST("/collections", a.CollectRecords)
+
+	// 分享短链 不需要登录
+	server.GET("/s/:code", a.ShareRedirect)
 }
 
 func (a *ArticleHandler) Withdraw(ctx *gin.Context) {
@@ -154,6 +188,10 @@ func (a *ArticleHandler) List(ctx *gin.Context) {
 		Status     uint8  `json:"status"`
 		AuthorId   int64  `json:"author_id"`
 		AuthorName string `json:"author_name"`
+		ReadCnt    int64  `json:"read_cnt"`
+		LikeCnt    int64  `json:"like_cnt"`
+		CollectCnt int64  `json:"collect_cnt"`
+		CommentCnt int64  `json:"comment_cnt"`
 		Ctime      int64  `json:"ctime"`
 		Utime      int64  `json:"utime"`
 	}
@@ -172,17 +210,29 @@ func (a *ArticleHandler) List(ctx *gin.Context) {
 			logger.Int64("uid", uc.Uid), logger.Error(err))
 		return
 	}
+	// 一次查出整页的互动计数，作者自己看不需要点赞收藏状态
+	intrs, err := a.intrSvc.GetIntrByArtIds(ctx, a.biz, slice.Map[domain.Article, int64](arts,
+		func(idx int, src domain.Article) int64 {
+			return src.Id
+		}), 0)
+	if err != nil {
+		// 计数拿不到不影响列表展示
+		a.l.Error("批量获取互动信息失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+	}
 	data = slice.Map[domain.Article, article](arts, func(idx int, src domain.Article) article {
+		intr := intrs[src.Id]
 		return article{
 			Id:      src.Id,
 			Title:   src.Title,
 			Content: src.Content,
 			Status:  src.Status.ToUint8(),
 			// 不需要Author作者信息
-			//Ctime: src.Ctime,
-			//Utime: src.Utime,
-			Ctime: src.Ctime,
-			Utime: src.Utime,
+			ReadCnt:    intr.ReadCnt,
+			LikeCnt:    intr.LikeCnt,
+			CollectCnt: intr.CollectCnt,
+			CommentCnt: intr.CommentCnt,
+			Ctime:      src.Ctime,
+			Utime:      src.Utime,
 		}
 	})
 	resp.SetGeneral(true, http.StatusOK, "ok")
@@ -252,10 +302,19 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 		Utime      int64  `json:"utime"`
 
 		ReadCnt    int64 `json:"read_cnt"`
+		ReaderCnt  int64 `json:"reader_cnt"`
 		LikeCnt    int64 `json:"like_cnt"`
 		CollectCnt int64 `json:"collect_cnt"`
+		CommentCnt int64 `json:"comment_cnt"`
+		ShareCnt   int64 `json:"share_cnt"`
 		Liked      bool  `json:"liked"`
 		Collected  bool  `json:"collected"`
+		// 当前用户是否关注了作者
+		Followed bool     `json:"followed"`
+		Tags     []string `json:"tags"`
+		// 每种表情的数量和当前用户点过的表情
+		ReactionCnts map[string]int64 `json:"reaction_cnts"`
+		Reactions    []string         `json:"reactions"`
 	}
 	var data article
 	str := ctx.Param("id")
@@ -265,15 +324,26 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 		return
 	}
 	var (
-		eg   errgroup.Group
-		art  domain.Article
-		intr domain.Interactive
+		eg       errgroup.Group
+		art      domain.Article
+		intr     domain.Interactive
+		followed bool
+		tags     []string
 	)
 	uc := ctx.MustGet("user").(ijwt.UserClaims)
 	eg.Go(func() error {
 		var er error
 		art, er = a.svc.GetPubByArtId(ctx, artId, uc.Uid)
-		return er
+		if er != nil {
+			return er
+		}
+		// 关注状态拿不到不影响看文章
+		followed, er = a.followSvc.Followed(ctx, uc.Uid, art.Author.Id)
+		if er != nil {
+			a.l.Error("获取关注状态失败", logger.Int64("uid", uc.Uid),
+				logger.Int64("author", art.Author.Id), logger.Error(er))
+		}
+		return nil
 	})
 
 	eg.Go(func() error {
@@ -281,40 +351,181 @@ func (a *ArticleHandler) PubDetail(ctx *gin.Context) {
 		intr, er = a.intrSvc.GetIntrByArtId(ctx, a.biz, artId, uc.Uid)
 		return er
 	})
+	eg.Go(func() error {
+		var er error
+		// 标签拿不到也不影响看文章
+		tags, er = a.relatedSvc.GetTags(ctx, artId)
+		if er != nil {
+			a.l.Error("获取文章标签失败", logger.Int64("id", artId), logger.Error(er))
+		}
+		return nil
+	})
 	if err := eg.Wait(); err != nil {
 		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
 		a.l.Error("获取文章详情数据失败", logger.Int64("uid", art.Author.Id), logger.Int64("id", art.Id), logger.Error(err))
 		return
 	}
 
-	go func() {
-		ctx, cancel := context.WithTimeout(ctx, time.Second*5)
-		defer cancel()
-		err = a.intrSvc.IncrReadCnt(ctx, a.biz, art.Id)
-		if err != nil {
-			a.l.Error("文章阅读量增加失败", logger.Int64("id", art.Id), logger.Error(err))
-		}
-	}()
+	// 阅读数由 GetPubByArtId 发出的阅读事件在消费者里面批量累加
+	// 通过分享链接进来的阅读，归因到分享者
+	if code := ctx.Query("share"); code != "" {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer cancel()
+			er := a.shareSvc.Read(ctx, code, artId, uc.Uid)
+			if er != nil {
+				a.l.Error("分享阅读归因失败", logger.String("code", code),
+					logger.Int64("id", artId), logger.Error(er))
+			}
+		}()
+	}
 	data = article{
-		Id:      art.Id,
-		Title:   art.Title,
-		Content: art.Content,
-		Status:  art.Status.ToUint8(),
-		// 需要
+		Id:         art.Id,
+		Title:      art.Title,
+		Content:    art.Content,
+		Status:     art.Status.ToUint8(),
+		AuthorId:   art.Author.Id,
 		AuthorName: art.Author.Name,
 		Ctime:      art.Ctime,
 		Utime:      art.Utime,
 
 		ReadCnt:    intr.ReadCnt,
+		ReaderCnt:  intr.ReaderCnt,
 		LikeCnt:    intr.LikeCnt,
 		CollectCnt: intr.CollectCnt,
+		CommentCnt: intr.CommentCnt,
+		ShareCnt:   intr.ShareCnt,
 		Liked:      intr.Liked,
 		Collected:  intr.Collected,
+		Followed:   followed,
+		Tags:       tags,
+
+		ReactionCnts: intr.ReactionCnts,
+		Reactions:    intr.Reactions,
 	}
 	resp.SetGeneral(true, http.StatusOK, "ok")
 	resp.SetData(data)
 }
 
+// Hot 热榜，定时任务算好放在缓存里面的
+func (a *ArticleHandler) Hot(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	arts, err := a.rankSvc.GetTopN(ctx)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取热榜失败", logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(toArticleBriefs(arts))
+}
+
+// Trending 实时热度，window 是 1h 或者 24h，默认 1h
+func (a *ArticleHandler) Trending(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	window, err := time.ParseDuration(ctx.DefaultQuery("window", "1h"))
+	if err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	arts, err := a.trendSvc.TopArticles(ctx, window, 20)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+		resp.SetData(toArticleBriefs(arts))
+	case errors.Is(err, service.ErrInvalidTrendingWindow):
+		resp.SetGeneral(true, http.StatusBadRequest, "只支持 1h 和 24h")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取实时热度失败", logger.String("window", window.String()), logger.Error(err))
+	}
+}
+
+// Related 详情页下面的相关文章，limit 默认 6
+func (a *ArticleHandler) Related(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	artId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	limit, err := strconv.Atoi(ctx.DefaultQuery("limit", "6"))
+	if err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	arts, err := a.relatedSvc.Related(ctx, artId, limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取相关文章失败", logger.Int64("id", artId), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(toArticleBriefs(arts))
+}
+
+// SetTags 作者修改文章的标签，tags 为空的时候清空
+func (a *ArticleHandler) SetTags(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Id   int64    `json:"id"`
+		Tags []string `json:"tags"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := a.relatedSvc.SetTags(ctx, req.Id, uc.Uid, req.Tags)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrInvalidTags):
+		resp.SetGeneral(true, http.StatusBadRequest, "最多 5 个标签，每个不超过 32 个字")
+	case errors.Is(err, service.ErrArticleNotFound):
+		resp.SetGeneral(true, http.StatusNotFound, "文章不存在")
+	case errors.Is(err, service.ErrTagsNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "只有作者可以修改标签")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("修改文章标签失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.Id), logger.Error(err))
+	}
+}
+
+// articleBrief 榜单里面的文章，没有内容
+type articleBrief struct {
+	Id       int64  `json:"id"`
+	Title    string `json:"title"`
+	AuthorId int64  `json:"author_id"`
+	Ctime    int64  `json:"ctime"`
+	Utime    int64  `json:"utime"`
+}
+
+func toArticleBriefs(arts []domain.Article) []articleBrief {
+	return slice.Map[domain.Article, articleBrief](arts, func(idx int, src domain.Article) articleBrief {
+		return articleBrief{
+			Id:       src.Id,
+			Title:    src.Title,
+			AuthorId: src.Author.Id,
+			Ctime:    src.Ctime,
+			Utime:    src.Utime,
+		}
+	})
+}
+
 func (a *ArticleHandler) Like(ctx *gin.Context) {
 	resp := proctocol.RespGeneral{}
 	defer func() {
@@ -330,6 +541,10 @@ func (a *ArticleHandler) Like(ctx *gin.Context) {
 		return
 	}
 	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if a.limiter.Limited(ctx, actionLike, uc.Uid, a.biz, req.ArtId) {
+		resp.SetGeneral(true, http.StatusTooManyRequests, "操作太频繁")
+		return
+	}
 	var err error
 	if req.Like {
 		err = a.intrSvc.Like(ctx, a.biz, req.ArtId, uc.Uid)
@@ -346,6 +561,45 @@ func (a *ArticleHandler) Like(ctx *gin.Context) {
 
 }
 
+// Reaction 点表情或者取消，表情 like 和点赞是一回事
+func (a *ArticleHandler) Reaction(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		ArtId    int64  `json:"art_id"`
+		Reaction string `json:"reaction"`
+		Cancel   bool   `json:"cancel"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if a.limiter.Limited(ctx, actionLike, uc.Uid, a.biz, req.ArtId) {
+		resp.SetGeneral(true, http.StatusTooManyRequests, "操作太频繁")
+		return
+	}
+	var err error
+	if req.Cancel {
+		err = a.intrSvc.CancelReaction(ctx, a.biz, req.ArtId, uc.Uid, req.Reaction)
+	} else {
+		err = a.intrSvc.React(ctx, a.biz, req.ArtId, uc.Uid, req.Reaction)
+	}
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrInvalidReaction):
+		resp.SetGeneral(true, http.StatusBadRequest, "不支持的表情")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("点表情失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.ArtId),
+			logger.String("reaction", req.Reaction), logger.Error(err))
+	}
+}
+
 func (a *ArticleHandler) Collection(ctx *gin.Context) {
 	resp := proctocol.RespGeneral{}
 	defer func() {
@@ -361,7 +615,15 @@ func (a *ArticleHandler) Collection(ctx *gin.Context) {
 		return
 	}
 	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if a.limiter.Limited(ctx, actionCollect, uc.Uid, a.biz, req.ArtId) {
+		resp.SetGeneral(true, http.StatusTooManyRequests, "操作太频繁")
+		return
+	}
 	err := a.intrSvc.AddCollectionItem(ctx, a.biz, req.ArtId, req.Cid, uc.Uid)
+	if errors.Is(err, service.ErrCollectionNoPermission) {
+		resp.SetGeneral(true, http.StatusForbidden, "收藏夹不存在")
+		return
+	}
 	if err != nil {
 		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
 		a.l.Error("收藏失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.ArtId), logger.Error(err))
@@ -371,3 +633,275 @@ func (a *ArticleHandler) Collection(ctx *gin.Context) {
 	resp.SetData(nil)
 
 }
+
+func (a *ArticleHandler) CancelCollection(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		ArtId int64 `json:"art_id"`
+		Cid   int64 `json:"cid"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	// 收藏和取消收藏共用限额，反复切换一样要限
+	if a.limiter.Limited(ctx, actionCollect, uc.Uid, a.biz, req.ArtId) {
+		resp.SetGeneral(true, http.StatusTooManyRequests, "操作太频繁")
+		return
+	}
+	err := a.intrSvc.CancelCollectionItem(ctx, a.biz, req.ArtId, req.Cid, uc.Uid)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("取消收藏失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.ArtId), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
+
+func (a *ArticleHandler) MoveCollection(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		ArtId   int64 `json:"art_id"`
+		FromCid int64 `json:"from_cid"`
+		ToCid   int64 `json:"to_cid"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := a.intrSvc.MoveCollectionItem(ctx, a.biz, req.ArtId, req.FromCid, req.ToCid, uc.Uid)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrCollectionNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "收藏夹不存在")
+	case errors.Is(err, service.ErrCollectionNotFound):
+		resp.SetGeneral(true, http.StatusBadRequest, "原收藏夹中没有这篇文章")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("移动收藏失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.ArtId), logger.Error(err))
+	}
+}
+
+func (a *ArticleHandler) Share(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		ArtId   int64  `json:"art_id"`
+		Channel string `json:"channel"`
+	}
+	type share struct {
+		Code string `json:"code"`
+		Url  string `json:"url"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	link, err := a.shareSvc.Share(ctx, a.biz, req.ArtId, uc.Uid, req.Channel)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("分享失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.ArtId), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(share{
+		Code: link.Code,
+		Url:  "/s/" + link.Code,
+	})
+}
+
+// ShareRedirect 分享短链，记录点击后跳转到文章详情
+func (a *ArticleHandler) ShareRedirect(ctx *gin.Context) {
+	code := ctx.Param("code")
+	link, err := a.shareSvc.Click(ctx, code)
+	switch {
+	case err == nil:
+		ctx.Redirect(http.StatusFound, fmt.Sprintf("/articles/pub/detail%d?share=%s", link.BizId, link.Code))
+	case errors.Is(err, service.ErrShareLinkNotFound):
+		ctx.Status(http.StatusNotFound)
+	default:
+		a.l.Error("分享链接点击失败", logger.String("code", code), logger.Error(err))
+		if link.BizId > 0 {
+			// 点击数没记上，不影响用户跳转
+			ctx.Redirect(http.StatusFound, fmt.Sprintf("/articles/pub/detail%d?share=%s", link.BizId, link.Code))
+			return
+		}
+		ctx.Status(http.StatusInternalServerError)
+	}
+}
+
+func (a *ArticleHandler) ShareStats(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	artId, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	stats, err := a.shareSvc.ArticleStats(ctx, artId, uc.Uid)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+		resp.SetData(stats)
+	case errors.Is(err, service.ErrShareNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "没有权限")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取分享数据失败", logger.Int64("uid", uc.Uid), logger.Int64("id", artId), logger.Error(err))
+	}
+}
+
+type InteractiveRecordVo struct {
+	BizId      int64  `json:"biz_id"`
+	Title      string `json:"title"`
+	Abstract   string `json:"abstract"`
+	AuthorId   int64  `json:"author_id"`
+	AuthorName string `json:"author_name"`
+	// 文章已经被删除或者撤回
+	Invalid bool  `json:"invalid"`
+	Utime   int64 `json:"utime"`
+}
+
+type interactiveRecordReq struct {
+	// 不传就是看自己的
+	Uid    int64 `json:"uid"`
+	Offset int   `json:"offset"`
+	Limit  int   `json:"limit"`
+}
+
+func (a *ArticleHandler) LikeRecords(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req interactiveRecordReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if req.Uid == 0 {
+		req.Uid = uc.Uid
+	}
+	if req.Uid != uc.Uid {
+		u, err := a.userSvc.FindById(ctx, req.Uid)
+		if err != nil {
+			resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+			a.l.Error("获取用户隐私设置失败", logger.Int64("uid", req.Uid), logger.Error(err))
+			return
+		}
+		if !u.LikesPublic {
+			resp.SetGeneral(true, http.StatusForbidden, "对方没有公开点赞记录")
+			return
+		}
+	}
+	records, err := a.intrSvc.LikeRecords(ctx, a.biz, req.Uid, req.Offset, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取点赞记录失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	vos, err := a.toRecordVos(ctx, records)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取点赞文章失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(vos)
+}
+
+// CollectRecords 收藏记录，看别人的时候只能看到公开收藏夹里面的
+func (a *ArticleHandler) CollectRecords(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req interactiveRecordReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if req.Uid == 0 {
+		req.Uid = uc.Uid
+	}
+	records, err := a.intrSvc.CollectRecords(ctx, a.biz, req.Uid, req.Uid != uc.Uid, req.Offset, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取收藏记录失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	vos, err := a.toRecordVos(ctx, records)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		a.l.Error("获取收藏文章失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(vos)
+}
+
+// toRecordVos 关联线上库的文章摘要和作者
+func (a *ArticleHandler) toRecordVos(ctx context.Context, records []domain.InteractiveRecord) ([]InteractiveRecordVo, error) {
+	arts, err := a.svc.GetPubByArtIds(ctx, slice.Map[domain.InteractiveRecord, int64](records,
+		func(idx int, src domain.InteractiveRecord) int64 {
+			return src.BizId
+		}))
+	if err != nil {
+		return nil, err
+	}
+	artMap := make(map[int64]domain.Article, len(arts))
+	uids := make([]int64, 0, len(arts))
+	for _, art := range arts {
+		artMap[art.Id] = art
+		uids = append(uids, art.Author.Id)
+	}
+	authors := make(map[int64]string, len(uids))
+	if len(uids) > 0 {
+		// 作者名字只是展示用，查不到就空着
+		users, er := a.userSvc.FindByIds(ctx, uids)
+		if er != nil {
+			a.l.Error("获取作者信息失败", logger.Error(er))
+		}
+		for _, u := range users {
+			authors[u.Id] = u.Nickname
+		}
+	}
+	return slice.Map[domain.InteractiveRecord, InteractiveRecordVo](records, func(idx int, src domain.InteractiveRecord) InteractiveRecordVo {
+		art, ok := artMap[src.BizId]
+		return InteractiveRecordVo{
+			BizId:      src.BizId,
+			Title:      art.Title,
+			Abstract:   art.Content,
+			AuthorId:   art.Author.Id,
+			AuthorName: authors[art.Author.Id],
+			Invalid:    !ok || art.Status != domain.ArticleStatusPublished,
+			Utime:      src.Utime,
+		}
+	}), nil
+}
@@ -0,0 +1,147 @@
+package web
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// ArticleStatsHandler 作者的文章数据统计
+type ArticleStatsHandler struct {
+	svc     service.ArticleStatsService
+	dashSvc service.AuthorDashboardService
+	l       logger.Logger
+}
+
+func NewArticleStatsHandler(svc service.ArticleStatsService, dashSvc service.AuthorDashboardService,
+	l logger.Logger) *ArticleStatsHandler {
+	return &ArticleStatsHandler{
+		svc:     svc,
+		dashSvc: dashSvc,
+		l:       l,
+	}
+}
+
+func (h *ArticleStatsHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/articles/stats")
+	g.POST("/series", h.Series)
+	g.POST("/export", h.Export)
+	g.POST("/dashboard", h.Dashboard)
+}
+
+// Dashboard 作者看板，总数据、热门文章、发表频率、粉丝和环比
+func (h *ArticleStatsHandler) Dashboard(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		// Days 环比的周期，默认 7 天，最多 90 天
+		Days int `json:"days"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	dash, err := h.dashSvc.Dashboard(ctx, uc.Uid, req.Days)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+		resp.SetData(dash)
+	case errors.Is(err, service.ErrInvalidStatsRange):
+		resp.SetGeneral(true, http.StatusBadRequest, "周期最多 90 天")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取作者看板失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+	}
+}
+
+type ArticleStatsReq struct {
+	// Id 为 0 的时候统计自己所有的文章
+	Id          int64  `json:"id"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Granularity string `json:"granularity"`
+}
+
+func (h *ArticleStatsHandler) Series(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req ArticleStatsReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	stats, err := h.stats(ctx, uc.Uid, req, &resp)
+	if err != nil {
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(stats)
+}
+
+// Export 导出 CSV，出错的时候和 Series 一样返回 JSON
+func (h *ArticleStatsHandler) Export(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	var req ArticleStatsReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		ctx.JSON(http.StatusOK, resp)
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	stats, err := h.stats(ctx, uc.Uid, req, &resp)
+	if err != nil {
+		ctx.JSON(http.StatusOK, resp)
+		return
+	}
+	name := fmt.Sprintf("article_stats_%d_%s_%s.csv", req.Id, req.Start, req.End)
+	ctx.Header("Content-Type", "text/csv; charset=utf-8")
+	ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	ctx.Status(http.StatusOK)
+	w := csv.NewWriter(ctx.Writer)
+	_ = w.Write([]string{"date", "read_cnt", "like_cnt", "collect_cnt", "comment_cnt"})
+	for _, s := range stats {
+		_ = w.Write([]string{
+			s.Date,
+			strconv.FormatInt(s.ReadCnt, 10),
+			strconv.FormatInt(s.LikeCnt, 10),
+			strconv.FormatInt(s.CollectCnt, 10),
+			strconv.FormatInt(s.CommentCnt, 10),
+		})
+	}
+	w.Flush()
+	if err = w.Error(); err != nil {
+		h.l.Error("导出文章数据失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+	}
+}
+
+// stats 出错的时候把错误码写进 resp
+func (h *ArticleStatsHandler) stats(ctx *gin.Context, uid int64, req ArticleStatsReq,
+	resp *proctocol.RespGeneral) ([]domain.InteractiveStat, error) {
+	stats, err := h.svc.Stats(ctx, uid, req.Id, req.Start, req.End, domain.StatsGranularity(req.Granularity))
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrInvalidStatsRange):
+		resp.SetGeneral(true, http.StatusBadRequest, "时间范围不合法，最多查询一年")
+	case errors.Is(err, service.ErrStatsNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "没有权限")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取文章数据失败", logger.Int64("uid", uid), logger.Int64("id", req.Id), logger.Error(err))
+	}
+	return stats, err
+}
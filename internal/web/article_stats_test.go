@@ -0,0 +1,138 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	svcmocks "webook/internal/service/mocks"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+func TestArticleStatsHandler_Export(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.ArticleStatsService
+		reqBody string
+
+		wantCsv         string
+		wantDisposition string
+		wantResp        proctocol.RespGeneral
+	}{
+		{
+			name: "导出按周汇总",
+			mock: func(ctrl *gomock.Controller) service.ArticleStatsService {
+				svc := svcmocks.NewMockArticleStatsService(ctrl)
+				svc.EXPECT().Stats(gomock.Any(), int64(123), int64(1), "2024-03-04", "2024-03-12",
+					domain.StatsGranularityWeek).
+					Return([]domain.InteractiveStat{
+						{Date: "2024-03-04", ReadCnt: 10, LikeCnt: 2, CollectCnt: 1},
+						{Date: "2024-03-11", ReadCnt: 3, LikeCnt: -1, CommentCnt: 4},
+					}, nil)
+				return svc
+			},
+			reqBody: `{"id":1,"start":"2024-03-04","end":"2024-03-12","granularity":"week"}`,
+			wantCsv: "date,read_cnt,like_cnt,collect_cnt,comment_cnt\n" +
+				"2024-03-04,10,2,1,0\n" +
+				"2024-03-11,3,-1,0,4\n",
+			wantDisposition: `attachment; filename="article_stats_1_2024-03-04_2024-03-12.csv"`,
+		},
+		{
+			name: "没有数据只有表头",
+			mock: func(ctrl *gomock.Controller) service.ArticleStatsService {
+				svc := svcmocks.NewMockArticleStatsService(ctrl)
+				svc.EXPECT().Stats(gomock.Any(), int64(123), int64(0), "2024-03-04", "2024-03-04",
+					domain.StatsGranularity("")).
+					Return([]domain.InteractiveStat{}, nil)
+				return svc
+			},
+			reqBody:         `{"start":"2024-03-04","end":"2024-03-04"}`,
+			wantCsv:         "date,read_cnt,like_cnt,collect_cnt,comment_cnt\n",
+			wantDisposition: `attachment; filename="article_stats_0_2024-03-04_2024-03-04.csv"`,
+		},
+		{
+			name: "不是自己的文章返回JSON",
+			mock: func(ctrl *gomock.Controller) service.ArticleStatsService {
+				svc := svcmocks.NewMockArticleStatsService(ctrl)
+				svc.EXPECT().Stats(gomock.Any(), int64(123), int64(1), "2024-03-04", "2024-03-12",
+					domain.StatsGranularity("")).
+					Return(nil, service.ErrStatsNoPermission)
+				return svc
+			},
+			reqBody: `{"id":1,"start":"2024-03-04","end":"2024-03-12"}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 403,
+				ErrorMsg:  "没有权限",
+			},
+		},
+		{
+			name: "查询失败返回JSON",
+			mock: func(ctrl *gomock.Controller) service.ArticleStatsService {
+				svc := svcmocks.NewMockArticleStatsService(ctrl)
+				svc.EXPECT().Stats(gomock.Any(), int64(123), int64(1), "2024-03-04", "2024-03-12",
+					domain.StatsGranularity("")).
+					Return(nil, errors.New("mock db error"))
+				return svc
+			},
+			reqBody: `{"id":1,"start":"2024-03-04","end":"2024-03-12"}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 500,
+				ErrorMsg:  "系统内部错误",
+			},
+		},
+		{
+			name: "Bind错误",
+			mock: func(ctrl *gomock.Controller) service.ArticleStatsService {
+				return svcmocks.NewMockArticleStatsService(ctrl)
+			},
+			reqBody: `{"id":1,`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 400,
+				ErrorMsg:  "参数错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			hdl := NewArticleStatsHandler(tc.mock(ctrl), nil, logger.NewNopLogger())
+			server := gin.Default()
+			server.Use(func(ctx *gin.Context) {
+				ctx.Set("user", ijwt.UserClaims{
+					Uid: 123,
+				})
+			})
+			hdl.RegisterRouter(server)
+			req, err := http.NewRequest(http.MethodPost, "/articles/stats/export", bytes.NewBufferString(tc.reqBody))
+			assert.NoError(t, err)
+			req.Header.Set("Content-Type", "application/json")
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, req)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			if tc.wantCsv != "" {
+				assert.Equal(t, "text/csv; charset=utf-8", recorder.Header().Get("Content-Type"))
+				assert.Equal(t, tc.wantDisposition, recorder.Header().Get("Content-Disposition"))
+				assert.Equal(t, tc.wantCsv, recorder.Body.String())
+				return
+			}
+			var res proctocol.RespGeneral
+			err = json.NewDecoder(recorder.Body).Decode(&res)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantResp, res)
+		})
+	}
+}
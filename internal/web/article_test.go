@@ -3,6 +3,7 @@ package web
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
@@ -12,6 +13,7 @@ import (
 	"webook/internal/domain"
 	"webook/internal/domain/proctocol"
 	"webook/internal/service"
+	svcmocks "webook/internal/service/mocks"
 	ijwt "webook/internal/web/jwt"
 	"webook/pkg/logger"
 )
@@ -28,7 +30,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 		{
 			name: "新建并发表",
 			mock: func(ctrl *gomock.Controller) service.ArticleService {
-				svc = svcmocks.NewMockArticleService(ctrl)
+				svc := svcmocks.NewMockArticleService(ctrl)
 				svc.EXPECT().Publish(gomock.Any(), domain.Article{
 					Title:   "标题",
 					Content: "内容",
@@ -50,7 +52,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 		{
 			name: "已有帖子发表成功",
 			mock: func(ctrl *gomock.Controller) service.ArticleService {
-				svc = svcmocks.NewMockArticleService(ctrl)
+				svc := svcmocks.NewMockArticleService(ctrl)
 				svc.EXPECT().Publish(gomock.Any(), domain.Article{
 					Id:      1,
 					Title:   "标题",
@@ -73,7 +75,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 		{
 			name: "发表失败",
 			mock: func(ctrl *gomock.Controller) service.ArticleService {
-				svc = svcmocks.NewMockArticleService(ctrl)
+				svc := svcmocks.NewMockArticleService(ctrl)
 				svc.EXPECT().Publish(gomock.Any(), domain.Article{
 					Title:   "标题",
 					Content: "内容",
@@ -94,12 +96,16 @@ func TestArticleHandler_Publish(t *testing.T) {
 		{
 			name: "Bind错误",
 			mock: func(ctrl *gomock.Controller) service.ArticleService {
-				svc = svcmocks.NewMockArticleService(ctrl)
+				svc := svcmocks.NewMockArticleService(ctrl)
 				return svc
 			},
 			reqBody:  `{"title":"标题","content":"内容"uuuuuuuu}`,
-			wantCode: 400,
-			wantResp: proctocol.RespGeneral{},
+			wantCode: http.StatusOK,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 400,
+				ErrorMsg:  "参数错误",
+			},
 		},
 	}
 	for _, tc := range testCases {
@@ -108,7 +114,7 @@ func TestArticleHandler_Publish(t *testing.T) {
 			defer ctrl.Finish()
 
 			artSvc := tc.mock(ctrl)
-			hdl := NewArticleHandler(artSvc, logger.NewNopLogger())
+			hdl := NewArticleHandler(artSvc, logger.NewNopLogger(), nil, nil, nil, nil, nil, nil, nil, nil)
 			server := gin.Default()
 			server.Use(func(ctx *gin.Context) {
 				ctx.Set("user", ijwt.UserClaims{
@@ -129,3 +135,207 @@ func TestArticleHandler_Publish(t *testing.T) {
 		})
 	}
 }
+
+func TestArticleHandler_LikeRecords(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService)
+		reqBody string
+
+		wantResp proctocol.RespGeneral
+	}{
+		{
+			name: "看自己的不查隐私设置",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				intrSvc.EXPECT().LikeRecords(gomock.Any(), "article", int64(123), 0, 20).
+					Return([]domain.InteractiveRecord{{Uid: 123, Biz: "article", BizId: 1, Utime: 100}}, nil)
+				artSvc.EXPECT().GetPubByArtIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Title: "标题", Content: "摘要",
+						Author: domain.Author{Id: 2}, Status: domain.ArticleStatusPublished}}, nil)
+				userSvc.EXPECT().FindByIds(gomock.Any(), []int64{2}).
+					Return([]domain.User{{Id: 2, Nickname: "作者"}}, nil)
+				return artSvc, intrSvc, userSvc
+			},
+			reqBody: `{}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+				Data: []any{map[string]any{
+					"biz_id":      float64(1),
+					"title":       "标题",
+					"abstract":    "摘要",
+					"author_id":   float64(2),
+					"author_name": "作者",
+					"invalid":     false,
+					"utime":       float64(100),
+				}},
+			},
+		},
+		{
+			name: "对方公开了点赞记录",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				userSvc.EXPECT().FindById(gomock.Any(), int64(456)).
+					Return(domain.User{Id: 456, LikesPublic: true}, nil)
+				intrSvc.EXPECT().LikeRecords(gomock.Any(), "article", int64(456), 0, 20).
+					Return([]domain.InteractiveRecord{{Uid: 456, Biz: "article", BizId: 1, Utime: 100}}, nil)
+				// 文章已经撤回，查不到
+				artSvc.EXPECT().GetPubByArtIds(gomock.Any(), []int64{1}).Return([]domain.Article{}, nil)
+				return artSvc, intrSvc, userSvc
+			},
+			reqBody: `{"uid":456}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+				Data: []any{map[string]any{
+					"biz_id":      float64(1),
+					"title":       "",
+					"abstract":    "",
+					"author_id":   float64(0),
+					"author_name": "",
+					"invalid":     true,
+					"utime":       float64(100),
+				}},
+			},
+		},
+		{
+			name: "对方没有公开点赞记录",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				userSvc.EXPECT().FindById(gomock.Any(), int64(456)).
+					Return(domain.User{Id: 456, LikesPublic: false}, nil)
+				return svcmocks.NewMockArticleService(ctrl), svcmocks.NewMockInteractiveService(ctrl), userSvc
+			},
+			reqBody: `{"uid":456}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 403,
+				ErrorMsg:  "对方没有公开点赞记录",
+			},
+		},
+		{
+			name: "查询隐私设置失败",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService) {
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				userSvc.EXPECT().FindById(gomock.Any(), int64(456)).
+					Return(domain.User{}, errors.New("mock db error"))
+				return svcmocks.NewMockArticleService(ctrl), svcmocks.NewMockInteractiveService(ctrl), userSvc
+			},
+			reqBody: `{"uid":456}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 500,
+				ErrorMsg:  "系统内部错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artSvc, intrSvc, userSvc := tc.mock(ctrl)
+			res := doRecordsRequest(t, artSvc, intrSvc, userSvc, "/articles/pub/likes", tc.reqBody)
+			assert.Equal(t, tc.wantResp, res)
+		})
+	}
+}
+
+func TestArticleHandler_CollectRecords(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService)
+		reqBody string
+
+		wantResp proctocol.RespGeneral
+	}{
+		{
+			name: "看自己的包括私密收藏夹",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				intrSvc.EXPECT().CollectRecords(gomock.Any(), "article", int64(123), false, 0, 20).
+					Return([]domain.InteractiveRecord{}, nil)
+				artSvc.EXPECT().GetPubByArtIds(gomock.Any(), []int64{}).Return([]domain.Article{}, nil)
+				return artSvc, intrSvc, svcmocks.NewMockUserService(ctrl)
+			},
+			reqBody: `{}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+				Data:      []any{},
+			},
+		},
+		{
+			name: "看别人的只有公开收藏夹",
+			mock: func(ctrl *gomock.Controller) (service.ArticleService, service.InteractiveService, service.UserService) {
+				artSvc := svcmocks.NewMockArticleService(ctrl)
+				intrSvc := svcmocks.NewMockInteractiveService(ctrl)
+				intrSvc.EXPECT().CollectRecords(gomock.Any(), "article", int64(456), true, 0, 20).
+					Return([]domain.InteractiveRecord{{Uid: 456, Biz: "article", BizId: 1, Utime: 100}}, nil)
+				artSvc.EXPECT().GetPubByArtIds(gomock.Any(), []int64{1}).
+					Return([]domain.Article{{Id: 1, Title: "标题", Content: "摘要",
+						Author: domain.Author{Id: 2}, Status: domain.ArticleStatusPublished}}, nil)
+				userSvc := svcmocks.NewMockUserService(ctrl)
+				// 作者信息查不到也正常返回
+				userSvc.EXPECT().FindByIds(gomock.Any(), []int64{2}).Return(nil, errors.New("mock db error"))
+				return artSvc, intrSvc, userSvc
+			},
+			reqBody: `{"uid":456}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+				Data: []any{map[string]any{
+					"biz_id":      float64(1),
+					"title":       "标题",
+					"abstract":    "摘要",
+					"author_id":   float64(2),
+					"author_name": "",
+					"invalid":     false,
+					"utime":       float64(100),
+				}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			artSvc, intrSvc, userSvc := tc.mock(ctrl)
+			res := doRecordsRequest(t, artSvc, intrSvc, userSvc, "/articles/pub/collections", tc.reqBody)
+			assert.Equal(t, tc.wantResp, res)
+		})
+	}
+}
+
+func doRecordsRequest(t *testing.T, artSvc service.ArticleService, intrSvc service.InteractiveService,
+	userSvc service.UserService, path string, body string) proctocol.RespGeneral {
+	hdl := NewArticleHandler(artSvc, logger.NewNopLogger(), intrSvc, nil, userSvc, nil, nil, nil, nil, nil)
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("user", ijwt.UserClaims{
+			Uid: 123,
+		})
+	})
+	hdl.RegisterRouter(server)
+	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var res proctocol.RespGeneral
+	err = json.NewDecoder(recorder.Body).Decode(&res)
+	assert.NoError(t, err)
+	return res
+}
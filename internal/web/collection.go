@@ -0,0 +1,234 @@
+package web
+
+import (
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// CollectionHandler 收藏夹管理，收藏和取消收藏在 ArticleHandler 上
+type CollectionHandler struct {
+	svc service.CollectionService
+	l   logger.Logger
+}
+
+func NewCollectionHandler(svc service.CollectionService, l logger.Logger) *CollectionHandler {
+	return &CollectionHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *CollectionHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/collections")
+	g.POST("/create", h.Create)
+	g.POST("/edit", h.Edit)
+	g.POST("/delete", h.Delete)
+	g.POST("/list", h.List)
+	g.POST("/items", h.Items)
+}
+
+type CollectionVo struct {
+	Id         int64  `json:"id"`
+	Uid        int64  `json:"uid"`
+	Name       string `json:"name"`
+	Visibility uint8  `json:"visibility"`
+	Ctime      int64  `json:"ctime"`
+	Utime      int64  `json:"utime"`
+}
+
+type CollectionItemVo struct {
+	Cid      int64  `json:"cid"`
+	Biz      string `json:"biz"`
+	BizId    int64  `json:"biz_id"`
+	Title    string `json:"title"`
+	Abstract string `json:"abstract"`
+	AuthorId int64  `json:"author_id"`
+	// 文章已经被删除或者撤回
+	Invalid bool  `json:"invalid"`
+	Ctime   int64 `json:"ctime"`
+}
+
+func (h *CollectionHandler) Create(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Name       string `json:"name"`
+		Visibility uint8  `json:"visibility"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Name == "" {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	id, err := h.svc.Create(ctx, domain.Collection{
+		Uid:        uc.Uid,
+		Name:       req.Name,
+		Visibility: domain.CollectionVisibility(req.Visibility),
+	})
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("创建收藏夹失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(id)
+}
+
+func (h *CollectionHandler) Edit(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Id         int64  `json:"id"`
+		Name       string `json:"name"`
+		Visibility uint8  `json:"visibility"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Name == "" {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.Update(ctx, domain.Collection{
+		Id:         req.Id,
+		Uid:        uc.Uid,
+		Name:       req.Name,
+		Visibility: domain.CollectionVisibility(req.Visibility),
+	})
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrCollectionNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "没有权限")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("修改收藏夹失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.Id), logger.Error(err))
+	}
+}
+
+func (h *CollectionHandler) Delete(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.Delete(ctx, req.Id, uc.Uid)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrCollectionNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "没有权限")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("删除收藏夹失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.Id), logger.Error(err))
+	}
+}
+
+func (h *CollectionHandler) List(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		// 不传就是看自己的
+		Uid int64 `json:"uid"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if req.Uid == 0 {
+		req.Uid = uc.Uid
+	}
+	cols, err := h.svc.List(ctx, req.Uid, uc.Uid)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取收藏夹列表失败", logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(slice.Map[domain.Collection, CollectionVo](cols, func(idx int, src domain.Collection) CollectionVo {
+		return CollectionVo{
+			Id:         src.Id,
+			Uid:        src.Uid,
+			Name:       src.Name,
+			Visibility: src.Visibility.ToUint8(),
+			Ctime:      src.Ctime,
+			Utime:      src.Utime,
+		}
+	}))
+}
+
+func (h *CollectionHandler) Items(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Cid int64 `json:"cid"`
+		// 看默认收藏夹的时候需要，不传就是自己的
+		Uid    int64 `json:"uid"`
+		Offset int   `json:"offset"`
+		Limit  int   `json:"limit"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if req.Uid == 0 {
+		req.Uid = uc.Uid
+	}
+	items, err := h.svc.Items(ctx, req.Cid, req.Uid, uc.Uid, req.Offset, req.Limit)
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrCollectionNotFound):
+		resp.SetGeneral(true, http.StatusBadRequest, "收藏夹不存在")
+		return
+	case errors.Is(err, service.ErrCollectionNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "没有权限")
+		return
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取收藏夹内容失败", logger.Int64("uid", uc.Uid), logger.Int64("cid", req.Cid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(slice.Map[domain.CollectionItem, CollectionItemVo](items, func(idx int, src domain.CollectionItem) CollectionItemVo {
+		return CollectionItemVo{
+			Cid:      src.Cid,
+			Biz:      src.Biz,
+			BizId:    src.BizId,
+			Title:    src.Article.Title,
+			Abstract: src.Article.Content,
+			AuthorId: src.Article.Author.Id,
+			Invalid:  src.Article.Status != domain.ArticleStatusPublished,
+			Ctime:    src.Ctime,
+		}
+	}))
+}
@@ -0,0 +1,234 @@
+package web
+
+import (
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+type CommentHandler struct {
+	svc     service.CommentService
+	limiter *ActionLimiter
+	l       logger.Logger
+}
+
+func NewCommentHandler(svc service.CommentService, limiter *ActionLimiter, l logger.Logger) *CommentHandler {
+	return &CommentHandler{
+		svc:     svc,
+		limiter: limiter,
+		l:       l,
+	}
+}
+
+func (h *CommentHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/comments")
+	g.POST("/create", h.Create)
+	g.POST("/delete", h.Delete)
+	g.POST("/list", h.List)
+	g.POST("/replies", h.Replies)
+	g.POST("/like", h.Like)
+}
+
+type CommentVo struct {
+	Id       int64  `json:"id"`
+	Uid      int64  `json:"uid"`
+	Content  string `json:"content"`
+	RootId   int64  `json:"root_id"`
+	ParentId int64  `json:"parent_id"`
+	LikeCnt  int64  `json:"like_cnt"`
+	ReplyCnt int64  `json:"reply_cnt"`
+	Ctime    int64  `json:"ctime"`
+}
+
+func (h *CommentHandler) Create(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Biz      string `json:"biz"`
+		BizId    int64  `json:"biz_id"`
+		Content  string `json:"content"`
+		ParentId int64  `json:"parent_id"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Content == "" || req.Biz == "" || req.BizId <= 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if h.limiter.Limited(ctx, actionComment, uc.Uid, req.Biz, req.BizId) {
+		resp.SetGeneral(true, http.StatusTooManyRequests, "操作太频繁")
+		return
+	}
+	cmt := domain.Comment{
+		Biz:         req.Biz,
+		BizId:       req.BizId,
+		Content:     req.Content,
+		Commentator: domain.User{Id: uc.Uid},
+	}
+	if req.ParentId > 0 {
+		cmt.ParentComment = &domain.Comment{Id: req.ParentId}
+	}
+	id, err := h.svc.Comment(ctx, cmt)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+		resp.SetData(id)
+	case errors.Is(err, service.ErrCommentInvalidBiz):
+		resp.SetGeneral(true, http.StatusBadRequest, "评论的资源不存在")
+	case errors.Is(err, service.ErrCommentNotFound), errors.Is(err, service.ErrCommentInvalidReply):
+		resp.SetGeneral(true, http.StatusBadRequest, "回复的评论不存在")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("发表评论失败", logger.Int64("uid", uc.Uid),
+			logger.String("biz", req.Biz), logger.Int64("bizId", req.BizId), logger.Error(err))
+	}
+}
+
+func (h *CommentHandler) Delete(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Id int64 `json:"id"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.Delete(ctx, req.Id, uc.Uid)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrCommentNotFound):
+		resp.SetGeneral(true, http.StatusBadRequest, "评论不存在")
+	case errors.Is(err, service.ErrCommentNoPermission):
+		resp.SetGeneral(true, http.StatusForbidden, "没有权限")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("删除评论失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.Id), logger.Error(err))
+	}
+}
+
+func (h *CommentHandler) List(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Biz   string `json:"biz"`
+		BizId int64  `json:"biz_id"`
+		// 0 最新 1 最热
+		Sort uint8 `json:"sort"`
+		domain.CommentCursor
+		Limit int `json:"limit"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	cmts, err := h.svc.List(ctx, req.Biz, req.BizId, domain.CommentSort(req.Sort), req.CommentCursor, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取评论列表失败", logger.String("biz", req.Biz),
+			logger.Int64("bizId", req.BizId), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(h.toVos(cmts))
+}
+
+func (h *CommentHandler) Replies(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		RootId int64 `json:"root_id"`
+		LastId int64 `json:"last_id"`
+		Limit  int   `json:"limit"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	cmts, err := h.svc.Replies(ctx, req.RootId, req.LastId, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取回复列表失败", logger.Int64("rootId", req.RootId), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(h.toVos(cmts))
+}
+
+func (h *CommentHandler) Like(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		Id   int64 `json:"id"`
+		Like bool  `json:"like"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if h.limiter.Limited(ctx, actionLike, uc.Uid, "comment", req.Id) {
+		resp.SetGeneral(true, http.StatusTooManyRequests, "操作太频繁")
+		return
+	}
+	var err error
+	if req.Like {
+		err = h.svc.Like(ctx, req.Id, uc.Uid)
+	} else {
+		err = h.svc.CancelLike(ctx, req.Id, uc.Uid)
+	}
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("评论点赞失败", logger.Int64("uid", uc.Uid), logger.Int64("id", req.Id), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
+
+func (h *CommentHandler) toVos(cmts []domain.Comment) []CommentVo {
+	return slice.Map[domain.Comment, CommentVo](cmts, func(idx int, src domain.Comment) CommentVo {
+		vo := CommentVo{
+			Id:       src.Id,
+			Uid:      src.Commentator.Id,
+			Content:  src.Content,
+			LikeCnt:  src.LikeCnt,
+			ReplyCnt: src.ReplyCnt,
+			Ctime:    src.Ctime,
+		}
+		if src.RootComment != nil {
+			vo.RootId = src.RootComment.Id
+		}
+		if src.ParentComment != nil {
+			vo.ParentId = src.ParentComment.Id
+		}
+		return vo
+	})
+}
@@ -0,0 +1,214 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	svcmocks "webook/internal/service/mocks"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// passLimiter 不限流
+type passLimiter struct{}
+
+func (passLimiter) Limit(ctx context.Context, key string) (bool, error) {
+	return false, nil
+}
+
+func TestCommentHandler_Create(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) service.CommentService
+		reqBody string
+
+		wantResp proctocol.RespGeneral
+	}{
+		{
+			name: "评论成功",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Comment(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Content: "评论", Commentator: domain.User{Id: 123},
+				}).Return(int64(10), nil)
+				return svc
+			},
+			reqBody: `{"biz":"article","biz_id":1,"content":"评论"}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				Data:      float64(10),
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+			},
+		},
+		{
+			name: "回复",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Comment(gomock.Any(), domain.Comment{
+					Biz: "article", BizId: 1, Content: "回复", Commentator: domain.User{Id: 123},
+					ParentComment: &domain.Comment{Id: 5},
+				}).Return(int64(11), nil)
+				return svc
+			},
+			reqBody: `{"biz":"article","biz_id":1,"content":"回复","parent_id":5}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				Data:      float64(11),
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+			},
+		},
+		{
+			name: "没有指定评论的资源",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				return svcmocks.NewMockCommentService(ctrl)
+			},
+			reqBody: `{"content":"评论"}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 400,
+				ErrorMsg:  "参数错误",
+			},
+		},
+		{
+			name: "评论的资源不存在",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Comment(gomock.Any(), gomock.Any()).Return(int64(0), service.ErrCommentInvalidBiz)
+				return svc
+			},
+			reqBody: `{"biz":"article","biz_id":100,"content":"评论"}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 400,
+				ErrorMsg:  "评论的资源不存在",
+			},
+		},
+		{
+			name: "回复的评论不属于这篇文章",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Comment(gomock.Any(), gomock.Any()).Return(int64(0), service.ErrCommentInvalidReply)
+				return svc
+			},
+			reqBody: `{"biz":"article","biz_id":1,"content":"回复","parent_id":5}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 400,
+				ErrorMsg:  "回复的评论不存在",
+			},
+		},
+		{
+			name: "系统错误",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Comment(gomock.Any(), gomock.Any()).Return(int64(0), errors.New("db error"))
+				return svc
+			},
+			reqBody: `{"biz":"article","biz_id":1,"content":"评论"}`,
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 500,
+				ErrorMsg:  "系统内部错误",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			res := doCommentRequest(t, tc.mock(ctrl), "/comments/create", tc.reqBody)
+			assert.Equal(t, tc.wantResp, res)
+		})
+	}
+}
+
+func TestCommentHandler_Delete(t *testing.T) {
+	testCases := []struct {
+		name string
+		mock func(ctrl *gomock.Controller) service.CommentService
+
+		wantResp proctocol.RespGeneral
+	}{
+		{
+			name: "删除成功",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(5), int64(123)).Return(nil)
+				return svc
+			},
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 200,
+				ErrorMsg:  "ok",
+			},
+		},
+		{
+			name: "没有权限",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(5), int64(123)).Return(service.ErrCommentNoPermission)
+				return svc
+			},
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 403,
+				ErrorMsg:  "没有权限",
+			},
+		},
+		{
+			name: "评论不存在",
+			mock: func(ctrl *gomock.Controller) service.CommentService {
+				svc := svcmocks.NewMockCommentService(ctrl)
+				svc.EXPECT().Delete(gomock.Any(), int64(5), int64(123)).Return(service.ErrCommentNotFound)
+				return svc
+			},
+			wantResp: proctocol.RespGeneral{
+				Success:   true,
+				ErrorCode: 400,
+				ErrorMsg:  "评论不存在",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			res := doCommentRequest(t, tc.mock(ctrl), "/comments/delete", `{"id":5}`)
+			assert.Equal(t, tc.wantResp, res)
+		})
+	}
+}
+
+func doCommentRequest(t *testing.T, svc service.CommentService, path string, body string) proctocol.RespGeneral {
+	limiter := NewActionLimiter(passLimiter{}, passLimiter{}, logger.NewNopLogger())
+	hdl := NewCommentHandler(svc, limiter, logger.NewNopLogger())
+	server := gin.Default()
+	server.Use(func(ctx *gin.Context) {
+		ctx.Set("user", ijwt.UserClaims{
+			Uid: 123,
+		})
+	})
+	hdl.RegisterRouter(server)
+	req, err := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+	assert.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var res proctocol.RespGeneral
+	err = json.NewDecoder(recorder.Body).Decode(&res)
+	assert.NoError(t, err)
+	return res
+}
@@ -0,0 +1,68 @@
+package web
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// FeedHandler 关注的作者发表的文章
+type FeedHandler struct {
+	svc service.FeedService
+	l   logger.Logger
+}
+
+func NewFeedHandler(svc service.FeedService, l logger.Logger) *FeedHandler {
+	return &FeedHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *FeedHandler) RegisterRouter(server *gin.Engine) {
+	server.POST("/feed", h.Feed)
+}
+
+type FeedReq struct {
+	Cursor int64 `json:"cursor"`
+	Limit  int   `json:"limit"`
+}
+
+type FeedVo struct {
+	Articles []articleBrief `json:"articles"`
+	// NextCursor 为 0 表示没有下一页了
+	NextCursor int64 `json:"next_cursor"`
+}
+
+func (h *FeedHandler) Feed(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req FeedReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Cursor < 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	feed, err := h.svc.Feed(ctx, uc.Uid, req.Cursor, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取关注流失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(FeedVo{
+		Articles:   toArticleBriefs(feed.Articles),
+		NextCursor: feed.NextCursor,
+	})
+}
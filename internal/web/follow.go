@@ -0,0 +1,158 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// FollowHandler 关注、取消关注和粉丝、关注列表
+type FollowHandler struct {
+	svc service.FollowService
+	l   logger.Logger
+}
+
+func NewFollowHandler(svc service.FollowService, l logger.Logger) *FollowHandler {
+	return &FollowHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *FollowHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/follow")
+	g.POST("/follow", h.Follow)
+	g.POST("/cancel", h.CancelFollow)
+	g.POST("/followers", h.Followers)
+	g.POST("/followees", h.Followees)
+}
+
+type FollowReq struct {
+	Followee int64 `json:"followee"`
+}
+
+func (h *FollowHandler) Follow(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req FollowReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Followee <= 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.Follow(ctx, uc.Uid, req.Followee)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrFollowSelf):
+		resp.SetGeneral(true, http.StatusBadRequest, "不能关注自己")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("关注失败", logger.Int64("follower", uc.Uid),
+			logger.Int64("followee", req.Followee), logger.Error(err))
+	}
+}
+
+func (h *FollowHandler) CancelFollow(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req FollowReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Followee <= 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.CancelFollow(ctx, uc.Uid, req.Followee)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("取消关注失败", logger.Int64("follower", uc.Uid),
+			logger.Int64("followee", req.Followee), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
+
+// FollowListReq uid 为 0 的时候看自己的，Cursor 是上一页返回的 next_cursor
+type FollowListReq struct {
+	Uid    int64 `json:"uid"`
+	Cursor int64 `json:"cursor"`
+	Limit  int   `json:"limit"`
+}
+
+type FollowUserVo struct {
+	Uid int64 `json:"uid"`
+	// Mutual 互相关注
+	Mutual bool  `json:"mutual"`
+	Ctime  int64 `json:"ctime"`
+}
+
+type FollowListVo struct {
+	Users []FollowUserVo `json:"users"`
+	// NextCursor 为 0 表示没有下一页了
+	NextCursor int64 `json:"next_cursor"`
+}
+
+func (h *FollowHandler) Followers(ctx *gin.Context) {
+	h.list(ctx, "获取粉丝列表失败", h.svc.Followers, func(r domain.FollowRelation) int64 {
+		return r.Follower
+	})
+}
+
+func (h *FollowHandler) Followees(ctx *gin.Context) {
+	h.list(ctx, "获取关注列表失败", h.svc.Followees, func(r domain.FollowRelation) int64 {
+		return r.Followee
+	})
+}
+
+func (h *FollowHandler) list(ctx *gin.Context, msg string,
+	find func(ctx context.Context, uid int64, cursor int64, limit int) ([]domain.FollowRelation, error),
+	other func(r domain.FollowRelation) int64) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req FollowListReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Cursor < 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Uid == 0 {
+		req.Uid = ctx.MustGet("user").(ijwt.UserClaims).Uid
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	rs, err := find(ctx, req.Uid, req.Cursor, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error(msg, logger.Int64("uid", req.Uid), logger.Error(err))
+		return
+	}
+	vo := FollowListVo{Users: make([]FollowUserVo, 0, len(rs))}
+	for _, r := range rs {
+		vo.Users = append(vo.Users, FollowUserVo{
+			Uid:    other(r),
+			Mutual: r.Mutual,
+			Ctime:  r.Ctime,
+		})
+	}
+	// 不满一页说明已经到头了
+	if len(rs) == req.Limit {
+		vo.NextCursor = rs[len(rs)-1].Id
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(vo)
+}
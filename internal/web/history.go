@@ -0,0 +1,205 @@
+package web
+
+import (
+	"errors"
+	"github.com/ecodeclub/ekit/slice"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// HistoryHandler 阅读历史和继续阅读
+type HistoryHandler struct {
+	svc service.HistoryService
+	l   logger.Logger
+}
+
+func NewHistoryHandler(svc service.HistoryService, l logger.Logger) *HistoryHandler {
+	return &HistoryHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *HistoryHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/history")
+	g.POST("/progress", h.ReportProgress)
+	g.POST("/list", h.List)
+	g.GET("/continue", h.Continue)
+	g.POST("/delete", h.Delete)
+	g.GET("/settings", h.Settings)
+	g.POST("/settings", h.SetPaused)
+}
+
+type ProgressReq struct {
+	ArtId int64 `json:"art_id"`
+	// Progress 滚动到了百分之多少，0 到 100
+	Progress uint8 `json:"progress"`
+}
+
+// ReportProgress 客户端滚动的时候节流上报
+func (h *HistoryHandler) ReportProgress(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req ProgressReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.ReportProgress(ctx, uc.Uid, req.ArtId, req.Progress)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrInvalidProgress):
+		resp.SetGeneral(true, http.StatusBadRequest, "进度只能是 0 到 100")
+	case errors.Is(err, service.ErrArticleNotFound):
+		resp.SetGeneral(true, http.StatusNotFound, "文章不存在")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("上报阅读进度失败", logger.Int64("uid", uc.Uid),
+			logger.Int64("artId", req.ArtId), logger.Error(err))
+	}
+}
+
+type HistoryListReq struct {
+	Offset int `json:"offset"`
+	Limit  int `json:"limit"`
+}
+
+type HistoryVo struct {
+	ArtId    int64  `json:"art_id"`
+	Title    string `json:"title"`
+	AuthorId int64  `json:"author_id"`
+	Progress uint8  `json:"progress"`
+	// ReadTime 最近一次阅读的时间
+	ReadTime int64 `json:"read_time"`
+	// Available 为 false 表示文章已经撤回了
+	Available bool `json:"available"`
+}
+
+func (h *HistoryHandler) List(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req HistoryListReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Offset < 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 || req.Limit > 100 {
+		req.Limit = 20
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	hs, err := h.svc.List(ctx, uc.Uid, req.Offset, req.Limit)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取阅读历史失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(toHistoryVos(hs))
+}
+
+// Continue 继续阅读，最近没有读完的几篇
+func (h *HistoryHandler) Continue(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	hs, err := h.svc.Continue(ctx, uc.Uid, 10)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取继续阅读失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(toHistoryVos(hs))
+}
+
+func toHistoryVos(hs []domain.ReadHistory) []HistoryVo {
+	return slice.Map[domain.ReadHistory, HistoryVo](hs, func(idx int, src domain.ReadHistory) HistoryVo {
+		return HistoryVo{
+			ArtId:     src.ArtId,
+			Title:     src.Article.Title,
+			AuthorId:  src.Article.Author.Id,
+			Progress:  src.Progress,
+			ReadTime:  src.Utime,
+			Available: src.Article.Id > 0,
+		}
+	})
+}
+
+// HistoryDeleteReq ArtIds 为空的时候清空
+type HistoryDeleteReq struct {
+	ArtIds []int64 `json:"art_ids"`
+}
+
+func (h *HistoryHandler) Delete(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req HistoryDeleteReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || len(req.ArtIds) > 100 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.Delete(ctx, uc.Uid, req.ArtIds)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("删除阅读历史失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
+
+type HistorySettingVo struct {
+	// Paused 暂停以后不再记录阅读历史和进度
+	Paused bool `json:"paused"`
+}
+
+func (h *HistoryHandler) Settings(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	paused, err := h.svc.Paused(ctx, uc.Uid)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取阅读历史设置失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(HistorySettingVo{Paused: paused})
+}
+
+func (h *HistoryHandler) SetPaused(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req HistorySettingVo
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.SetPaused(ctx, uc.Uid, req.Paused)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("修改阅读历史设置失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
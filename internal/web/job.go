@@ -0,0 +1,102 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// JobHandler 管理数据库里面的定时任务，只有管理员可以用
+type JobHandler struct {
+	svc    service.JobService
+	admins map[int64]struct{}
+	l      logger.Logger
+}
+
+func NewJobHandler(svc service.JobService, admins []int64, l logger.Logger) *JobHandler {
+	m := make(map[int64]struct{}, len(admins))
+	for _, uid := range admins {
+		m[uid] = struct{}{}
+	}
+	return &JobHandler{
+		svc:    svc,
+		admins: m,
+		l:      l,
+	}
+}
+
+func (h *JobHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/admin/jobs", h.checkAdmin)
+	g.POST("/list", h.List)
+	g.POST("/pause", h.Pause)
+	g.POST("/resume", h.Resume)
+	g.POST("/trigger", h.Trigger)
+}
+
+func (h *JobHandler) checkAdmin(ctx *gin.Context) {
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	if _, ok := h.admins[uc.Uid]; !ok {
+		ctx.AbortWithStatus(http.StatusForbidden)
+	}
+}
+
+func (h *JobHandler) List(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	jobs, err := h.svc.List(ctx)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取任务列表失败", logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(jobs)
+}
+
+type JobReq struct {
+	Id int64 `json:"id"`
+}
+
+func (h *JobHandler) Pause(ctx *gin.Context) {
+	h.update(ctx, "暂停任务失败", h.svc.Pause)
+}
+
+func (h *JobHandler) Resume(ctx *gin.Context) {
+	h.update(ctx, "恢复任务失败", h.svc.Resume)
+}
+
+func (h *JobHandler) Trigger(ctx *gin.Context) {
+	h.update(ctx, "触发任务失败", h.svc.Trigger)
+}
+
+func (h *JobHandler) update(ctx *gin.Context, msg string, fn func(ctx context.Context, id int64) error) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req JobReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	err := fn(ctx, req.Id)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrJobNotFound):
+		resp.SetGeneral(true, http.StatusNotFound, "任务不存在")
+	case errors.Is(err, service.ErrJobStatusChanged):
+		// 比如暂停一个已经暂停的任务，或者触发一个正在执行的任务
+		resp.SetGeneral(true, http.StatusConflict, "任务当前状态不允许该操作")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error(msg, logger.Int64("id", req.Id), logger.Error(err))
+	}
+}
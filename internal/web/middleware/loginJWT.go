@@ -4,6 +4,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"net/http"
+	"strings"
 	ijwt "webook/internal/web/jwt"
 )
 
@@ -25,7 +26,8 @@ func (m *LoginJWTMilddlewareBuilder) CheckLoginJWT() gin.HandlerFunc {
 			path == "/users/login_sms/code/send" ||
 			path == "/users/login_sms" ||
 			path == "/oauth2/wechat/authurl" ||
-			path == "/oauth2/wechat/callback" {
+			path == "/oauth2/wechat/callback" ||
+			strings.HasPrefix(path, "/s/") {
 			return
 		}
 		tokenStr := m.ExtractToken(ctx)
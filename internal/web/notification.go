@@ -0,0 +1,171 @@
+package web
+
+import (
+	"errors"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"webook/internal/domain"
+	"webook/internal/domain/proctocol"
+	"webook/internal/service"
+	ijwt "webook/internal/web/jwt"
+	"webook/pkg/logger"
+)
+
+// NotificationHandler 站内通知
+type NotificationHandler struct {
+	svc service.NotificationService
+	l   logger.Logger
+}
+
+func NewNotificationHandler(svc service.NotificationService, l logger.Logger) *NotificationHandler {
+	return &NotificationHandler{
+		svc: svc,
+		l:   l,
+	}
+}
+
+func (h *NotificationHandler) RegisterRouter(server *gin.Engine) {
+	g := server.Group("/notifications")
+	g.POST("/list", h.List)
+	g.GET("/unread", h.UnreadCnt)
+	g.POST("/read", h.MarkRead)
+	g.GET("/settings", h.Settings)
+	g.POST("/settings", h.SetEnabled)
+}
+
+type NotificationListReq struct {
+	Cursor     int64 `json:"cursor"`
+	Limit      int   `json:"limit"`
+	UnreadOnly bool  `json:"unread_only"`
+}
+
+type NotificationListVo struct {
+	Notifications []domain.Notification `json:"notifications"`
+	// NextCursor 为 0 表示没有下一页了
+	NextCursor int64 `json:"next_cursor"`
+}
+
+func (h *NotificationHandler) List(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req NotificationListReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || req.Cursor < 0 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	if req.Limit <= 0 {
+		req.Limit = 20
+	}
+	if req.Limit > 100 {
+		req.Limit = 100
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	ns, err := h.svc.List(ctx, uc.Uid, req.Cursor, req.Limit, req.UnreadOnly)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取通知列表失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	vo := NotificationListVo{Notifications: ns}
+	if len(ns) == req.Limit {
+		vo.NextCursor = ns[len(ns)-1].Id
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(vo)
+}
+
+type UnreadCntVo struct {
+	Total int64                             `json:"total"`
+	Cnts  map[domain.NotificationType]int64 `json:"cnts"`
+}
+
+func (h *NotificationHandler) UnreadCnt(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	cnts, err := h.svc.UnreadCnt(ctx, uc.Uid)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取未读数失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	vo := UnreadCntVo{Cnts: cnts}
+	for _, cnt := range cnts {
+		vo.Total += cnt
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(vo)
+}
+
+// MarkReadReq Ids 为空的时候全部标记成已读
+type MarkReadReq struct {
+	Ids []int64 `json:"ids"`
+}
+
+func (h *NotificationHandler) MarkRead(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req MarkReadReq
+	if err := ctx.ShouldBindJSON(&req); err != nil || len(req.Ids) > 100 {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.MarkRead(ctx, uc.Uid, req.Ids)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("标记已读失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
+
+func (h *NotificationHandler) Settings(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	settings, err := h.svc.Settings(ctx, uc.Uid)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("获取通知设置失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(settings)
+}
+
+type NotificationSettingReq struct {
+	Type    string `json:"type"`
+	Enabled bool   `json:"enabled"`
+}
+
+func (h *NotificationHandler) SetEnabled(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	var req NotificationSettingReq
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.SetEnabled(ctx, uc.Uid, domain.NotificationType(req.Type), req.Enabled)
+	switch {
+	case err == nil:
+		resp.SetGeneral(true, http.StatusOK, "ok")
+	case errors.Is(err, service.ErrInvalidNotificationType):
+		resp.SetGeneral(true, http.StatusBadRequest, "不支持的通知类型")
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统内部错误")
+		h.l.Error("修改通知设置失败", logger.Int64("uid", uc.Uid), logger.Error(err))
+	}
+}
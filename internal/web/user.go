@@ -6,7 +6,9 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
 	"net/http"
+	"strconv"
 	"time"
 	"webook/internal/domain"
 	"webook/internal/domain/proctocol"
@@ -32,15 +34,18 @@ type UserHandler struct {
 	passwordRegexExp *regexp.Regexp
 	svc              service.UserService
 	codeSvc          service.CodeService
+	followSvc        service.FollowService
 	ijwt.Handler
 }
 
-func NewUserHandler(svc service.UserService, codeSvc service.CodeService, hdl ijwt.Handler) *UserHandler {
+func NewUserHandler(svc service.UserService, codeSvc service.CodeService, followSvc service.FollowService,
+	hdl ijwt.Handler) *UserHandler {
 	return &UserHandler{
 		emailRegexExp:    regexp.MustCompile(emailRegexPattern, regexp.None),
 		passwordRegexExp: regexp.MustCompile(passwordRegexPattern, regexp.None),
 		svc:              svc,
 		codeSvc:          codeSvc,
+		followSvc:        followSvc,
 		Handler:          hdl,
 	}
 }
@@ -69,6 +74,8 @@ func (h *UserHandler) RegisterRouter(server *gin.Engine) {
 	ug.POST("/logout", h.LogoutJWT)
 	ug.POST("/edit", h.Edit)
 	ug.GET("/profile", h.Profile)
+	ug.GET("/public/:id", h.PublicProfile)
+	ug.POST("/privacy", h.Privacy)
 
 	ug.GET("/refresh_token", h.RefreshToken)
 	//触发发送验证码
@@ -221,20 +228,107 @@ func (h *UserHandler) Profile(ctx *gin.Context) {
 		Password        string `json:"password"`
 		ConfirmPassword string `json:"confirmPassword"`
 
-		Birthday time.Time `json:"birthday"`
-		AboutMe  string    `json:"aboutMe"`
+		Birthday    time.Time `json:"birthday"`
+		AboutMe     string    `json:"aboutMe"`
+		LikesPublic bool      `json:"likesPublic"`
 	}
 	resp.SetGeneral(true, http.StatusOK, "profile success")
 	resp.SetData(User{
-		Email:    u.Email,
-		Phone:    u.Phone,
-		Nickname: u.Nickname,
-		Password: u.Password,
-		Birthday: u.Birthday,
-		AboutMe:  u.AboutMe,
+		Email:       u.Email,
+		Phone:       u.Phone,
+		Nickname:    u.Nickname,
+		Password:    u.Password,
+		Birthday:    u.Birthday,
+		AboutMe:     u.AboutMe,
+		LikesPublic: u.LikesPublic,
 	})
 }
 
+// PublicProfile 别人看到的主页，不能带邮箱、手机号
+func (h *UserHandler) PublicProfile(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	uid, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	var (
+		eg     errgroup.Group
+		u      domain.User
+		follow domain.FollowStatistic
+	)
+	eg.Go(func() error {
+		var er error
+		u, er = h.svc.FindById(ctx, uid)
+		return er
+	})
+	eg.Go(func() error {
+		var er error
+		follow, er = h.followSvc.Statistic(ctx, uc.Uid, uid)
+		return er
+	})
+	err = eg.Wait()
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrUserNotFound):
+		resp.SetGeneral(true, http.StatusNotFound, "用户不存在")
+		return
+	default:
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统错误")
+		zap.L().Error("获取用户主页失败", zap.Int64("uid", uid), zap.Error(err))
+		return
+	}
+	type User struct {
+		Id       int64  `json:"id"`
+		Nickname string `json:"nickname"`
+		AboutMe  string `json:"aboutMe"`
+
+		Followers  int64 `json:"followers"`
+		Followees  int64 `json:"followees"`
+		Followed   bool  `json:"followed"`
+		FollowedBy bool  `json:"followedBy"`
+		Mutual     bool  `json:"mutual"`
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+	resp.SetData(User{
+		Id:         u.Id,
+		Nickname:   u.Nickname,
+		AboutMe:    u.AboutMe,
+		Followers:  follow.Followers,
+		Followees:  follow.Followees,
+		Followed:   follow.Followed,
+		FollowedBy: follow.FollowedBy,
+		Mutual:     follow.Mutual(),
+	})
+}
+
+// Privacy 隐私设置，目前只有点赞记录是否公开
+func (h *UserHandler) Privacy(ctx *gin.Context) {
+	resp := proctocol.RespGeneral{}
+	defer func() {
+		ctx.JSON(http.StatusOK, resp)
+	}()
+	type Req struct {
+		LikesPublic bool `json:"likesPublic"`
+	}
+	var req Req
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		resp.SetGeneral(true, http.StatusBadRequest, "参数错误")
+		return
+	}
+	uc := ctx.MustGet("user").(ijwt.UserClaims)
+	err := h.svc.UpdatePrivacy(ctx, uc.Uid, req.LikesPublic)
+	if err != nil {
+		resp.SetGeneral(true, http.StatusInternalServerError, "系统错误")
+		return
+	}
+	resp.SetGeneral(true, http.StatusOK, "ok")
+}
+
 func (h *UserHandler) LoginJWT(ctx *gin.Context) {
 	type Req struct {
 		Email    string `json:"email"`
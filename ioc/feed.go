@@ -0,0 +1,57 @@
+package ioc
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/repository"
+	"webook/internal/repository/cache"
+	"webook/internal/service"
+	"webook/pkg/logger"
+)
+
+// InitFeedCache 收件箱、发件箱的容量，activeTTL 内没有看过关注流的用户不再推
+func InitFeedCache(cmd redis.Cmdable) cache.FeedCache {
+	type Config struct {
+		InboxSize  int           `yaml:"inboxSize"`
+		OutboxSize int           `yaml:"outboxSize"`
+		ActiveTTL  time.Duration `yaml:"activeTTL"`
+	}
+	c := cache.NewRedisFeedCache(cmd)
+	cfg := Config{
+		InboxSize:  c.InboxSize,
+		OutboxSize: c.OutboxSize,
+		ActiveTTL:  c.ActiveTTL,
+	}
+	err := viper.UnmarshalKey("feed", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	c.InboxSize = cfg.InboxSize
+	c.OutboxSize = cfg.OutboxSize
+	c.ActiveTTL = cfg.ActiveTTL
+	return c
+}
+
+func InitFeedService(repo repository.FeedRepository, followRepo repository.FollowRepository,
+	artRepo repository.ArticleRepository, l logger.Logger) service.FeedService {
+	type Config struct {
+		PushThreshold    int64 `yaml:"pushThreshold"`
+		BatchSize        int   `yaml:"batchSize"`
+		RebuildFollowees int   `yaml:"rebuildFollowees"`
+	}
+	svc := service.NewPushPullFeedService(repo, followRepo, artRepo, l)
+	cfg := Config{
+		PushThreshold:    svc.PushThreshold,
+		BatchSize:        svc.BatchSize,
+		RebuildFollowees: svc.RebuildFollowees,
+	}
+	err := viper.UnmarshalKey("feed", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	svc.PushThreshold = cfg.PushThreshold
+	svc.BatchSize = cfg.BatchSize
+	svc.RebuildFollowees = cfg.RebuildFollowees
+	return svc
+}
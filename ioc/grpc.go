@@ -0,0 +1,57 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	intrv1 "webook/api/proto/gen/intr/v1"
+	"webook/internal/domain"
+	"webook/internal/domain/events/notification"
+	igrpc "webook/internal/grpc"
+	"webook/internal/repository"
+	"webook/internal/service"
+	"webook/pkg/grpcx"
+	"webook/pkg/logger"
+)
+
+// InitGRPCServer 互动服务单独部署的时候用
+func InitGRPCServer(intrSvr *igrpc.InteractiveServiceServer) *grpcx.Server {
+	type Config struct {
+		Addr string `yaml:"addr"`
+	}
+	cfg := Config{
+		Addr: ":8090",
+	}
+	err := viper.UnmarshalKey("grpc.server", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	server := grpc.NewServer()
+	intrSvr.Register(server)
+	return &grpcx.Server{
+		Server: server,
+		Addr:   cfg.Addr,
+	}
+}
+
+// InitInteractiveService 根据配置决定用本地的互动服务还是远程的 gRPC 服务
+func InitInteractiveService(repo repository.InteractiveRepository, reactions domain.ReactionSet,
+	producer notification.Producer, l logger.Logger) service.InteractiveService {
+	type Config struct {
+		Remote bool   `yaml:"remote"`
+		Addr   string `yaml:"addr"`
+	}
+	var cfg Config
+	err := viper.UnmarshalKey("grpc.client.intr", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if !cfg.Remote {
+		return service.NewInteractiveService(repo, reactions, producer, l)
+	}
+	cc, err := grpc.Dial(cfg.Addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(err)
+	}
+	return igrpc.NewInteractiveClient(intrv1.NewInteractiveServiceClient(cc))
+}
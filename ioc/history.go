@@ -0,0 +1,28 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/repository"
+	"webook/internal/service"
+)
+
+// InitHistoryService 进度到了 finishedProgress 算读完，继续阅读只看 continueWindow 之内读过的
+func InitHistoryService(repo repository.HistoryRepository, artRepo repository.ArticleRepository) service.HistoryService {
+	type Config struct {
+		FinishedProgress uint8         `yaml:"finishedProgress"`
+		ContinueWindow   time.Duration `yaml:"continueWindow"`
+	}
+	svc := service.NewDBHistoryService(repo, artRepo)
+	cfg := Config{
+		FinishedProgress: svc.FinishedProgress,
+		ContinueWindow:   svc.ContinueWindow,
+	}
+	err := viper.UnmarshalKey("history", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	svc.FinishedProgress = cfg.FinishedProgress
+	svc.ContinueWindow = cfg.ContinueWindow
+	return svc
+}
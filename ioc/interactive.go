@@ -0,0 +1,28 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"webook/internal/domain"
+)
+
+// InitReactionSet 允许使用的表情，默认表情一定在里面并且放在第一个
+func InitReactionSet() domain.ReactionSet {
+	type Config struct {
+		Reactions []string `yaml:"reactions"`
+	}
+	cfg := Config{
+		Reactions: []string{domain.ReactionLike, "insightful", "funny"},
+	}
+	err := viper.UnmarshalKey("interactive", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	res := domain.ReactionSet{domain.ReactionLike}
+	for _, r := range cfg.Reactions {
+		if r == "" || res.Contains(r) {
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
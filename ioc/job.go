@@ -0,0 +1,169 @@
+package ioc
+
+import (
+	"context"
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/domain"
+	"webook/internal/job"
+	"webook/internal/repository"
+	"webook/internal/service"
+	"webook/internal/web"
+	"webook/pkg/logger"
+	"webook/pkg/rlock"
+)
+
+func InitReadCntFlusher(repo repository.InteractiveRepository, l logger.Logger) *job.ReadCntFlusher {
+	type Config struct {
+		// 待刷新的资源数达到 BatchSize 或者距离上次刷新超过 Interval 就刷
+		BatchSize int64         `yaml:"batchSize"`
+		Interval  time.Duration `yaml:"interval"`
+	}
+	f := job.NewReadCntFlusher(repo, l)
+	cfg := Config{
+		BatchSize: f.BatchSize,
+		Interval:  f.Interval,
+	}
+	err := viper.UnmarshalKey("interactive.readCnt", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	f.BatchSize = cfg.BatchSize
+	f.Interval = cfg.Interval
+	return f
+}
+
+// JobConfig 单个定时任务的配置，Preempt 为 true 的时候交给数据库调度，否则用 redis 锁选 leader 跑
+type JobConfig struct {
+	Spec    string        `yaml:"spec"`
+	Timeout time.Duration `yaml:"timeout"`
+	Preempt bool          `yaml:"preempt"`
+}
+
+// jobConfig 读 job.<name> 的配置，没有配置的用 def
+func jobConfig(name string, def JobConfig) JobConfig {
+	cfg := def
+	err := viper.UnmarshalKey("job."+name, &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cfg
+}
+
+func rankingJobConfig() JobConfig {
+	return jobConfig("ranking", JobConfig{
+		Spec:    "@every 3m",
+		Timeout: time.Minute,
+	})
+}
+
+func relatedJobConfig() JobConfig {
+	return jobConfig("related", JobConfig{
+		Spec:    "@every 1h",
+		Timeout: 10 * time.Minute,
+	})
+}
+
+type scheduledJob struct {
+	cfg JobConfig
+	job job.Job
+}
+
+// scheduledJobs 所有的定时任务，preempt 的交给数据库调度，其余的用 CronRunner 跑
+func scheduledJobs(rankSvc service.RankingService, relatedSvc service.RelatedService) []scheduledJob {
+	return []scheduledJob{
+		{cfg: rankingJobConfig(), job: job.NewRankingJob(rankSvc)},
+		{cfg: relatedJobConfig(), job: job.NewRelatedJob(relatedSvc)},
+	}
+}
+
+// InitCronRunner 注册所有的定时任务，多个实例只有抢到锁的那个会跑
+func InitCronRunner(client *rlock.Client, rankSvc service.RankingService,
+	relatedSvc service.RelatedService, l logger.Logger) *job.CronRunner {
+	r := job.NewCronRunner(client, l)
+	leaseTTL := viper.GetDuration("job.leaseTTL")
+	if leaseTTL > 0 {
+		r.LeaseTTL = leaseTTL
+	}
+	jobs := scheduledJobs(rankSvc, relatedSvc)
+	for _, j := range jobs {
+		if j.cfg.Preempt {
+			continue
+		}
+		err := r.AddJob(j.cfg.Spec, j.job, j.cfg.Timeout)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+func InitJobService(repo repository.JobRepository) service.JobService {
+	svc := service.NewPreemptJobService(repo)
+	// 心跳超过 heartbeatTimeout 没有更新就认为执行的实例挂了
+	timeout := viper.GetDuration("job.scheduler.heartbeatTimeout")
+	if timeout > 0 {
+		svc.Timeout = timeout
+	}
+	return svc
+}
+
+// InitScheduler 数据库调度，本地执行器里面注册所有可以调度的任务，配置了 preempt 的任务写到数据库里面
+func InitScheduler(svc service.JobService, rankSvc service.RankingService,
+	relatedSvc service.RelatedService, l logger.Logger) *job.Scheduler {
+	type Config struct {
+		Concurrency       int64         `yaml:"concurrency"`
+		Interval          time.Duration `yaml:"interval"`
+		HeartbeatInterval time.Duration `yaml:"heartbeatInterval"`
+		Timeout           time.Duration `yaml:"timeout"`
+	}
+	s := job.NewScheduler(svc, l)
+	cfg := Config{
+		Concurrency:       s.Concurrency,
+		Interval:          s.Interval,
+		HeartbeatInterval: s.HeartbeatInterval,
+		Timeout:           s.Timeout,
+	}
+	err := viper.UnmarshalKey("job.scheduler", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	s.Concurrency = cfg.Concurrency
+	s.Interval = cfg.Interval
+	s.HeartbeatInterval = cfg.HeartbeatInterval
+	s.Timeout = cfg.Timeout
+
+	local := job.NewLocalExecutor()
+	jobs := scheduledJobs(rankSvc, relatedSvc)
+	for _, j := range jobs {
+		local.Register(j.job)
+	}
+	s.RegisterExecutor(local)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, j := range jobs {
+		if !j.cfg.Preempt {
+			continue
+		}
+		err = svc.Register(ctx, domain.Job{
+			Name:       j.job.Name(),
+			Executor:   local.Name(),
+			Expression: j.cfg.Spec,
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+	return s
+}
+
+func InitJobHandler(svc service.JobService, l logger.Logger) *web.JobHandler {
+	// 可以管理任务的用户
+	var admins []int64
+	err := viper.UnmarshalKey("admin.uids", &admins)
+	if err != nil {
+		panic(err)
+	}
+	return web.NewJobHandler(svc, admins, l)
+}
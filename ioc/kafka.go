@@ -3,6 +3,12 @@ package ioc
 import (
 	"github.com/IBM/sarama"
 	"github.com/spf13/viper"
+	"webook/internal/domain/events"
+	"webook/internal/domain/events/article"
+	"webook/internal/domain/events/notification"
+	"webook/internal/repository"
+	"webook/pkg/logger"
+	"webook/pkg/saramax"
 )
 
 func InitSaramaClient() sarama.Client {
@@ -10,7 +16,7 @@ func InitSaramaClient() sarama.Client {
 		Addrs []string
 	}
 	var cfg Config
-	err := viper.UnmarshalKey("kafkakafka", &cfg)
+	err := viper.UnmarshalKey("kafka", &cfg)
 	if err != nil {
 		panic(err)
 	}
@@ -32,7 +38,38 @@ func InitSyncProducer(c sarama.Client) sarama.SyncProducer {
 	return p
 }
 
-//
-//func InitConsumers(c article.InteractiveReadEventConsumer) []events.Consumer {
-//	return []events.Consumer{c}
-//}
+// InitDeadLetter 所有消费者共用一个死信投递
+func InitDeadLetter(p sarama.SyncProducer) saramax.DeadLetter {
+	return saramax.NewSaramaDeadLetter(p)
+}
+
+func InitInteractiveReadEventConsumer(repo repository.InteractiveRepository, client sarama.Client,
+	dlq saramax.DeadLetter, l logger.Logger) *article.InteractiveReadEventConsumer {
+	return article.NewInteractiveReadEventConsumer(repo, client, l, consumerOptions("interactive", dlq)...)
+}
+
+func InitHistoryReadEventConsumer(repo repository.HistoryRepository, client sarama.Client,
+	dlq saramax.DeadLetter, l logger.Logger) *article.HistoryReadEventConsumer {
+	return article.NewHistoryReadEventConsumer(repo, client, l, consumerOptions("history", dlq)...)
+}
+
+func InitNotificationEventConsumer(repo repository.NotificationRepository, artRepo repository.ArticleRepository,
+	client sarama.Client, dlq saramax.DeadLetter, l logger.Logger) *notification.NotificationEventConsumer {
+	return notification.NewNotificationEventConsumer(repo, artRepo, client, l, consumerOptions("notification", dlq)...)
+}
+
+// consumerOptions 每个消费者组在 kafka.consumers.<group>.retry 下面单独配置重试，没配的字段用默认值
+func consumerOptions(group string, dlq saramax.DeadLetter) []saramax.Option {
+	cfg := saramax.DefaultRetryConfig()
+	err := viper.UnmarshalKey("kafka.consumers."+group+".retry", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return []saramax.Option{saramax.WithRetry(cfg), saramax.WithDeadLetter(dlq)}
+}
+
+func InitConsumers(intrConsumer *article.InteractiveReadEventConsumer,
+	notifyConsumer *notification.NotificationEventConsumer,
+	historyConsumer *article.HistoryReadEventConsumer) []events.Consumer {
+	return []events.Consumer{intrConsumer, notifyConsumer, historyConsumer}
+}
@@ -0,0 +1,38 @@
+package ioc
+
+import (
+	"fmt"
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/repository"
+	"webook/internal/service"
+)
+
+func InitRankingService(artSvc service.ArticleService, intrSvc service.InteractiveService,
+	repo repository.RankingRepository) service.RankingService {
+	type Config struct {
+		BatchSize int                  `yaml:"batchSize"`
+		N         int                  `yaml:"n"`
+		Window    time.Duration        `yaml:"window"`
+		Score     service.RankingScore `yaml:"score"`
+	}
+	svc := service.NewBatchRankingService(artSvc, intrSvc, repo)
+	cfg := Config{
+		BatchSize: svc.BatchSize,
+		N:         svc.N,
+		Window:    svc.Window,
+		Score:     svc.Score,
+	}
+	err := viper.UnmarshalKey("ranking", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	if cfg.N <= 0 {
+		panic(fmt.Errorf("ranking.n 必须大于 0，实际是 %d", cfg.N))
+	}
+	svc.BatchSize = cfg.BatchSize
+	svc.N = cfg.N
+	svc.Window = cfg.Window
+	svc.Score = cfg.Score
+	return svc
+}
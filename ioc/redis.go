@@ -1,7 +1,86 @@
 package ioc
 
-import "github.com/go-redis/redis/v8"
+import (
+	"context"
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/repository/cache"
+	"webook/pkg/cachex"
+	"webook/pkg/logger"
+)
 
 func InitRedis() redis.Cmdable {
 	return redis.NewClient(&redis.Options{Addr: "localhost:6379"})
 }
+
+// InitReaderCache 独立读者统计，同一个用户在 readDedupWindow 内重复阅读只算一次阅读数
+func InitReaderCache(cmd redis.Cmdable) cache.ReaderCache {
+	type Config struct {
+		ReadDedupWindow time.Duration `yaml:"readDedupWindow"`
+	}
+	cfg := Config{
+		ReadDedupWindow: 30 * time.Minute,
+	}
+	err := viper.UnmarshalKey("interactive", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cache.NewRedisReaderCache(cmd, cfg.ReadDedupWindow)
+}
+
+// InitInteractiveCache 开了 hotCache 的时候在 redis 前面加一层热点本地缓存
+func InitInteractiveCache(cmd redis.Cmdable, l logger.Logger) cache.InteractiveCache {
+	type Config struct {
+		Enabled   bool          `yaml:"enabled"`
+		Capacity  int           `yaml:"capacity"`
+		TTL       time.Duration `yaml:"ttl"`
+		Threshold int64         `yaml:"threshold"`
+		Window    time.Duration `yaml:"window"`
+	}
+	cfg := Config{
+		Capacity:  1000,
+		TTL:       3 * time.Second,
+		Threshold: 50,
+		Window:    time.Second,
+	}
+	err := viper.UnmarshalKey("interactive.hotCache", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	redisCache := cache.NewInteractiveCache(cmd)
+	if !cfg.Enabled {
+		return redisCache
+	}
+	c := cache.NewHotInteractiveCache(redisCache, cmd, cfg.Capacity, cfg.TTL)
+	c.Threshold = cfg.Threshold
+	c.Window = cfg.Window
+	client, ok := cmd.(redis.UniversalClient)
+	if !ok {
+		// 收不到别的实例的失效通知，只能靠 TTL
+		l.Warn("redis 客户端不支持订阅，热点缓存只依赖过期时间")
+		return c
+	}
+	go func() {
+		err := c.Subscribe(context.Background(), client)
+		if err != nil {
+			l.Error("订阅热点缓存失效通知失败", logger.Error(err))
+		}
+	}()
+	return c
+}
+
+// InitCacheAside 缓存没命中时合并请求，不存在的数据记 negativeTTL 的空值
+func InitCacheAside(cmd redis.Cmdable) *cachex.Aside {
+	type Config struct {
+		NegativeTTL time.Duration `yaml:"negativeTTL"`
+	}
+	cfg := Config{
+		NegativeTTL: 30 * time.Second,
+	}
+	err := viper.UnmarshalKey("cache", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return cachex.NewAside(cmd, cfg.NegativeTTL)
+}
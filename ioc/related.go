@@ -0,0 +1,41 @@
+package ioc
+
+import (
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/repository"
+	"webook/internal/service"
+	"webook/pkg/logger"
+)
+
+func InitRelatedService(repo repository.RelatedRepository, artRepo repository.ArticleRepository,
+	rankSvc service.RankingService, l logger.Logger) service.RelatedService {
+	type Config struct {
+		BatchSize  int                  `yaml:"batchSize"`
+		Window     time.Duration        `yaml:"window"`
+		N          int                  `yaml:"n"`
+		Candidates int                  `yaml:"candidates"`
+		Likers     int                  `yaml:"likers"`
+		Score      service.RelatedScore `yaml:"score"`
+	}
+	svc := service.NewPrecomputedRelatedService(repo, artRepo, rankSvc, l)
+	cfg := Config{
+		BatchSize:  svc.BatchSize,
+		Window:     svc.Window,
+		N:          svc.N,
+		Candidates: svc.Candidates,
+		Likers:     svc.Likers,
+		Score:      svc.Score,
+	}
+	err := viper.UnmarshalKey("related", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	svc.BatchSize = cfg.BatchSize
+	svc.Window = cfg.Window
+	svc.N = cfg.N
+	svc.Candidates = cfg.Candidates
+	svc.Likers = cfg.Likers
+	svc.Score = cfg.Score
+	return svc
+}
@@ -0,0 +1,25 @@
+package ioc
+
+import (
+	"github.com/bwmarrin/snowflake"
+	"github.com/spf13/viper"
+)
+
+func InitSnowflakeNode() *snowflake.Node {
+	type Config struct {
+		// 多实例部署的时候每个实例要配置不同的节点号
+		Node int64 `yaml:"node"`
+	}
+	var cfg Config = Config{
+		Node: 1,
+	}
+	err := viper.UnmarshalKey("snowflake", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	node, err := snowflake.NewNode(cfg.Node)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
@@ -0,0 +1,41 @@
+package ioc
+
+import (
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
+	"time"
+	"webook/internal/repository"
+	"webook/internal/repository/cache"
+)
+
+// InitTrendingCache 实时热度的时间桶，retention 要不小于能查的最大窗口
+func InitTrendingCache(cmd redis.Cmdable) cache.TrendingCache {
+	type Config struct {
+		BucketSize time.Duration `yaml:"bucketSize"`
+		Retention  time.Duration `yaml:"retention"`
+		MergeTTL   time.Duration `yaml:"mergeTTL"`
+	}
+	c := cache.NewRedisTrendingCache(cmd)
+	cfg := Config{
+		BucketSize: c.BucketSize,
+		Retention:  c.Retention,
+		MergeTTL:   c.MergeTTL,
+	}
+	err := viper.UnmarshalKey("trending", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	c.BucketSize = cfg.BucketSize
+	c.Retention = cfg.Retention
+	c.MergeTTL = cfg.MergeTTL
+	return c
+}
+
+func InitTrendingRepository(c cache.TrendingCache) repository.TrendingRepository {
+	repo := repository.NewCachedTrendingRepository(c)
+	err := viper.UnmarshalKey("trending.weights", &repo.Weights)
+	if err != nil {
+		panic(err)
+	}
+	return repo
+}
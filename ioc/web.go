@@ -3,6 +3,8 @@ package ioc
 import (
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/spf13/viper"
 	"strings"
 	"time"
 	"webook/internal/web"
@@ -10,17 +12,34 @@ import (
 	"webook/internal/web/middleware"
 	"webook/pkg/ginx/middleware/prometheus"
 	"webook/pkg/logger"
+	"webook/pkg/ratelimit"
 )
 
 func InitWebService(funcs []gin.HandlerFunc,
 	userHdl *web.UserHandler,
 	wechatHdl *web.OAuth2WechatHandler,
-	artHdl *web.ArticleHandler) *gin.Engine {
+	artHdl *web.ArticleHandler,
+	commentHdl *web.CommentHandler,
+	collectionHdl *web.CollectionHandler,
+	statsHdl *web.ArticleStatsHandler,
+	followHdl *web.FollowHandler,
+	feedHdl *web.FeedHandler,
+	notificationHdl *web.NotificationHandler,
+	historyHdl *web.HistoryHandler,
+	jobHdl *web.JobHandler) *gin.Engine {
 	server := gin.Default()
 	server.Use(funcs...)
 	userHdl.RegisterRouter(server)
 	wechatHdl.RegisterRouters(server)
 	artHdl.RegisterRouter(server)
+	commentHdl.RegisterRouter(server)
+	collectionHdl.RegisterRouter(server)
+	statsHdl.RegisterRouter(server)
+	followHdl.RegisterRouter(server)
+	feedHdl.RegisterRouter(server)
+	notificationHdl.RegisterRouter(server)
+	historyHdl.RegisterRouter(server)
+	jobHdl.RegisterRouter(server)
 	return server
 }
 
@@ -57,3 +76,27 @@ func InitGinMiddleware(hdl ijwt.Handler, l logger.Logger) []gin.HandlerFunc {
 		middleware.NewLoginJWTMilddlewareBuilder(hdl).CheckLoginJWT(),
 	}
 }
+
+// InitActionLimiter 点赞、收藏、评论的限流，默认每个用户一分钟 60 次，同一个资源一分钟 10 次
+func InitActionLimiter(cmd redis.Cmdable, l logger.Logger) *web.ActionLimiter {
+	type Limit struct {
+		Interval time.Duration `yaml:"interval"`
+		Rate     int           `yaml:"rate"`
+	}
+	type Config struct {
+		User Limit `yaml:"user"`
+		Item Limit `yaml:"item"`
+	}
+	cfg := Config{
+		User: Limit{Interval: time.Minute, Rate: 60},
+		Item: Limit{Interval: time.Minute, Rate: 10},
+	}
+	err := viper.UnmarshalKey("ratelimit.action", &cfg)
+	if err != nil {
+		panic(err)
+	}
+	return web.NewActionLimiter(
+		ratelimit.NewRedisSlidingWindowLimiter(cmd, cfg.User.Interval, cfg.User.Rate),
+		ratelimit.NewRedisSlidingWindowLimiter(cmd, cfg.Item.Interval, cfg.Item.Rate),
+		l)
+}
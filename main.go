@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"github.com/fsnotify/fsnotify"
 	"github.com/gin-gonic/gin"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -10,17 +12,60 @@ import (
 	"go.uber.org/zap"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
 func main() {
 	InitViperWatch()
 	initLogger()
 	initPrometheus()
-	server := InitWebServer()
+	app := InitApp()
+	for _, c := range app.consumers {
+		err := c.Start()
+		if err != nil {
+			panic(err)
+		}
+	}
+	app.readCntFlusher.Start()
+	app.cron.Start()
+	app.scheduler.Start()
+	server := app.server
 	server.GET("/hello", func(ctx *gin.Context) {
 		ctx.String(http.StatusOK, "hello")
 	})
-	server.Run(":8080")
+	srv := &http.Server{Addr: ":8080", Handler: server}
+	go func() {
+		err := srv.ListenAndServe()
+		if err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(err)
+		}
+	}()
+
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, os.Interrupt, syscall.SIGTERM)
+	<-ch
+	log.Println("开始退出")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+	// 先等正在处理的请求结束，再停后台任务
+	err := srv.Shutdown(ctx)
+	if err != nil {
+		log.Println("关闭 http 服务失败", err)
+	}
+	for _, c := range app.consumers {
+		err = c.Close()
+		if err != nil {
+			log.Println("关闭消费者失败", err)
+		}
+	}
+	app.scheduler.Close()
+	app.cron.Close()
+	// 最后把阅读数缓冲刷进数据库
+	app.readCntFlusher.Close()
+	log.Println("退出完成")
 }
 
 func initPrometheus() {
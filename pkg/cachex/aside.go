@@ -0,0 +1,99 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"golang.org/x/sync/singleflight"
+	"math/rand"
+	"time"
+)
+
+// Aside cache-aside 读的公共部分
+// 1. 缓存没命中的时候，同一个 key 同时只有一个请求去数据库，别的等它的结果
+// 2. 数据库里面没有的数据记一个短时间的空值，不存在的 id 不会一直打到数据库
+// 缓存本身的读写还是交给各自的 cache，这里只管流程
+type Aside struct {
+	cmd   redis.Cmdable
+	group singleflight.Group
+	// 空值的过期时间，新建数据的时候如果不能 Forget，最多这么久之后才能读到
+	negativeTTL time.Duration
+}
+
+func NewAside(cmd redis.Cmdable, negativeTTL time.Duration) *Aside {
+	return &Aside{
+		cmd:         cmd,
+		negativeTTL: negativeTTL,
+	}
+}
+
+// Query 一次读
+type Query[T any] struct {
+	// Key 用来合并请求和记录空值，不同业务之间不能重复
+	Key string
+	// Get 读缓存，返回 error 就认为没命中
+	Get func(ctx context.Context) (T, error)
+	// Load 读数据库
+	Load func(ctx context.Context) (T, error)
+	// Set 回写缓存，可以为 nil，比如 Load 自己决定要不要回写
+	Set func(ctx context.Context, val T) error
+	// NotFound Load 返回这个错误的时候记空值，为 nil 的时候不记
+	NotFound error
+}
+
+// Get 泛型方法只能写成函数
+// 合并之后用的是第一个请求的 ctx，它超时了等它的请求也会一起失败
+func Get[T any](ctx context.Context, a *Aside, q Query[T]) (T, error) {
+	val, err := q.Get(ctx)
+	if err == nil {
+		return val, nil
+	}
+	res, err, _ := a.group.Do(q.Key, func() (interface{}, error) {
+		return load(ctx, a, q)
+	})
+	val, _ = res.(T)
+	return val, err
+}
+
+func load[T any](ctx context.Context, a *Aside, q Query[T]) (T, error) {
+	var zero T
+	if q.NotFound != nil {
+		// 查空值失败就当没有，大不了去一次数据库
+		n, err := a.cmd.Exists(ctx, a.negativeKey(q.Key)).Result()
+		if err == nil && n > 0 {
+			return zero, q.NotFound
+		}
+	}
+	val, err := q.Load(ctx)
+	switch {
+	case err == nil:
+	case q.NotFound != nil && errors.Is(err, q.NotFound):
+		_ = a.cmd.Set(ctx, a.negativeKey(q.Key), "", Jitter(a.negativeTTL)).Err()
+		return zero, err
+	default:
+		// 数据库出错不记空值，可能会返回部分数据，比如文章有了作者没有
+		return val, err
+	}
+	if q.Set != nil {
+		// 回写失败不影响返回结果
+		_ = q.Set(ctx, val)
+	}
+	return val, nil
+}
+
+// Forget 删掉空值，数据刚创建出来的时候调用
+func (a *Aside) Forget(ctx context.Context, key string) error {
+	return a.cmd.Del(ctx, a.negativeKey(key)).Err()
+}
+
+func (a *Aside) negativeKey(key string) string {
+	return "cache:nil:" + key
+}
+
+// Jitter 过期时间随机加上最多十分之一，免得同一批写进去的缓存同时过期
+func Jitter(ttl time.Duration) time.Duration {
+	if ttl < 10 {
+		return ttl
+	}
+	return ttl + time.Duration(rand.Int63n(int64(ttl/10)))
+}
@@ -0,0 +1,192 @@
+package cachex
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+var errNotFound = errors.New("not found")
+
+func TestGet(t *testing.T) {
+	miss := func(ctx context.Context) (string, error) {
+		return "", errors.New("miss")
+	}
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		query   func(loads *int64) Query[string]
+		want    string
+		wantErr error
+		// 去数据库的次数
+		wantLoads int64
+	}{
+		{
+			name: "缓存命中",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismocks.NewMockCmdable(ctrl)
+			},
+			query: func(loads *int64) Query[string] {
+				return Query[string]{
+					Key: "k",
+					Get: func(ctx context.Context) (string, error) {
+						return "cached", nil
+					},
+				}
+			},
+			want: "cached",
+		},
+		{
+			name: "没命中回源并回写",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:k").Return(redis.NewIntResult(0, nil))
+				return cmd
+			},
+			query: func(loads *int64) Query[string] {
+				return Query[string]{
+					Key: "k",
+					Get: miss,
+					Load: func(ctx context.Context) (string, error) {
+						atomic.AddInt64(loads, 1)
+						return "db", nil
+					},
+					Set: func(ctx context.Context, val string) error {
+						assert.Equal(t, "db", val)
+						return errors.New("回写失败不影响结果")
+					},
+					NotFound: errNotFound,
+				}
+			},
+			want:      "db",
+			wantLoads: 1,
+		},
+		{
+			name: "不存在的记空值",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:k").Return(redis.NewIntResult(0, nil))
+				cmd.EXPECT().Set(gomock.Any(), "cache:nil:k", "", gomock.Any()).
+					Return(redis.NewStatusResult("OK", nil))
+				return cmd
+			},
+			query: func(loads *int64) Query[string] {
+				return Query[string]{
+					Key: "k",
+					Get: miss,
+					Load: func(ctx context.Context) (string, error) {
+						atomic.AddInt64(loads, 1)
+						return "", errNotFound
+					},
+					NotFound: errNotFound,
+				}
+			},
+			wantErr:   errNotFound,
+			wantLoads: 1,
+		},
+		{
+			name: "命中空值不回源",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Exists(gomock.Any(), "cache:nil:k").Return(redis.NewIntResult(1, nil))
+				return cmd
+			},
+			query: func(loads *int64) Query[string] {
+				return Query[string]{
+					Key: "k",
+					Get: miss,
+					Load: func(ctx context.Context) (string, error) {
+						atomic.AddInt64(loads, 1)
+						return "db", nil
+					},
+					NotFound: errNotFound,
+				}
+			},
+			wantErr: errNotFound,
+		},
+		{
+			name: "数据库出错不记空值",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				return redismocks.NewMockCmdable(ctrl)
+			},
+			query: func(loads *int64) Query[string] {
+				return Query[string]{
+					Key: "k",
+					Get: miss,
+					Load: func(ctx context.Context) (string, error) {
+						atomic.AddInt64(loads, 1)
+						return "", errors.New("db error")
+					},
+				}
+			},
+			wantErr:   errors.New("db error"),
+			wantLoads: 1,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			a := NewAside(tc.mock(ctrl), time.Second)
+			var loads int64
+			val, err := Get(context.Background(), a, tc.query(&loads))
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.want, val)
+			assert.Equal(t, tc.wantLoads, loads)
+		})
+	}
+}
+
+func TestGet_Coalesce(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	a := NewAside(redismocks.NewMockCmdable(ctrl), time.Second)
+	const n = 10
+	var (
+		misses int64
+		loads  int64
+		wg     sync.WaitGroup
+	)
+	q := Query[int]{
+		Key: "hot",
+		Get: func(ctx context.Context) (int, error) {
+			atomic.AddInt64(&misses, 1)
+			return 0, errors.New("miss")
+		},
+		Load: func(ctx context.Context) (int, error) {
+			atomic.AddInt64(&loads, 1)
+			// 等所有请求都没命中缓存，再多等一会让它们都进到 singleflight 里面
+			for atomic.LoadInt64(&misses) < n {
+				time.Sleep(time.Millisecond)
+			}
+			time.Sleep(time.Millisecond * 50)
+			return 42, nil
+		},
+	}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := Get(context.Background(), a, q)
+			assert.NoError(t, err)
+			assert.Equal(t, 42, val)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1), loads)
+}
+
+func TestJitter(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ttl := Jitter(time.Minute)
+		assert.GreaterOrEqual(t, ttl, time.Minute)
+		assert.Less(t, ttl, time.Minute+time.Second*6)
+	}
+}
@@ -0,0 +1,20 @@
+package grpcx
+
+import (
+	"google.golang.org/grpc"
+	"net"
+)
+
+type Server struct {
+	*grpc.Server
+	Addr string
+}
+
+// Serve 监听 Addr，阻塞直到 Stop 或者 GracefulStop
+func (s *Server) Serve() error {
+	l, err := net.Listen("tcp", s.Addr)
+	if err != nil {
+		return err
+	}
+	return s.Server.Serve(l)
+}
@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"context"
+	_ "embed"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"time"
+)
+
+//go:embed slide_window.lua
+var luaSlideWindow string
+
+// RedisSlidingWindowLimiter 基于 redis 的滑动窗口限流，多个实例共享限额
+type RedisSlidingWindowLimiter struct {
+	cmd redis.Cmdable
+	// 窗口大小
+	interval time.Duration
+	// 窗口内允许的请求数
+	rate int
+	now  func() time.Time
+}
+
+func NewRedisSlidingWindowLimiter(cmd redis.Cmdable, interval time.Duration, rate int) Limiter {
+	return &RedisSlidingWindowLimiter{
+		cmd:      cmd,
+		interval: interval,
+		rate:     rate,
+		now:      time.Now,
+	}
+}
+
+func (r *RedisSlidingWindowLimiter) Limit(ctx context.Context, key string) (bool, error) {
+	// 同一毫秒可能有多个请求，member 不能只用时间
+	return r.cmd.Eval(ctx, luaSlideWindow, []string{key},
+		r.interval.Milliseconds(), r.rate, r.now().UnixMilli(), uuid.New().String()).Bool()
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestRedisSlidingWindowLimiter_Limit(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	testCases := []struct {
+		name        string
+		mock        func(ctrl *gomock.Controller) redis.Cmdable
+		wantLimited bool
+		wantErr     error
+	}{
+		{
+			name: "没有超过限额",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaSlideWindow, []string{"limit:1"},
+					int64(60000), 10, now.UnixMilli(), gomock.Any()).
+					Return(redis.NewCmdResult("false", nil))
+				return cmd
+			},
+		},
+		{
+			name: "限流",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaSlideWindow, []string{"limit:1"},
+					int64(60000), 10, now.UnixMilli(), gomock.Any()).
+					Return(redis.NewCmdResult("true", nil))
+				return cmd
+			},
+			wantLimited: true,
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaSlideWindow, []string{"limit:1"},
+					gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(redis.NewCmdResult(nil, errors.New("redis error")))
+				return cmd
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			l := NewRedisSlidingWindowLimiter(tc.mock(ctrl), time.Minute, 10).(*RedisSlidingWindowLimiter)
+			l.now = func() time.Time {
+				return now
+			}
+			limited, err := l.Limit(context.Background(), "limit:1")
+			assert.Equal(t, tc.wantErr, err)
+			assert.Equal(t, tc.wantLimited, limited)
+		})
+	}
+}
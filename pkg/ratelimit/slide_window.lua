@@ -0,0 +1,19 @@
+-- 滑动窗口限流，窗口内的请求记在 ZSET 里面，score 是请求的时间
+local key = KEYS[1]
+-- 窗口大小 毫秒
+local window = tonumber(ARGV[1])
+-- 窗口内允许的请求数
+local threshold = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local member = ARGV[4]
+
+-- 删掉窗口外面的
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+local cnt = redis.call('ZCOUNT', key, '-inf', '+inf')
+if cnt >= threshold then
+    -- 限流，这次请求不记
+    return "true"
+end
+redis.call('ZADD', key, now, member)
+redis.call('PEXPIRE', key, window)
+return "false"
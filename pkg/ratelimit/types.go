@@ -0,0 +1,8 @@
+package ratelimit
+
+import "context"
+
+type Limiter interface {
+	// Limit 返回 true 表示要限流，key 是限流的对象，比如用户、用户加资源
+	Limit(ctx context.Context, key string) (bool, error)
+}
@@ -0,0 +1,10 @@
+-- 没有人持有锁，或者是自己持有（上一次加锁超时但其实成功了），都算加锁成功
+local val = redis.call('get', KEYS[1])
+if val == false then
+    return redis.call('set', KEYS[1], ARGV[1], 'PX', ARGV[2])
+elseif val == ARGV[1] then
+    redis.call('pexpire', KEYS[1], ARGV[2])
+    return 'OK'
+else
+    return ''
+end
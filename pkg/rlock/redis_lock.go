@@ -0,0 +1,126 @@
+package rlock
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+	"sync"
+	"time"
+)
+
+var (
+	//go:embed lock.lua
+	luaLock string
+	//go:embed refresh.lua
+	luaRefresh string
+	//go:embed unlock.lua
+	luaUnlock string
+
+	// ErrFailedToPreemptLock 锁被别人持有
+	ErrFailedToPreemptLock = errors.New("rlock: 抢锁失败")
+	// ErrLockNotHold 锁已经过期或者被别人抢走了
+	ErrLockNotHold = errors.New("rlock: 未持有锁")
+)
+
+// Client 基于 redis 的分布式锁，value 是每次加锁生成的 uuid，保证只有持有者能续约和释放
+type Client struct {
+	cmd redis.Cmdable
+}
+
+func NewClient(cmd redis.Cmdable) *Client {
+	return &Client{
+		cmd: cmd,
+	}
+}
+
+// TryLock 尝试加锁一次，锁被别人持有的时候返回 ErrFailedToPreemptLock
+func (c *Client) TryLock(ctx context.Context, key string, expiration time.Duration) (*Lock, error) {
+	val := uuid.New().String()
+	res, err := c.cmd.Eval(ctx, luaLock, []string{key}, val, expiration.Milliseconds()).Text()
+	if err != nil {
+		return nil, err
+	}
+	if res != "OK" {
+		return nil, ErrFailedToPreemptLock
+	}
+	return newLock(c.cmd, key, val, expiration), nil
+}
+
+type Lock struct {
+	cmd        redis.Cmdable
+	key        string
+	value      string
+	expiration time.Duration
+
+	unlock     chan struct{}
+	unlockOnce sync.Once
+}
+
+func newLock(cmd redis.Cmdable, key, value string, expiration time.Duration) *Lock {
+	return &Lock{
+		cmd:        cmd,
+		key:        key,
+		value:      value,
+		expiration: expiration,
+		unlock:     make(chan struct{}),
+	}
+}
+
+func (l *Lock) Key() string {
+	return l.key
+}
+
+// Refresh 续约一次，把过期时间重置为加锁时的 expiration
+func (l *Lock) Refresh(ctx context.Context) error {
+	res, err := l.cmd.Eval(ctx, luaRefresh, []string{l.key}, l.value, l.expiration.Milliseconds()).Int64()
+	if err != nil {
+		return err
+	}
+	if res != 1 {
+		return ErrLockNotHold
+	}
+	return nil
+}
+
+// AutoRefresh 每隔 interval 续约一次，直到 Unlock 或者续约失败
+// 续约超时会立刻重试，其它错误直接返回，调用方要认为锁已经丢了
+func (l *Lock) AutoRefresh(interval time.Duration, timeout time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	retry := make(chan struct{}, 1)
+	for {
+		select {
+		case <-ticker.C:
+		case <-retry:
+		case <-l.unlock:
+			return nil
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := l.Refresh(ctx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			retry <- struct{}{}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Unlock 释放锁，同时停止 AutoRefresh
+func (l *Lock) Unlock(ctx context.Context) error {
+	l.unlockOnce.Do(func() {
+		close(l.unlock)
+	})
+	res, err := l.cmd.Eval(ctx, luaUnlock, []string{l.key}, l.value).Int64()
+	if err != nil {
+		return err
+	}
+	if res != 1 {
+		return ErrLockNotHold
+	}
+	return nil
+}
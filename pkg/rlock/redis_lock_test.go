@@ -0,0 +1,116 @@
+package rlock
+
+import (
+	"context"
+	"errors"
+	"github.com/go-redis/redis/v8"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"testing"
+	"time"
+	redismocks "webook/internal/repository/cache/rediscache"
+)
+
+func TestClient_TryLock(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		wantErr error
+	}{
+		{
+			name: "加锁成功",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:1"}, gomock.Any(), int64(60000)).
+					Return(redis.NewCmdResult("OK", nil))
+				return cmd
+			},
+		},
+		{
+			name: "锁被别人持有",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:1"}, gomock.Any(), int64(60000)).
+					Return(redis.NewCmdResult("", nil))
+				return cmd
+			},
+			wantErr: ErrFailedToPreemptLock,
+		},
+		{
+			name: "redis返回error",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaLock, []string{"lock:1"}, gomock.Any(), int64(60000)).
+					Return(redis.NewCmdResult(nil, errors.New("redis error")))
+				return cmd
+			},
+			wantErr: errors.New("redis error"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			c := NewClient(tc.mock(ctrl))
+			l, err := c.TryLock(context.Background(), "lock:1", time.Minute)
+			assert.Equal(t, tc.wantErr, err)
+			if err != nil {
+				return
+			}
+			assert.Equal(t, "lock:1", l.Key())
+			assert.NotEmpty(t, l.value)
+		})
+	}
+}
+
+func TestLock_AutoRefresh(t *testing.T) {
+	testCases := []struct {
+		name    string
+		mock    func(ctrl *gomock.Controller) redis.Cmdable
+		unlock  bool
+		wantErr error
+	}{
+		{
+			name: "续约超时重试，然后锁被抢走",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				first := cmd.EXPECT().Eval(gomock.Any(), luaRefresh, []string{"lock:1"}, "val", int64(60000)).
+					Return(redis.NewCmdResult(nil, context.DeadlineExceeded))
+				cmd.EXPECT().Eval(gomock.Any(), luaRefresh, []string{"lock:1"}, "val", int64(60000)).
+					Return(redis.NewCmdResult(int64(0), nil)).After(first)
+				return cmd
+			},
+			wantErr: ErrLockNotHold,
+		},
+		{
+			name: "解锁之后停止续约",
+			mock: func(ctrl *gomock.Controller) redis.Cmdable {
+				cmd := redismocks.NewMockCmdable(ctrl)
+				cmd.EXPECT().Eval(gomock.Any(), luaRefresh, []string{"lock:1"}, "val", int64(60000)).
+					Return(redis.NewCmdResult(int64(1), nil)).AnyTimes()
+				cmd.EXPECT().Eval(gomock.Any(), luaUnlock, []string{"lock:1"}, "val").
+					Return(redis.NewCmdResult(int64(1), nil))
+				return cmd
+			},
+			unlock: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			l := newLock(tc.mock(ctrl), "lock:1", "val", time.Minute)
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				if tc.unlock {
+					time.Sleep(50 * time.Millisecond)
+					assert.NoError(t, l.Unlock(context.Background()))
+				}
+			}()
+			err := l.AutoRefresh(10*time.Millisecond, time.Second)
+			assert.Equal(t, tc.wantErr, err)
+			<-done
+		})
+	}
+}
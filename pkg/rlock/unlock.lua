@@ -0,0 +1,6 @@
+-- 只有持有锁的人才能释放锁
+if redis.call('get', KEYS[1]) == ARGV[1] then
+    return redis.call('del', KEYS[1])
+else
+    return 0
+end
@@ -0,0 +1,107 @@
+package saramax
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/IBM/sarama"
+	"time"
+	"webook/pkg/logger"
+)
+
+// BatchHandler 攒够 BatchSize 条消息或者等了 BatchDuration 之后交给业务批量处理
+// 业务处理成功或者整批投递到死信之后才提交这一批的偏移量
+// 会话结束的时候没有提交的消息会在 rebalance 之后重新消费
+type BatchHandler[T any] struct {
+	fn  func(msgs []*sarama.ConsumerMessage, events []T) error
+	l   logger.Logger
+	cfg handlerConfig
+
+	BatchSize     int
+	BatchDuration time.Duration
+}
+
+func NewBatchHandler[T any](fn func(msgs []*sarama.ConsumerMessage, events []T) error, l logger.Logger,
+	opts ...Option) *BatchHandler[T] {
+	return &BatchHandler[T]{
+		fn:            fn,
+		l:             l,
+		cfg:           newHandlerConfig(opts),
+		BatchSize:     100,
+		BatchDuration: time.Second,
+	}
+}
+
+func (b *BatchHandler[T]) Setup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (b *BatchHandler[T]) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (b *BatchHandler[T]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	msgsCh := claim.Messages()
+	for {
+		msgs, closed := b.collect(session.Context(), msgsCh)
+		if len(msgs) > 0 && !b.handle(session, msgs) {
+			// 会话结束了，这一批不提交
+			return nil
+		}
+		if closed {
+			return nil
+		}
+	}
+}
+
+// collect 攒一批消息，第二个返回值表示消息通道已经关闭或者会话已经结束
+func (b *BatchHandler[T]) collect(ctx context.Context, msgsCh <-chan *sarama.ConsumerMessage) ([]*sarama.ConsumerMessage, bool) {
+	timer := time.NewTimer(b.BatchDuration)
+	defer timer.Stop()
+	msgs := make([]*sarama.ConsumerMessage, 0, b.BatchSize)
+	for len(msgs) < b.BatchSize {
+		select {
+		case <-timer.C:
+			return msgs, false
+		case <-ctx.Done():
+			return msgs, true
+		case msg, ok := <-msgsCh:
+			if !ok {
+				return msgs, true
+			}
+			msgs = append(msgs, msg)
+		}
+	}
+	return msgs, false
+}
+
+// handle 处理一批消息，成功之后提交偏移量，返回 false 说明会话已经结束
+func (b *BatchHandler[T]) handle(session sarama.ConsumerGroupSession, msgs []*sarama.ConsumerMessage) bool {
+	validMsgs := make([]*sarama.ConsumerMessage, 0, len(msgs))
+	events := make([]T, 0, len(msgs))
+	for _, msg := range msgs {
+		var t T
+		err := json.Unmarshal(msg.Value, &t)
+		if err != nil {
+			// 格式不对的消息重试也没用，有死信就投递，没有就跳过
+			b.l.Error("json unmarshal error",
+				logger.String("topic", msg.Topic),
+				logger.Int32("partition", msg.Partition),
+				logger.Int64("offset", msg.Offset),
+				logger.Error(err))
+			if b.cfg.dlq != nil && !b.cfg.deadLetter(session.Context(), b.l, []*sarama.ConsumerMessage{msg}, err, 0) {
+				return false
+			}
+			continue
+		}
+		validMsgs = append(validMsgs, msg)
+		events = append(events, t)
+	}
+	if len(events) > 0 && !b.cfg.process(session.Context(), b.l, validMsgs, func() error {
+		return b.fn(validMsgs, events)
+	}) {
+		return false
+	}
+	// 同一个分区按顺序提交，标记最后一条就可以
+	session.MarkMessage(msgs[len(msgs)-1], "")
+	return true
+}
@@ -0,0 +1,141 @@
+package saramax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"webook/pkg/logger"
+)
+
+type testEvent struct {
+	Id int64 `json:"id"`
+}
+
+func TestBatchHandler_ConsumeClaim(t *testing.T) {
+	testCases := []struct {
+		name string
+		msgs []*sarama.ConsumerMessage
+		// 每次调用业务函数返回的错误
+		errs      []error
+		batchSize int
+		// 期望业务函数收到的批次
+		wantBatches [][]int64
+		wantMarked  []int64
+	}{
+		{
+			name:        "按数量分批",
+			msgs:        testMsgs(t, 1, 2, 3, 4, 5),
+			batchSize:   2,
+			wantBatches: [][]int64{{1, 2}, {3, 4}, {5}},
+			wantMarked:  []int64{1, 3, 4},
+		},
+		{
+			name:        "失败之后重试同一批",
+			msgs:        testMsgs(t, 1, 2),
+			errs:        []error{errors.New("db error")},
+			batchSize:   2,
+			wantBatches: [][]int64{{1, 2}, {1, 2}},
+			wantMarked:  []int64{1},
+		},
+		{
+			name: "格式不对的消息跳过",
+			msgs: append(testMsgs(t, 1),
+				&sarama.ConsumerMessage{Value: []byte("bad"), Offset: 1},
+			),
+			batchSize:   2,
+			wantBatches: [][]int64{{1}},
+			wantMarked:  []int64{1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var batches [][]int64
+			calls := 0
+			h := NewBatchHandler[testEvent](func(msgs []*sarama.ConsumerMessage, events []testEvent) error {
+				ids := make([]int64, 0, len(events))
+				for _, evt := range events {
+					ids = append(ids, evt.Id)
+				}
+				batches = append(batches, ids)
+				calls++
+				if calls <= len(tc.errs) {
+					return tc.errs[calls-1]
+				}
+				return nil
+			}, logger.NewNopLogger(), WithRetry(RetryConfig{
+				MaxRetries: 3,
+				Initial:    time.Millisecond,
+				Max:        time.Millisecond,
+			}))
+			h.BatchSize = tc.batchSize
+			h.BatchDuration = 50 * time.Millisecond
+
+			ch := make(chan *sarama.ConsumerMessage, len(tc.msgs))
+			for _, msg := range tc.msgs {
+				ch <- msg
+			}
+			close(ch)
+			session := &fakeSession{ctx: context.Background()}
+			err := h.ConsumeClaim(session, &fakeClaim{msgs: ch})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBatches, batches)
+			assert.Equal(t, tc.wantMarked, session.marked)
+		})
+	}
+}
+
+func TestBatchHandler_SessionDone(t *testing.T) {
+	h := NewBatchHandler[testEvent](func(msgs []*sarama.ConsumerMessage, events []testEvent) error {
+		return errors.New("db error")
+	}, logger.NewNopLogger(), WithRetry(RetryConfig{
+		Initial: 10 * time.Millisecond,
+		Max:     10 * time.Millisecond,
+	}))
+	h.BatchDuration = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	ch := make(chan *sarama.ConsumerMessage, 1)
+	ch <- testMsgs(t, 1)[0]
+	session := &fakeSession{ctx: ctx}
+	err := h.ConsumeClaim(session, &fakeClaim{msgs: ch})
+	assert.NoError(t, err)
+	// 一直失败，会话结束的时候不能提交
+	assert.Empty(t, session.marked)
+}
+
+func testMsgs(t *testing.T, ids ...int64) []*sarama.ConsumerMessage {
+	res := make([]*sarama.ConsumerMessage, 0, len(ids))
+	for idx, id := range ids {
+		val, err := json.Marshal(testEvent{Id: id})
+		assert.NoError(t, err)
+		res = append(res, &sarama.ConsumerMessage{Value: val, Offset: int64(idx)})
+	}
+	return res
+}
+
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (f *fakeSession) Context() context.Context {
+	return f.ctx
+}
+
+func (f *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, metadata string) {
+	f.marked = append(f.marked, msg.Offset)
+}
+
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	msgs chan *sarama.ConsumerMessage
+}
+
+func (f *fakeClaim) Messages() <-chan *sarama.ConsumerMessage {
+	return f.msgs
+}
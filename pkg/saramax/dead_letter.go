@@ -0,0 +1,86 @@
+package saramax
+
+import (
+	"github.com/IBM/sarama"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 死信消息的 header，记录原始位置和失败原因
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+	HeaderRetries           = "x-retries"
+	HeaderFailedAt          = "x-failed-at"
+	// HeaderReplayCount 被重新投递过几次，重放的时候带回原 topic
+	HeaderReplayCount = "x-replay-count"
+)
+
+// DeadLetterTopic 每个 topic 对应的死信 topic
+func DeadLetterTopic(topic string) string {
+	return topic + "_dlq"
+}
+
+type DeadLetter interface {
+	// Send 把原始消息投递到死信 topic，cause 和 retries 放在 header 里面
+	Send(msg *sarama.ConsumerMessage, cause error, retries int) error
+}
+
+type SaramaDeadLetter struct {
+	producer sarama.SyncProducer
+}
+
+func NewSaramaDeadLetter(producer sarama.SyncProducer) DeadLetter {
+	return &SaramaDeadLetter{
+		producer: producer,
+	}
+}
+
+func (s *SaramaDeadLetter) Send(msg *sarama.ConsumerMessage, cause error, retries int) error {
+	headers := copyHeaders(msg.Headers, true)
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(HeaderOriginalTopic), Value: []byte(msg.Topic)},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalPartition), Value: []byte(strconv.FormatInt(int64(msg.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(HeaderOriginalOffset), Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		sarama.RecordHeader{Key: []byte(HeaderError), Value: []byte(cause.Error())},
+		sarama.RecordHeader{Key: []byte(HeaderRetries), Value: []byte(strconv.Itoa(retries))},
+		sarama.RecordHeader{Key: []byte(HeaderFailedAt), Value: []byte(strconv.FormatInt(time.Now().UnixMilli(), 10))},
+	)
+	_, _, err := s.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   DeadLetterTopic(msg.Topic),
+		Key:     sarama.ByteEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	})
+	return err
+}
+
+// copyHeaders 去掉上一次死信留下的 header，keepReplay 为 true 的时候保留重放次数
+func copyHeaders(src []*sarama.RecordHeader, keepReplay bool) []sarama.RecordHeader {
+	res := make([]sarama.RecordHeader, 0, len(src)+6)
+	for _, h := range src {
+		key := string(h.Key)
+		if key == HeaderReplayCount && keepReplay {
+			res = append(res, *h)
+			continue
+		}
+		if strings.HasPrefix(key, "x-original-") || key == HeaderError ||
+			key == HeaderRetries || key == HeaderFailedAt || key == HeaderReplayCount {
+			continue
+		}
+		res = append(res, *h)
+	}
+	return res
+}
+
+func headerValue(headers []*sarama.RecordHeader, key string) string {
+	for _, h := range headers {
+		if string(h.Key) == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
@@ -7,8 +7,9 @@ import (
 )
 
 type Handler[T any] struct {
-	fn func(msg *sarama.ConsumerMessage, event T) error
-	l  logger.ZapLogger
+	fn  func(msg *sarama.ConsumerMessage, event T) error
+	l   logger.Logger
+	cfg handlerConfig
 }
 
 func (h *Handler[T]) Setup(session sarama.ConsumerGroupSession) error {
@@ -26,12 +27,20 @@ func (h *Handler[T]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sar
 		var t T
 		err := json.Unmarshal(msg.Value, &t)
 		if err != nil {
-			// 记录错误日志,可以引入重试
+			// 格式不对重试也没用，有死信就直接投递
 			h.l.Error("json unmarshal error",
 				logger.String("topic", msg.Topic),
 				logger.Int32("partition", msg.Partition),
 				logger.Int64("offset", msg.Offset),
 				logger.Error(err))
+			if h.cfg.dlq != nil && !h.cfg.deadLetter(session.Context(), h.l, []*sarama.ConsumerMessage{msg}, err, 0) {
+				return nil
+			}
+		} else if !h.cfg.process(session.Context(), h.l, []*sarama.ConsumerMessage{msg}, func() error {
+			return h.fn(msg, t)
+		}) {
+			// 会话结束了，这条消息不提交
+			return nil
 		}
 		// 消费完成
 		session.MarkMessage(msg, "")
@@ -39,9 +48,10 @@ func (h *Handler[T]) ConsumeClaim(session sarama.ConsumerGroupSession, claim sar
 	return nil
 }
 
-func NewHandler[T any](fn func(msg *sarama.ConsumerMessage, event T) error, l logger.ZapLogger) *Handler[T] {
+func NewHandler[T any](fn func(msg *sarama.ConsumerMessage, event T) error, l logger.Logger, opts ...Option) *Handler[T] {
 	return &Handler[T]{
-		fn: fn,
-		l:  l,
+		fn:  fn,
+		l:   l,
+		cfg: newHandlerConfig(opts),
 	}
 }
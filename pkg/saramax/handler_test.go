@@ -0,0 +1,110 @@
+package saramax
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+	"webook/pkg/logger"
+)
+
+func TestHandler_ConsumeClaim(t *testing.T) {
+	testCases := []struct {
+		name string
+		msgs []*sarama.ConsumerMessage
+		// 业务函数前几次返回的错误
+		errs      []error
+		wantCalls int
+		// 投递到死信的 offset
+		wantDead   []int64
+		wantMarked []int64
+	}{
+		{
+			name:       "重试之后成功",
+			msgs:       testMsgs(t, 1),
+			errs:       []error{errors.New("db error"), errors.New("db error")},
+			wantCalls:  3,
+			wantMarked: []int64{0},
+		},
+		{
+			name: "重试耗尽投递死信",
+			msgs: testMsgs(t, 1, 2),
+			errs: []error{errors.New("db error"), errors.New("db error"),
+				errors.New("db error"), errors.New("db error")},
+			// 第一条 1 + 3 次重试，第二条一次成功
+			wantCalls:  5,
+			wantDead:   []int64{0},
+			wantMarked: []int64{0, 1},
+		},
+		{
+			name:       "格式不对直接投递死信",
+			msgs:       []*sarama.ConsumerMessage{{Value: []byte("bad"), Offset: 0}},
+			wantDead:   []int64{0},
+			wantMarked: []int64{0},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			calls := 0
+			dlq := &fakeDeadLetter{}
+			h := NewHandler[testEvent](func(msg *sarama.ConsumerMessage, event testEvent) error {
+				calls++
+				if calls <= len(tc.errs) {
+					return tc.errs[calls-1]
+				}
+				return nil
+			}, logger.NewNopLogger(), WithRetry(RetryConfig{
+				MaxRetries: 3,
+				Initial:    time.Millisecond,
+				Max:        time.Millisecond,
+			}), WithDeadLetter(dlq))
+			ch := make(chan *sarama.ConsumerMessage, len(tc.msgs))
+			for _, msg := range tc.msgs {
+				ch <- msg
+			}
+			close(ch)
+			session := &fakeSession{ctx: context.Background()}
+			err := h.ConsumeClaim(session, &fakeClaim{msgs: ch})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantCalls, calls)
+			assert.Equal(t, tc.wantDead, dlq.offsets)
+			assert.Equal(t, tc.wantMarked, session.marked)
+		})
+	}
+}
+
+func TestRetryConfig_interval(t *testing.T) {
+	cfg := RetryConfig{Initial: 100 * time.Millisecond, Max: time.Second}
+	assert.Equal(t, 100*time.Millisecond, cfg.interval(0))
+	assert.Equal(t, 200*time.Millisecond, cfg.interval(1))
+	assert.Equal(t, 800*time.Millisecond, cfg.interval(3))
+	assert.Equal(t, time.Second, cfg.interval(4))
+	assert.Equal(t, time.Second, cfg.interval(100))
+}
+
+func TestCopyHeaders(t *testing.T) {
+	src := []*sarama.RecordHeader{
+		{Key: []byte("trace_id"), Value: []byte("abc")},
+		{Key: []byte(HeaderOriginalTopic), Value: []byte("article_read")},
+		{Key: []byte(HeaderError), Value: []byte("db error")},
+		{Key: []byte(HeaderReplayCount), Value: []byte("1")},
+	}
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte("trace_id"), Value: []byte("abc")},
+		{Key: []byte(HeaderReplayCount), Value: []byte("1")},
+	}, copyHeaders(src, true))
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte("trace_id"), Value: []byte("abc")},
+	}, copyHeaders(src, false))
+}
+
+type fakeDeadLetter struct {
+	offsets []int64
+}
+
+func (f *fakeDeadLetter) Send(msg *sarama.ConsumerMessage, cause error, retries int) error {
+	f.offsets = append(f.offsets, msg.Offset)
+	return nil
+}
@@ -0,0 +1,159 @@
+package saramax
+
+import (
+	"context"
+	"errors"
+	"github.com/IBM/sarama"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"webook/pkg/logger"
+)
+
+// Replayer 把死信 topic 里面的消息重新投递回原来的 topic
+// 用消费者组记录进度，同一条死信只会重放一次
+type Replayer struct {
+	client   sarama.Client
+	producer sarama.SyncProducer
+	l        logger.Logger
+	// GroupId 重放进度所在的消费者组
+	GroupId string
+}
+
+func NewReplayer(client sarama.Client, producer sarama.SyncProducer, l logger.Logger) *Replayer {
+	return &Replayer{
+		client:   client,
+		producer: producer,
+		l:        l,
+		GroupId:  "dlq_replay",
+	}
+}
+
+// Replay 重放 dlqTopic 里面到调用时为止的消息，返回重放的条数
+// 重放过程中新进来的死信留到下一次
+func (r *Replayer) Replay(ctx context.Context, dlqTopic string) (int64, error) {
+	cg, err := sarama.NewConsumerGroupFromClient(r.GroupId, r.client)
+	if err != nil {
+		return 0, err
+	}
+	defer cg.Close()
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	h := &replayHandler{r: r, cancel: cancel}
+	err = cg.Consume(ctx, []string{dlqTopic}, h)
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return h.cnt.Load(), err
+	}
+	if h.err != nil {
+		return h.cnt.Load(), h.err
+	}
+	return h.cnt.Load(), ctx.Err()
+}
+
+type replayHandler struct {
+	r      *Replayer
+	cancel context.CancelFunc
+	cnt    atomic.Int64
+
+	mu      sync.Mutex
+	pending int
+	err     error
+}
+
+func (h *replayHandler) Setup(session sarama.ConsumerGroupSession) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, partitions := range session.Claims() {
+		h.pending += len(partitions)
+	}
+	if h.pending == 0 {
+		h.cancel()
+	}
+	return nil
+}
+
+func (h *replayHandler) Cleanup(session sarama.ConsumerGroupSession) error {
+	return nil
+}
+
+func (h *replayHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	defer h.done()
+	// 只重放到开始时候的位置
+	end := claim.HighWaterMarkOffset()
+	start := claim.InitialOffset()
+	if start == sarama.OffsetOldest {
+		var err error
+		start, err = h.r.client.GetOffset(claim.Topic(), claim.Partition(), sarama.OffsetOldest)
+		if err != nil {
+			h.fail(err)
+			return err
+		}
+	}
+	// 没有新的死信，不然会一直阻塞在 Messages 上面
+	// 新的消费者组要把 Consumer.Offsets.Initial 配置成 OffsetOldest，不然什么都不会重放
+	if start < 0 || start >= end {
+		return nil
+	}
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+			err := h.replay(msg)
+			if err != nil {
+				h.fail(err)
+				return err
+			}
+			h.cnt.Add(1)
+			session.MarkMessage(msg, "")
+			if msg.Offset >= end-1 {
+				return nil
+			}
+		}
+	}
+}
+
+func (h *replayHandler) replay(msg *sarama.ConsumerMessage) error {
+	topic := headerValue(msg.Headers, HeaderOriginalTopic)
+	if topic == "" {
+		h.r.l.Warn("死信没有原始 topic，跳过",
+			logger.String("topic", msg.Topic),
+			logger.Int64("offset", msg.Offset))
+		return nil
+	}
+	cnt, _ := strconv.Atoi(headerValue(msg.Headers, HeaderReplayCount))
+	headers := copyHeaders(msg.Headers, false)
+	headers = append(headers, sarama.RecordHeader{
+		Key:   []byte(HeaderReplayCount),
+		Value: []byte(strconv.Itoa(cnt + 1)),
+	})
+	_, _, err := h.r.producer.SendMessage(&sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.ByteEncoder(msg.Key),
+		Value:   sarama.ByteEncoder(msg.Value),
+		Headers: headers,
+	})
+	return err
+}
+
+// done 所有分区都重放完了就结束
+func (h *replayHandler) done() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pending--
+	if h.pending <= 0 {
+		h.cancel()
+	}
+}
+
+func (h *replayHandler) fail(err error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.err == nil {
+		h.err = err
+	}
+	h.cancel()
+}
@@ -0,0 +1,126 @@
+package saramax
+
+import (
+	"context"
+	"github.com/IBM/sarama"
+	"time"
+	"webook/pkg/logger"
+)
+
+// RetryConfig 业务处理失败之后的重试策略，间隔从 Initial 开始每次翻倍，最多 Max
+type RetryConfig struct {
+	// MaxRetries 重试这么多次还失败就投递到死信 topic
+	// 没有配置死信 topic 的时候会按照 Max 的间隔一直重试，消息不会丢
+	MaxRetries int
+	Initial    time.Duration
+	Max        time.Duration
+}
+
+// DefaultRetryConfig 没有配置的时候重试 3 次，间隔 100ms 到 10s
+func DefaultRetryConfig() RetryConfig {
+	return RetryConfig{
+		MaxRetries: 3,
+		Initial:    100 * time.Millisecond,
+		Max:        10 * time.Second,
+	}
+}
+
+func (r RetryConfig) interval(retries int) time.Duration {
+	res := r.Initial
+	for i := 0; i < retries && res < r.Max; i++ {
+		res *= 2
+	}
+	if res > r.Max {
+		res = r.Max
+	}
+	return res
+}
+
+type handlerConfig struct {
+	retry RetryConfig
+	dlq   DeadLetter
+}
+
+type Option func(c *handlerConfig)
+
+func WithRetry(cfg RetryConfig) Option {
+	return func(c *handlerConfig) {
+		c.retry = cfg
+	}
+}
+
+// WithDeadLetter 重试耗尽或者消息格式不对的时候投递到死信 topic
+func WithDeadLetter(dlq DeadLetter) Option {
+	return func(c *handlerConfig) {
+		c.dlq = dlq
+	}
+}
+
+func newHandlerConfig(opts []Option) handlerConfig {
+	c := handlerConfig{
+		retry: DefaultRetryConfig(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// process 执行业务处理，失败了按照重试策略重试，重试耗尽就投递死信
+// 返回 false 说明会话已经结束，这些消息不能提交
+func (c handlerConfig) process(ctx context.Context, l logger.Logger,
+	msgs []*sarama.ConsumerMessage, fn func() error) bool {
+	for retries := 0; ; retries++ {
+		err := fn()
+		if err == nil {
+			return true
+		}
+		last := msgs[len(msgs)-1]
+		l.Error("处理消息失败",
+			logger.String("topic", last.Topic),
+			logger.Int32("partition", last.Partition),
+			logger.Int64("offset", last.Offset),
+			logger.Int("size", len(msgs)),
+			logger.Int("retries", retries),
+			logger.Error(err))
+		if retries >= c.retry.MaxRetries && c.dlq != nil {
+			return c.deadLetter(ctx, l, msgs, err, retries)
+		}
+		if !sleep(ctx, c.retry.interval(retries)) {
+			return false
+		}
+	}
+}
+
+// deadLetter 投递死信，投递失败也要重试，不然消息就丢了
+func (c handlerConfig) deadLetter(ctx context.Context, l logger.Logger,
+	msgs []*sarama.ConsumerMessage, cause error, retries int) bool {
+	for _, msg := range msgs {
+		for i := 0; ; i++ {
+			err := c.dlq.Send(msg, cause, retries)
+			if err == nil {
+				break
+			}
+			l.Error("投递死信失败",
+				logger.String("topic", msg.Topic),
+				logger.Int32("partition", msg.Partition),
+				logger.Int64("offset", msg.Offset),
+				logger.Error(err))
+			if !sleep(ctx, c.retry.interval(i)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
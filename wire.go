@@ -3,8 +3,9 @@
 package main
 
 import (
-	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	"webook/internal/domain/events/article"
+	"webook/internal/domain/events/notification"
 	"webook/internal/repository"
 	"webook/internal/repository/cache"
 	"webook/internal/repository/dao"
@@ -12,19 +13,106 @@ import (
 	"webook/internal/web"
 	"webook/internal/web/jwt"
 	"webook/ioc"
+	"webook/pkg/rlock"
 )
 
 var interactiveSvcSet = wire.NewSet(
 	dao.NewGormInteractiveDAO,
-	cache.NewInteractiveCache,
+	ioc.InitInteractiveCache,
+	cache.NewRedisReadCntBuffer,
+	ioc.InitReaderCache,
+	ioc.InitTrendingCache,
+	ioc.InitTrendingRepository,
 	repository.NewCachedInteractiveRepository,
-	service.NewInteractiveService,
+	ioc.InitReactionSet,
+	ioc.InitInteractiveService,
 )
 
-func InitWebServer() *gin.Engine {
+var commentSvcSet = wire.NewSet(
+	dao.NewGormCommentDAO,
+	repository.NewCachedCommentRepository,
+	service.NewCommentService,
+	web.NewCommentHandler,
+)
+
+var shareSvcSet = wire.NewSet(
+	dao.NewGormShareDAO,
+	repository.NewCachedShareRepository,
+	service.NewShareService,
+)
+
+var collectionSvcSet = wire.NewSet(
+	dao.NewGormCollectionDAO,
+	repository.NewCachedCollectionRepository,
+	service.NewCollectionService,
+	web.NewCollectionHandler,
+)
+
+var rankingSvcSet = wire.NewSet(
+	cache.NewRankingRedisCache,
+	cache.NewRankingLocalCache,
+	repository.NewCachedRankingRepository,
+	ioc.InitRankingService,
+	service.NewTrendingService,
+)
+
+var articleStatsSvcSet = wire.NewSet(
+	cache.NewRedisAuthorDashboardCache,
+	repository.NewCachedAuthorDashboardRepository,
+	service.NewAuthorDashboardService,
+	service.NewArticleStatsService,
+	web.NewArticleStatsHandler,
+)
+
+var followSvcSet = wire.NewSet(
+	dao.NewGormFollowDAO,
+	cache.NewRedisFollowCache,
+	repository.NewCachedFollowRepository,
+	service.NewFollowService,
+	web.NewFollowHandler,
+)
+
+var feedSvcSet = wire.NewSet(
+	ioc.InitFeedCache,
+	repository.NewCachedFeedRepository,
+	ioc.InitFeedService,
+	web.NewFeedHandler,
+)
+
+var notificationSvcSet = wire.NewSet(
+	dao.NewGormNotificationDAO,
+	cache.NewRedisNotificationCache,
+	repository.NewCachedNotificationRepository,
+	service.NewNotificationService,
+	web.NewNotificationHandler,
+)
+
+var relatedSvcSet = wire.NewSet(
+	dao.NewGormRelatedDAO,
+	cache.NewRedisRelatedCache,
+	repository.NewCachedRelatedRepository,
+	ioc.InitRelatedService,
+)
+
+var historySvcSet = wire.NewSet(
+	dao.NewGormHistoryDAO,
+	repository.NewDBHistoryRepository,
+	ioc.InitHistoryService,
+	web.NewHistoryHandler,
+)
+
+var jobSvcSet = wire.NewSet(
+	dao.NewGormJobDAO,
+	repository.NewPreemptJobRepository,
+	ioc.InitJobService,
+	ioc.InitJobHandler,
+)
+
+func InitApp() *App {
 	wire.Build(
 		//第三方依赖
-		ioc.InitLogger, ioc.InitDB, ioc.InitRedis,
+		ioc.InitLogger, ioc.InitDB, ioc.InitRedis, ioc.InitSnowflakeNode, ioc.InitCacheAside,
+		ioc.InitSaramaClient, ioc.InitSyncProducer, ioc.InitDeadLetter,
 		//dao
 		dao.NewGormUserDAO, dao.NewGormArticleDAO,
 		//cache
@@ -35,10 +123,27 @@ func InitWebServer() *gin.Engine {
 		ioc.InitSMSService, ioc.InitWechatService,
 		service.NewUserService, service.NewCodeService, service.NewArticleService,
 		//handler
-		jwt.NewRedisJWTHandler,
+		jwt.NewRedisJWTHandler, ioc.InitActionLimiter,
 		web.NewUserHandler, web.NewOAuth2WechatHandler, web.NewArticleHandler,
 		ioc.InitGinMiddleware, ioc.InitWebService,
 		interactiveSvcSet,
+		commentSvcSet,
+		shareSvcSet,
+		collectionSvcSet,
+		articleStatsSvcSet,
+		rankingSvcSet,
+		followSvcSet,
+		feedSvcSet,
+		notificationSvcSet,
+		relatedSvcSet,
+		historySvcSet,
+		jobSvcSet,
+		ioc.InitReadCntFlusher,
+		rlock.NewClient, ioc.InitCronRunner, ioc.InitScheduler,
+		notification.NewSaramaSyncProducer, ioc.InitNotificationEventConsumer,
+		article.NewSaramaSyncProducer, ioc.InitInteractiveReadEventConsumer, ioc.InitHistoryReadEventConsumer,
+		ioc.InitConsumers,
+		wire.Struct(new(App), "server", "consumers", "readCntFlusher", "cron", "scheduler"),
 	)
-	return gin.Default()
+	return new(App)
 }
@@ -7,8 +7,9 @@
 package main
 
 import (
-	"github.com/gin-gonic/gin"
 	"github.com/google/wire"
+	"webook/internal/domain/events/article"
+	"webook/internal/domain/events/notification"
 	"webook/internal/repository"
 	"webook/internal/repository/cache"
 	"webook/internal/repository/dao"
@@ -16,6 +17,7 @@ import (
 	"webook/internal/web"
 	"webook/internal/web/jwt"
 	"webook/ioc"
+	"webook/pkg/rlock"
 )
 
 import (
@@ -24,7 +26,7 @@ import (
 
 // Injectors from wire.go:
 
-func InitWebServer() *gin.Engine {
+func InitApp() *App {
 	cmdable := ioc.InitRedis()
 	handler := jwt.NewRedisJWTHandler(cmdable)
 	logger := ioc.InitLogger()
@@ -32,28 +34,125 @@ func InitWebServer() *gin.Engine {
 	db := ioc.InitDB(logger)
 	userDAO := dao.NewGormUserDAO(db)
 	userCache := cache.NewRedisUserCache(cmdable)
-	userRepository := repository.NewCacheUserRepository(userDAO, userCache)
+	aside := ioc.InitCacheAside(cmdable)
+	userRepository := repository.NewCacheUserRepository(userDAO, userCache, aside)
 	userService := service.NewUserService(userRepository)
 	codeCache := cache.NewRedisCodeCache(cmdable)
 	codeRepository := repository.NewCodeRepository(codeCache)
 	localsmsService := ioc.InitSMSService()
 	codeService := service.NewCodeService(codeRepository, localsmsService)
-	userHandler := web.NewUserHandler(userService, codeService, handler)
+	followDAO := dao.NewGormFollowDAO(db)
+	followCache := cache.NewRedisFollowCache(cmdable)
+	followRepository := repository.NewCachedFollowRepository(followDAO, followCache, aside)
+	client := ioc.InitSaramaClient()
+	syncProducer := ioc.InitSyncProducer(client)
+	producer := notification.NewSaramaSyncProducer(syncProducer)
+	followService := service.NewFollowService(followRepository, producer, logger)
+	userHandler := web.NewUserHandler(userService, codeService, followService, handler)
 	wechatService := ioc.InitWechatService(logger)
 	oAuth2WechatHandler := web.NewOAuth2WechatHandler(wechatService, userService, handler)
 	articleDAO := dao.NewGormArticleDAO(db)
 	articleCache := cache.NewArticleRedisCache(cmdable)
-	articleRepository := repository.NewCachedArticleRepository(articleDAO, articleCache)
-	articleService := service.NewArticleService(articleRepository, logger)
+	articleRepository := repository.NewCachedArticleRepository(articleDAO, articleCache, userRepository, aside)
+	articleProducer := article.NewSaramaSyncProducer(syncProducer)
+	feedCache := ioc.InitFeedCache(cmdable)
+	feedRepository := repository.NewCachedFeedRepository(feedCache)
+	feedService := ioc.InitFeedService(feedRepository, followRepository, articleRepository, logger)
+	articleService := service.NewArticleService(articleRepository, articleProducer, feedService, logger)
 	interactiveDAO := dao.NewGormInteractiveDAO(db)
-	interactiveCache := cache.NewInteractiveCache(cmdable)
-	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache)
-	interactiveService := service.NewInteractiveService(interactiveRepository)
-	articleHandler := web.NewArticleHandler(articleService, logger, interactiveService)
-	engine := ioc.InitWebService(v, userHandler, oAuth2WechatHandler, articleHandler)
-	return engine
+	interactiveCache := ioc.InitInteractiveCache(cmdable, logger)
+	readCntBuffer := cache.NewRedisReadCntBuffer(cmdable)
+	readerCache := ioc.InitReaderCache(cmdable)
+	trendingCache := ioc.InitTrendingCache(cmdable)
+	trendingRepository := ioc.InitTrendingRepository(trendingCache)
+	interactiveRepository := repository.NewCachedInteractiveRepository(interactiveDAO, interactiveCache, readCntBuffer, readerCache, aside, trendingRepository, logger)
+	reactionSet := ioc.InitReactionSet()
+	interactiveService := ioc.InitInteractiveService(interactiveRepository, reactionSet, producer, logger)
+	shareDAO := dao.NewGormShareDAO(db)
+	shareRepository := repository.NewCachedShareRepository(shareDAO, interactiveCache)
+	node := ioc.InitSnowflakeNode()
+	shareService := service.NewShareService(shareRepository, articleRepository, node)
+	rankingCache := cache.NewRankingRedisCache(cmdable)
+	rankingLocalCache := cache.NewRankingLocalCache()
+	rankingRepository := repository.NewCachedRankingRepository(rankingCache, rankingLocalCache)
+	rankingService := ioc.InitRankingService(articleService, interactiveService, rankingRepository)
+	trendingService := service.NewTrendingService(trendingRepository, articleService)
+	relatedDAO := dao.NewGormRelatedDAO(db)
+	relatedCache := cache.NewRedisRelatedCache(cmdable)
+	relatedRepository := repository.NewCachedRelatedRepository(relatedDAO, relatedCache)
+	relatedService := ioc.InitRelatedService(relatedRepository, articleRepository, rankingService, logger)
+	actionLimiter := ioc.InitActionLimiter(cmdable, logger)
+	articleHandler := web.NewArticleHandler(articleService, logger, interactiveService, shareService, userService, rankingService, trendingService, followService, relatedService, actionLimiter)
+	commentDAO := dao.NewGormCommentDAO(db)
+	commentRepository := repository.NewCachedCommentRepository(commentDAO, interactiveCache)
+	commentService := service.NewCommentService(commentRepository, articleRepository, producer, logger)
+	commentHandler := web.NewCommentHandler(commentService, actionLimiter, logger)
+	collectionDAO := dao.NewGormCollectionDAO(db)
+	collectionRepository := repository.NewCachedCollectionRepository(collectionDAO, interactiveCache)
+	collectionService := service.NewCollectionService(collectionRepository, articleRepository)
+	collectionHandler := web.NewCollectionHandler(collectionService, logger)
+	articleStatsService := service.NewArticleStatsService(interactiveRepository, articleRepository)
+	authorDashboardCache := cache.NewRedisAuthorDashboardCache(cmdable)
+	authorDashboardRepository := repository.NewCachedAuthorDashboardRepository(authorDashboardCache)
+	authorDashboardService := service.NewAuthorDashboardService(authorDashboardRepository, interactiveRepository, articleRepository, followRepository, logger)
+	articleStatsHandler := web.NewArticleStatsHandler(articleStatsService, authorDashboardService, logger)
+	followHandler := web.NewFollowHandler(followService, logger)
+	feedHandler := web.NewFeedHandler(feedService, logger)
+	notificationDAO := dao.NewGormNotificationDAO(db)
+	notificationCache := cache.NewRedisNotificationCache(cmdable)
+	notificationRepository := repository.NewCachedNotificationRepository(notificationDAO, notificationCache, aside)
+	notificationService := service.NewNotificationService(notificationRepository)
+	notificationHandler := web.NewNotificationHandler(notificationService, logger)
+	historyDAO := dao.NewGormHistoryDAO(db)
+	historyRepository := repository.NewDBHistoryRepository(historyDAO)
+	historyService := ioc.InitHistoryService(historyRepository, articleRepository)
+	historyHandler := web.NewHistoryHandler(historyService, logger)
+	jobDAO := dao.NewGormJobDAO(db)
+	jobRepository := repository.NewPreemptJobRepository(jobDAO)
+	jobService := ioc.InitJobService(jobRepository)
+	jobHandler := ioc.InitJobHandler(jobService, logger)
+	engine := ioc.InitWebService(v, userHandler, oAuth2WechatHandler, articleHandler, commentHandler, collectionHandler, articleStatsHandler, followHandler, feedHandler, notificationHandler, historyHandler, jobHandler)
+	deadLetter := ioc.InitDeadLetter(syncProducer)
+	interactiveReadEventConsumer := ioc.InitInteractiveReadEventConsumer(interactiveRepository, client, deadLetter, logger)
+	notificationEventConsumer := ioc.InitNotificationEventConsumer(notificationRepository, articleRepository, client, deadLetter, logger)
+	historyReadEventConsumer := ioc.InitHistoryReadEventConsumer(historyRepository, client, deadLetter, logger)
+	v2 := ioc.InitConsumers(interactiveReadEventConsumer, notificationEventConsumer, historyReadEventConsumer)
+	readCntFlusher := ioc.InitReadCntFlusher(interactiveRepository, logger)
+	rlockClient := rlock.NewClient(cmdable)
+	cronRunner := ioc.InitCronRunner(rlockClient, rankingService, relatedService, logger)
+	scheduler := ioc.InitScheduler(jobService, rankingService, relatedService, logger)
+	app := &App{
+		server:         engine,
+		consumers:      v2,
+		readCntFlusher: readCntFlusher,
+		cron:           cronRunner,
+		scheduler:      scheduler,
+	}
+	return app
 }
 
 // wire.go:
 
-var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDAO, cache.NewInteractiveCache, repository.NewCachedInteractiveRepository, service.NewInteractiveService)
+var interactiveSvcSet = wire.NewSet(dao.NewGormInteractiveDAO, ioc.InitInteractiveCache, cache.NewRedisReadCntBuffer, ioc.InitReaderCache, ioc.InitTrendingCache, ioc.InitTrendingRepository, repository.NewCachedInteractiveRepository, ioc.InitReactionSet, ioc.InitInteractiveService)
+
+var commentSvcSet = wire.NewSet(dao.NewGormCommentDAO, repository.NewCachedCommentRepository, service.NewCommentService, web.NewCommentHandler)
+
+var shareSvcSet = wire.NewSet(dao.NewGormShareDAO, repository.NewCachedShareRepository, service.NewShareService)
+
+var collectionSvcSet = wire.NewSet(dao.NewGormCollectionDAO, repository.NewCachedCollectionRepository, service.NewCollectionService, web.NewCollectionHandler)
+
+var rankingSvcSet = wire.NewSet(cache.NewRankingRedisCache, cache.NewRankingLocalCache, repository.NewCachedRankingRepository, ioc.InitRankingService, service.NewTrendingService)
+
+var articleStatsSvcSet = wire.NewSet(cache.NewRedisAuthorDashboardCache, repository.NewCachedAuthorDashboardRepository, service.NewAuthorDashboardService, service.NewArticleStatsService, web.NewArticleStatsHandler)
+
+var followSvcSet = wire.NewSet(dao.NewGormFollowDAO, cache.NewRedisFollowCache, repository.NewCachedFollowRepository, service.NewFollowService, web.NewFollowHandler)
+
+var feedSvcSet = wire.NewSet(ioc.InitFeedCache, repository.NewCachedFeedRepository, ioc.InitFeedService, web.NewFeedHandler)
+
+var notificationSvcSet = wire.NewSet(dao.NewGormNotificationDAO, cache.NewRedisNotificationCache, repository.NewCachedNotificationRepository, service.NewNotificationService, web.NewNotificationHandler)
+
+var relatedSvcSet = wire.NewSet(dao.NewGormRelatedDAO, cache.NewRedisRelatedCache, repository.NewCachedRelatedRepository, ioc.InitRelatedService)
+
+var historySvcSet = wire.NewSet(dao.NewGormHistoryDAO, repository.NewDBHistoryRepository, ioc.InitHistoryService, web.NewHistoryHandler)
+
+var jobSvcSet = wire.NewSet(dao.NewGormJobDAO, repository.NewPreemptJobRepository, ioc.InitJobService, ioc.InitJobHandler)